@@ -5,10 +5,22 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	activityH "github.com/TeaStealers-backend-sem4/internal/activity/delivery"
+	activityRep "github.com/TeaStealers-backend-sem4/internal/activity/repo"
+	activityUc "github.com/TeaStealers-backend-sem4/internal/activity/usecase"
 	audioHl "github.com/TeaStealers-backend-sem4/internal/audio/delivery"
+	classH "github.com/TeaStealers-backend-sem4/internal/classroom/delivery"
+	classRep "github.com/TeaStealers-backend-sem4/internal/classroom/repo"
+	classUc "github.com/TeaStealers-backend-sem4/internal/classroom/usecase"
+	gameH "github.com/TeaStealers-backend-sem4/internal/gamification/delivery"
+	gameRep "github.com/TeaStealers-backend-sem4/internal/gamification/repo"
+	gameUc "github.com/TeaStealers-backend-sem4/internal/gamification/usecase"
 	moduleH "github.com/TeaStealers-backend-sem4/internal/module/delivery"
 	moduleRep "github.com/TeaStealers-backend-sem4/internal/module/repo"
 	moduleUc "github.com/TeaStealers-backend-sem4/internal/module/usecase"
+	notificationH "github.com/TeaStealers-backend-sem4/internal/notification/delivery"
+	notificationRep "github.com/TeaStealers-backend-sem4/internal/notification/repo"
+	notificationUc "github.com/TeaStealers-backend-sem4/internal/notification/usecase"
 	wordH "github.com/TeaStealers-backend-sem4/internal/word/delivery"
 
 	wordRep "github.com/TeaStealers-backend-sem4/internal/word/repo"
@@ -18,6 +30,7 @@ import (
 	middleware "github.com/TeaStealers-backend-sem4/pkg/middleware"
 	minioS "github.com/TeaStealers-backend-sem4/pkg/minio"
 	minioH "github.com/TeaStealers-backend-sem4/pkg/minio/delivery"
+	"github.com/TeaStealers-backend-sem4/pkg/phonetics"
 	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
 
 	authH "github.com/TeaStealers-backend-sem4/internal/auth/delivery"
@@ -75,9 +88,25 @@ func main() {
 	minioStorageClient := utils.NewFileStorageClient(cfg.MinCli.AddressPort)
 
 	wRepo := wordRep.NewRepository(db, logr)
-	wordUsecase := wordUc.NewWordUsecase(wRepo, logr)
-	audioHandler := audioHl.NewAudioHandler(cfg, logr)
+	scorer := phonetics.NewScorer(cfg.Scoring.PassThreshold)
+	wordUsecase := wordUc.NewWordUsecase(wRepo, logr, scorer, cfg)
+	audioHandler := audioHl.NewAudioHandler(cfg, logr, scorer)
 	wordHandler := wordH.NewWordHandler(wordUsecase, cfg, logr, minioStorageClient)
+	actRepo := activityRep.NewRepository(db, logr)
+	actUsecase := activityUc.NewActivityUsecase(actRepo, logr, cfg)
+	activityHandler := activityH.NewActivityHandler(actUsecase, logr)
+	gamRepo := gameRep.NewRepository(db, logr)
+	gamUsecase := gameUc.NewGamificationUsecase(gamRepo, logr, cfg)
+	gamificationHandler := gameH.NewGamificationHandler(gamUsecase, logr)
+	// серия должна обновиться раньше, чем проверяются достижения за неё
+	wordUsecase.AddAttemptListener(actUsecase)
+	wordUsecase.AddAttemptListener(gamUsecase)
+	notifRepo := notificationRep.NewRepository(db, logr)
+	notifUsecase := notificationUc.NewNotificationUsecase(notifRepo, logr)
+	notificationHandler := notificationH.NewNotificationHandler(notifUsecase, logr)
+	clsRepo := classRep.NewRepository(db, logr)
+	clsUsecase := classUc.NewClassroomUsecase(clsRepo, notifUsecase, logr, cfg)
+	classroomHandler := classH.NewClassroomHandler(clsUsecase, logr, minioStorageClient)
 	modulRep := moduleRep.NewRepository(db, logr)
 	modulUc := moduleUc.NewModuleUsecase(modulRep, logr)
 	modulHandler := moduleH.NewModuleHandler(modulUc, cfg, logr)
@@ -90,6 +119,27 @@ func main() {
 	r.Handle("/logout", middleware.JwtMiddleware(http.HandlerFunc(autHandler.Logout), authRepo)).Methods(http.MethodGet, http.MethodOptions)
 	r.Handle("/change-password", middleware.JwtMiddleware(http.HandlerFunc(autHandler.UpdateUserPassword), authRepo)).Methods(http.MethodPost, http.MethodOptions)
 	r.Handle("/me", middleware.JwtMiddleware(http.HandlerFunc(autHandler.MeHandler), authRepo)).Methods(http.MethodGet)
+	r.Handle("/me/phonemes", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.GetUserPhonemesHandler), authRepo)).Methods(http.MethodGet)
+	r.Handle("/me/progress", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.GetProgressDashboardHandler), authRepo)).Methods(http.MethodGet)
+	r.Handle("/me/activity", middleware.JwtMiddleware(http.HandlerFunc(activityHandler.GetActivityHandler), authRepo)).Methods(http.MethodGet)
+	r.Handle("/me/activity/settings", middleware.JwtMiddleware(http.HandlerFunc(activityHandler.UpdateSettingsHandler), authRepo)).Methods(http.MethodPut)
+	r.Handle("/me/achievements", middleware.JwtMiddleware(http.HandlerFunc(gamificationHandler.GetAchievementsHandler), authRepo)).Methods(http.MethodGet)
+	r.Handle("/me/leaderboard-settings", middleware.JwtMiddleware(http.HandlerFunc(gamificationHandler.UpdateLeaderboardSettingsHandler), authRepo)).Methods(http.MethodPut)
+	r.Handle("/me/friends", middleware.JwtMiddleware(http.HandlerFunc(gamificationHandler.AddFriendHandler), authRepo)).Methods(http.MethodPost)
+	r.Handle("/me/friends", middleware.JwtMiddleware(http.HandlerFunc(gamificationHandler.RemoveFriendHandler), authRepo)).Methods(http.MethodDelete)
+	r.Handle("/me/friends/requests", middleware.JwtMiddleware(http.HandlerFunc(gamificationHandler.GetFriendRequestsHandler), authRepo)).Methods(http.MethodGet)
+	r.Handle("/leaderboards/{scope}", middleware.JwtMiddleware(http.HandlerFunc(gamificationHandler.GetLeaderboardHandler), authRepo)).Methods(http.MethodGet)
+	r.Handle("/me/notifications", middleware.JwtMiddleware(http.HandlerFunc(notificationHandler.GetNotificationsHandler), authRepo)).Methods(http.MethodGet)
+	r.Handle("/me/notifications/read-all", middleware.JwtMiddleware(http.HandlerFunc(notificationHandler.MarkAllReadHandler), authRepo)).Methods(http.MethodPost)
+	r.Handle("/me/notifications/{id:[0-9]+}/read", middleware.JwtMiddleware(http.HandlerFunc(notificationHandler.MarkReadHandler), authRepo)).Methods(http.MethodPost)
+	r.Handle("/me/bookmarks", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.GetBookmarksHandler), authRepo)).Methods(http.MethodGet)
+	r.Handle("/me/bookmarks", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.AddBookmarkHandler), authRepo)).Methods(http.MethodPost)
+	r.Handle("/me/bookmarks/exercises", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.GetBookmarkExercisesHandler), authRepo)).Methods(http.MethodGet)
+	r.Handle("/me/bookmarks/{id:[0-9]+}", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.UpdateBookmarkNoteHandler), authRepo)).Methods(http.MethodPut)
+	r.Handle("/me/bookmarks/{id:[0-9]+}", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.DeleteBookmarkHandler), authRepo)).Methods(http.MethodDelete)
+	r.Handle("/me/content-settings", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.GetContentSettingsHandler), authRepo)).Methods(http.MethodGet)
+	r.Handle("/me/content-settings", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.UpdateContentSettingsHandler), authRepo)).Methods(http.MethodPut)
+	r.Handle("/me/next-exercises", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.GetNextExercisesHandler), authRepo)).Methods(http.MethodGet)
 	//r.HandleFunc("/check_auth", autHandler.CheckAuth).Methods(http.MethodGet, http.MethodOptions)
 
 	r.Handle("/current-word-module",
@@ -102,9 +152,24 @@ func main() {
 
 	r.Handle("/word-exercises", http.HandlerFunc(wordHandler.CreateWordExerciseHandler)).Methods(http.MethodPost)
 	r.Handle("/phrases-exercises", http.HandlerFunc(wordHandler.CreatePhraseExerciseHandler)).Methods(http.MethodPost)
+	r.Handle("/authoring/transcribe", http.HandlerFunc(wordHandler.TranscribeHandler)).Methods(http.MethodPost, http.MethodOptions)
+	r.Handle("/word-exercises/{id}/pronounce",
+		middleware.JwtMiddleware(http.HandlerFunc(wordHandler.PronounceWordExerciseHandler), authRepo)).Methods(http.MethodPost, http.MethodOptions)
+	r.Handle("/phrase-exercises/{id}/pronounce",
+		middleware.JwtMiddleware(http.HandlerFunc(wordHandler.PronouncePhraseExerciseHandler), authRepo)).Methods(http.MethodPost, http.MethodOptions)
+	r.Handle("/word-exercises/{id:[0-9]+}/words/{index:[0-9]+}/accents/{accent}",
+		middleware.JwtMiddleware(http.HandlerFunc(wordHandler.SetWordVariantHandler), authRepo)).Methods(http.MethodPut)
+	r.Handle("/word-exercises/{id:[0-9]+}/words/{index:[0-9]+}/accents/{accent}",
+		middleware.JwtMiddleware(http.HandlerFunc(wordHandler.DeleteWordVariantHandler), authRepo)).Methods(http.MethodDelete)
+	r.Handle("/phrase-exercises/{id:[0-9]+}/accents/{accent}",
+		middleware.JwtMiddleware(http.HandlerFunc(wordHandler.SetPhraseVariantHandler), authRepo)).Methods(http.MethodPut)
+	r.Handle("/phrase-exercises/{id:[0-9]+}/accents/{accent}",
+		middleware.JwtMiddleware(http.HandlerFunc(wordHandler.DeletePhraseVariantHandler), authRepo)).Methods(http.MethodDelete)
 
 	r.Handle("/exercise-progress",
 		middleware.JwtMiddleware(http.HandlerFunc(wordHandler.UpdateProgressHandler), authRepo)).Methods(http.MethodPost)
+	r.Handle("/exercises/{id}/attempts",
+		middleware.JwtMiddleware(http.HandlerFunc(wordHandler.GetExerciseAttemptsHandler), authRepo)).Methods(http.MethodGet)
 
 	r.Handle("/word-modules", http.HandlerFunc(wordHandler.WordModulesHandler)).Methods(http.MethodGet)
 	r.Handle("/phrase-modules", http.HandlerFunc(wordHandler.PhraseModulesHandler)).Methods(http.MethodGet)
@@ -114,13 +179,72 @@ func main() {
 	r.Handle("/phrase-modules/{id}/exercises",
 		middleware.JwtMiddlewareOptional(http.HandlerFunc(wordHandler.GetPhraseModuleExercisesHandler), authRepo)).Methods(http.MethodGet)
 
+	r.Handle("/search",
+		middleware.JwtMiddlewareOptional(http.HandlerFunc(wordHandler.SearchHandler), authRepo)).Methods(http.MethodGet)
+
 	r.Handle("/transcribe-word", http.HandlerFunc(audioHandler.TranscribeWordHandler)).Methods(http.MethodPost, http.MethodOptions)
 	r.Handle("/transcribe-phrase", http.HandlerFunc(audioHandler.TranscribePhraseHandler)).Methods(http.MethodPost, http.MethodOptions)
 
+	placement := r.PathPrefix("/placement").Subrouter()
+	placement.Handle("", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.StartPlacementHandler), authRepo)).Methods(http.MethodPost)
+	placement.Handle("/{id:[0-9]+}", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.GetPlacementHandler), authRepo)).Methods(http.MethodGet)
+	placement.Handle("/{id:[0-9]+}/answers", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.AnswerPlacementHandler), authRepo)).Methods(http.MethodPost)
+
+	review := r.PathPrefix("/review").Subrouter()
+	review.Handle("/queue", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.GetReviewQueueHandler), authRepo)).Methods(http.MethodGet)
+	review.Handle("/settings", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.GetReviewSettingsHandler), authRepo)).Methods(http.MethodGet)
+	review.Handle("/settings", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.UpdateReviewSettingsHandler), authRepo)).Methods(http.MethodPut)
+	review.Handle("/{id:[0-9]+}", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.GradeReviewHandler), authRepo)).Methods(http.MethodPost)
+
+	decks := r.PathPrefix("/decks").Subrouter()
+	decks.Handle("", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.GetDecksHandler), authRepo)).Methods(http.MethodGet)
+	decks.Handle("", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.CreateDeckHandler), authRepo)).Methods(http.MethodPost)
+	decks.Handle("/{id:[0-9]+}", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.GetDeckHandler), authRepo)).Methods(http.MethodGet)
+	decks.Handle("/{id:[0-9]+}", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.RenameDeckHandler), authRepo)).Methods(http.MethodPut)
+	decks.Handle("/{id:[0-9]+}", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.DeleteDeckHandler), authRepo)).Methods(http.MethodDelete)
+	decks.Handle("/{id:[0-9]+}/words", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.AddDeckWordHandler), authRepo)).Methods(http.MethodPost)
+	decks.Handle("/{id:[0-9]+}/words/{exercise_id:[0-9]+}", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.DeleteDeckWordHandler), authRepo)).Methods(http.MethodDelete)
+
+	classes := r.PathPrefix("/classes").Subrouter()
+	classes.Handle("", middleware.JwtMiddleware(http.HandlerFunc(classroomHandler.GetClassesHandler), authRepo)).Methods(http.MethodGet)
+	classes.Handle("", middleware.JwtMiddleware(http.HandlerFunc(classroomHandler.CreateClassHandler), authRepo)).Methods(http.MethodPost)
+	classes.Handle("/join", middleware.JwtMiddleware(http.HandlerFunc(classroomHandler.JoinClassHandler), authRepo)).Methods(http.MethodPost)
+	classes.Handle("/{id:[0-9]+}/join-code", middleware.JwtMiddleware(http.HandlerFunc(classroomHandler.RegenerateJoinCodeHandler), authRepo)).Methods(http.MethodPost)
+	classes.Handle("/{id:[0-9]+}/students/{student_id}", middleware.JwtMiddleware(http.HandlerFunc(classroomHandler.RemoveStudentHandler), authRepo)).Methods(http.MethodDelete)
+	classes.Handle("/{id:[0-9]+}/assignments", middleware.JwtMiddleware(http.HandlerFunc(classroomHandler.GetAssignmentsHandler), authRepo)).Methods(http.MethodGet)
+	classes.Handle("/{id:[0-9]+}/assignments", middleware.JwtMiddleware(http.HandlerFunc(classroomHandler.CreateAssignmentHandler), authRepo)).Methods(http.MethodPost)
+	classes.Handle("/{id:[0-9]+}/assignments/{assignment_id:[0-9]+}", middleware.JwtMiddleware(http.HandlerFunc(classroomHandler.DeleteAssignmentHandler), authRepo)).Methods(http.MethodDelete)
+	classes.Handle("/{id:[0-9]+}/dashboard", middleware.JwtMiddleware(http.HandlerFunc(classroomHandler.GetDashboardHandler), authRepo)).Methods(http.MethodGet)
+
+	teacher := r.PathPrefix("/teacher").Subrouter()
+	teacher.Handle("/recordings", middleware.JwtMiddleware(http.HandlerFunc(classroomHandler.GetRecordingsHandler), authRepo)).Methods(http.MethodGet)
+	teacher.Handle("/recordings/{id:[0-9]+}/grade", middleware.JwtMiddleware(http.HandlerFunc(classroomHandler.GradeRecordingHandler), authRepo)).Methods(http.MethodPost)
+
 	tip := r.PathPrefix("/tip").Subrouter()
 	tip.Handle("/get_tip", http.HandlerFunc(wordHandler.GetTipHandler)).Methods(http.MethodPost)
 	tip.Handle("/upload_tip", http.HandlerFunc(wordHandler.UploadTipHandler)).Methods(http.MethodPost)
 
+	tips := r.PathPrefix("/tips").Subrouter()
+	tips.Handle("", http.HandlerFunc(wordHandler.GetTipsHandler)).Methods(http.MethodGet)
+	tips.Handle("", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.CreateTipHandler), authRepo)).Methods(http.MethodPost)
+	tips.Handle("/{id:[0-9]+}", http.HandlerFunc(wordHandler.GetTipByIDHandler)).Methods(http.MethodGet)
+	tips.Handle("/{id:[0-9]+}", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.UpdateTipHandler), authRepo)).Methods(http.MethodPut)
+	tips.Handle("/{id:[0-9]+}", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.DeleteTipHandler), authRepo)).Methods(http.MethodDelete)
+
+	r.Handle("/lexicon/{id:[0-9]+}/examples", http.HandlerFunc(wordHandler.GetLexiconExamplesHandler)).Methods(http.MethodGet)
+	examples := r.PathPrefix("/examples").Subrouter()
+	examples.Handle("", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.CreateExampleHandler), authRepo)).Methods(http.MethodPost)
+	examples.Handle("/{id:[0-9]+}", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.UpdateExampleHandler), authRepo)).Methods(http.MethodPut)
+	examples.Handle("/{id:[0-9]+}", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.DeleteExampleHandler), authRepo)).Methods(http.MethodDelete)
+
+	topics := r.PathPrefix("/topics").Subrouter()
+	topics.Handle("", middleware.JwtMiddlewareOptional(http.HandlerFunc(wordHandler.GetTopicsHandler), authRepo)).Methods(http.MethodGet)
+	topics.Handle("", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.CreateTopicHandler), authRepo)).Methods(http.MethodPost)
+	topics.Handle("/{id:[0-9]+}", middleware.JwtMiddleware(http.HandlerFunc(wordHandler.DeleteTopicHandler), authRepo)).Methods(http.MethodDelete)
+	topicLink := "/{id:[0-9]+}/{target:word-modules|phrase-modules|word-exercises|phrase-exercises|lexicon}/{target_id:[0-9]+}"
+	topics.Handle(topicLink, middleware.JwtMiddleware(http.HandlerFunc(wordHandler.AddTopicLinkHandler), authRepo)).Methods(http.MethodPut)
+	topics.Handle(topicLink, middleware.JwtMiddleware(http.HandlerFunc(wordHandler.DeleteTopicLinkHandler), authRepo)).Methods(http.MethodDelete)
+
 	srv := &http.Server{
 		Addr:              ":8080",
 		Handler:           r,
@@ -129,6 +253,11 @@ func main() {
 		WriteTimeout:      10 * time.Second,
 	}
 
+	jobsCtx, stopJobs := context.WithCancel(context.WithValue(context.Background(), utils.REQUEST_ID_KEY, "league-rollover"))
+	go gamUsecase.RunLeagueRollover(jobsCtx)
+	go wordUsecase.BackfillPhonemes(context.WithValue(jobsCtx, utils.REQUEST_ID_KEY, "phonemes-backfill"))
+	go wordUsecase.BackfillSentenceWords(context.WithValue(jobsCtx, utils.REQUEST_ID_KEY, "sentence-words-backfill"))
+
 	signalCh := make(chan os.Signal, 1)
 	signal.Notify(signalCh, syscall.SIGINT, syscall.SIGTERM)
 
@@ -143,6 +272,7 @@ func main() {
 	sig := <-signalCh
 	str := fmt.Sprintf("Received signal: %v\n", sig)
 	logr.LogDebug(str)
+	stopJobs()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -0,0 +1,30 @@
+ALTER TABLE exercise_progress
+    ADD COLUMN IF NOT EXISTS attempts INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS best_score INTEGER,
+    ADD COLUMN IF NOT EXISTS last_attempt_at TIMESTAMP;
+
+CREATE TABLE IF NOT EXISTS exercise_attempts (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    exercise_id INTEGER NOT NULL,
+    exercise_type VARCHAR(10) NOT NULL,  -- "word" или "phrase"
+    result VARCHAR(20) NOT NULL,         -- "completed" или "failed"
+    score INTEGER CONSTRAINT attempt_score_range CHECK (score BETWEEN 0 AND 100),
+    duration_ms INTEGER NOT NULL DEFAULT 0,
+    transcription TEXT,
+    recording_id TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS exercise_attempts_user_exercise_idx
+    ON exercise_attempts (user_id, exercise_type, exercise_id, created_at DESC);
+
+-- уже сохранённые статусы превращаем в одну попытку, чтобы сводка не потерялась при пересчёте
+INSERT INTO exercise_attempts (user_id, exercise_id, exercise_type, result, created_at)
+SELECT user_id, exercise_id, exercise_type, status, COALESCE(updated_at, CURRENT_TIMESTAMP)
+FROM exercise_progress
+WHERE status IN ('completed', 'failed');
+
+UPDATE exercise_progress
+SET attempts = 1, last_attempt_at = updated_at
+WHERE status IN ('completed', 'failed');
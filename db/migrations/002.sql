@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS user_phoneme_mastery (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    phoneme TEXT NOT NULL,
+    accuracy DOUBLE PRECISION NOT NULL DEFAULT 0,  -- взвешенная доля верного произношения, 0..1
+    weight DOUBLE PRECISION NOT NULL DEFAULT 0,    -- суммарный вес наблюдений с учётом затухания
+    trend DOUBLE PRECISION NOT NULL DEFAULT 0,     -- сглаженное изменение accuracy
+    attempts INTEGER NOT NULL DEFAULT 0,
+    correct INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, phoneme)
+);
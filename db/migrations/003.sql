@@ -0,0 +1,41 @@
+CREATE TABLE IF NOT EXISTS review_items (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    exercise_id INTEGER NOT NULL,
+    exercise_type VARCHAR(10) NOT NULL,
+    ease DOUBLE PRECISION NOT NULL DEFAULT 2.5,
+    interval_days INTEGER NOT NULL DEFAULT 0,
+    repetitions INTEGER NOT NULL DEFAULT 0,
+    lapses INTEGER NOT NULL DEFAULT 0,
+    due_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_reviewed_at TIMESTAMP,                      -- NULL пока карточка новая
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_review_per_exercise UNIQUE (user_id, exercise_id, exercise_type)
+);
+
+CREATE INDEX IF NOT EXISTS review_items_due_idx ON review_items (user_id, due_at);
+
+CREATE TABLE IF NOT EXISTS review_log (
+    id SERIAL PRIMARY KEY,
+    review_id INTEGER NOT NULL REFERENCES review_items(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    grade INTEGER NOT NULL CONSTRAINT review_grade_range CHECK (grade BETWEEN 0 AND 5),
+    was_new BOOLEAN NOT NULL,
+    interval_days INTEGER NOT NULL,
+    reviewed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS review_log_user_day_idx ON review_log (user_id, reviewed_at);
+
+CREATE TABLE IF NOT EXISTS user_review_settings (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    new_per_day INTEGER NOT NULL CONSTRAINT new_per_day_positive CHECK (new_per_day >= 0),
+    reviews_per_day INTEGER NOT NULL CONSTRAINT reviews_per_day_positive CHECK (reviews_per_day >= 0)
+);
+
+-- слова, пройденные до появления повторений, сразу попадают в очередь
+INSERT INTO review_items (user_id, exercise_id, exercise_type)
+SELECT user_id, exercise_id, exercise_type
+FROM exercise_progress
+WHERE status = 'completed' AND exercise_type = 'word'
+ON CONFLICT (user_id, exercise_id, exercise_type) DO NOTHING;
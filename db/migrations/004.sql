@@ -0,0 +1,33 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS start_word_module INTEGER REFERENCES word_modules(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS placement_completed_at TIMESTAMP;
+
+CREATE TABLE IF NOT EXISTS placement_tests (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'in_progress',  -- in_progress, finished, abandoned
+    module_ids INTEGER[] NOT NULL,                       -- модули в порядке прохождения на момент старта
+    low INTEGER NOT NULL,                                -- границы бинарного поиска по module_ids
+    high INTEGER NOT NULL,
+    probe_items INTEGER NOT NULL DEFAULT 0,              -- ответы по текущему проверяемому модулю
+    probe_passed INTEGER NOT NULL DEFAULT 0,
+    answered INTEGER NOT NULL DEFAULT 0,
+    correct INTEGER NOT NULL DEFAULT 0,
+    current_exercise_id INTEGER,
+    start_module_id INTEGER REFERENCES word_modules(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS placement_tests_user_idx ON placement_tests (user_id, status);
+
+CREATE TABLE IF NOT EXISTS placement_answers (
+    id SERIAL PRIMARY KEY,
+    test_id INTEGER NOT NULL REFERENCES placement_tests(id) ON DELETE CASCADE,
+    exercise_id INTEGER NOT NULL,
+    module_id INTEGER NOT NULL,
+    passed BOOLEAN NOT NULL,
+    score INTEGER,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_placement_answer UNIQUE (test_id, exercise_id)
+);
@@ -0,0 +1,30 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS timezone TEXT NOT NULL DEFAULT 'UTC';
+
+CREATE TABLE IF NOT EXISTS user_streaks (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    goal_type VARCHAR(10) NOT NULL DEFAULT 'exercises',  -- exercises или minutes
+    goal_value INTEGER NOT NULL DEFAULT 10 CONSTRAINT goal_value_positive CHECK (goal_value > 0),
+    current_streak INTEGER NOT NULL DEFAULT 0,
+    longest_streak INTEGER NOT NULL DEFAULT 0,
+    freezes INTEGER NOT NULL DEFAULT 0,                   -- доступные заморозки серии
+    last_goal_day DATE                                    -- последний день с выполненной целью
+);
+
+CREATE TABLE IF NOT EXISTS daily_activity (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    day DATE NOT NULL,                                    -- дата в часовом поясе пользователя
+    exercises INTEGER NOT NULL DEFAULT 0,
+    completed INTEGER NOT NULL DEFAULT 0,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    goal_met BOOLEAN NOT NULL DEFAULT FALSE,
+    frozen BOOLEAN NOT NULL DEFAULT FALSE,                -- пропуск закрыт заморозкой
+    PRIMARY KEY (user_id, day)
+);
+
+-- активность до появления календаря восстанавливается из попыток в UTC
+INSERT INTO daily_activity (user_id, day, exercises, completed, duration_ms)
+SELECT user_id, created_at::date, COUNT(*), COUNT(*) FILTER (WHERE result = 'completed'), SUM(duration_ms)
+FROM exercise_attempts
+GROUP BY user_id, created_at::date
+ON CONFLICT (user_id, day) DO NOTHING;
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS user_xp (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    xp INTEGER NOT NULL DEFAULT 0,
+    level INTEGER NOT NULL DEFAULT 1,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- каждое начисление опыта; уникальность (source, ref) делает начисления идемпотентными
+CREATE TABLE IF NOT EXISTS xp_events (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    source VARCHAR(40) NOT NULL,   -- exercise_completed, first_try, perfect_score, word_module_completed, phrase_module_completed
+    ref TEXT NOT NULL,             -- к чему относится начисление, например "word:12"
+    xp INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_xp_event UNIQUE (user_id, source, ref)
+);
+
+CREATE INDEX IF NOT EXISTS xp_events_user_time_idx ON xp_events (user_id, created_at);
+
+CREATE TABLE IF NOT EXISTS user_achievements (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code VARCHAR(50) NOT NULL,
+    unlocked_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, code)
+);
@@ -0,0 +1,47 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS leaderboard_opt_out BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS user_xp_rank_idx ON user_xp (xp DESC);
+
+-- односторонняя подписка: таблица друзей показывает пользователя и тех, на кого он подписан
+CREATE TABLE IF NOT EXISTS friendships (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    friend_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, friend_id),
+    CONSTRAINT friendship_not_self CHECK (user_id <> friend_id)
+);
+
+-- опыт за неделю, начинающуюся в понедельник (UTC); пополняется вместе с user_xp
+CREATE TABLE IF NOT EXISTS weekly_xp (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    week_start DATE NOT NULL,
+    xp INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, week_start)
+);
+
+CREATE INDEX IF NOT EXISTS weekly_xp_rank_idx ON weekly_xp (week_start, xp DESC);
+
+CREATE TABLE IF NOT EXISTS user_leagues (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    tier INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS user_leagues_tier_idx ON user_leagues (tier);
+
+-- недели, по итогам которых уже прошло повышение и понижение в лигах
+CREATE TABLE IF NOT EXISTS league_rollovers (
+    week_start DATE PRIMARY KEY,
+    processed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO weekly_xp (user_id, week_start, xp)
+SELECT user_id, date_trunc('week', created_at)::date, SUM(xp)
+FROM xp_events
+GROUP BY user_id, date_trunc('week', created_at)::date
+ON CONFLICT (user_id, week_start) DO NOTHING;
+
+INSERT INTO user_leagues (user_id)
+SELECT user_id FROM user_xp
+ON CONFLICT (user_id) DO NOTHING;
@@ -0,0 +1,29 @@
+-- классы ведёт их создатель: только он видит учеников и их прогресс
+CREATE TABLE IF NOT EXISTS classes (
+    id SERIAL PRIMARY KEY,
+    teacher_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title TEXT NOT NULL,
+    join_code VARCHAR(16) NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS classes_teacher_idx ON classes (teacher_id);
+
+CREATE TABLE IF NOT EXISTS class_members (
+    class_id INTEGER NOT NULL REFERENCES classes(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    joined_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (class_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS class_members_user_idx ON class_members (user_id);
+
+CREATE TABLE IF NOT EXISTS class_assignments (
+    id SERIAL PRIMARY KEY,
+    class_id INTEGER NOT NULL REFERENCES classes(id) ON DELETE CASCADE,
+    module_type VARCHAR(10) NOT NULL CONSTRAINT assignment_module_type CHECK (module_type IN ('word', 'phrase')),
+    module_id INTEGER NOT NULL,
+    due_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_class_assignment UNIQUE (class_id, module_type, module_id)
+);
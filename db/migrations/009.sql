@@ -0,0 +1,30 @@
+CREATE INDEX IF NOT EXISTS exercise_attempts_recording_idx
+    ON exercise_attempts (user_id, created_at)
+    WHERE recording_id IS NOT NULL;
+
+-- оценка учителя заменяет результат и балл попытки; автоматическая оценка сохраняется здесь
+CREATE TABLE IF NOT EXISTS recording_grades (
+    attempt_id INTEGER PRIMARY KEY REFERENCES exercise_attempts(id) ON DELETE CASCADE,
+    teacher_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    score INTEGER NOT NULL CONSTRAINT grade_score_range CHECK (score BETWEEN 0 AND 100),
+    result VARCHAR(20) NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    auto_result VARCHAR(20) NOT NULL,
+    auto_score INTEGER,
+    graded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS recording_grades_teacher_idx ON recording_grades (teacher_id, graded_at DESC);
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL DEFAULT '',
+    payload JSONB NOT NULL DEFAULT '{}',
+    read_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS notifications_user_idx ON notifications (user_id, created_at DESC);
@@ -0,0 +1,6 @@
+-- личные наборы слов хранятся как модули с владельцем; у общих модулей owner_id пустой
+ALTER TABLE word_modules
+    ADD COLUMN IF NOT EXISTS owner_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS word_modules_owner_idx ON word_modules (owner_id) WHERE owner_id IS NOT NULL;
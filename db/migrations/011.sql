@@ -0,0 +1,18 @@
+-- слово в упражнении определяется парой (упражнение, позиция в массиве words),
+-- фраза - самим упражнением; ровно одна из ссылок заполнена
+CREATE TABLE IF NOT EXISTS bookmarks (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    word_exercise_id INTEGER REFERENCES word_exercises(id) ON DELETE CASCADE,
+    word_index INTEGER NOT NULL DEFAULT 0 CONSTRAINT bookmark_word_index CHECK (word_index >= 0),
+    phrase_exercise_id INTEGER REFERENCES phrase_exercises(id) ON DELETE CASCADE,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT bookmark_single_target CHECK ((word_exercise_id IS NULL) <> (phrase_exercise_id IS NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS bookmarks_word_uniq
+    ON bookmarks (user_id, word_exercise_id, word_index) WHERE word_exercise_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS bookmarks_phrase_uniq
+    ON bookmarks (user_id, phrase_exercise_id) WHERE phrase_exercise_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS bookmarks_user_idx ON bookmarks (user_id, created_at DESC);
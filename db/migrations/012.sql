@@ -0,0 +1,113 @@
+-- словарь: одно слово с одной транскрипцией хранится один раз, упражнения ссылаются на статьи.
+-- Слова личных наборов не смешиваются с общими: у таких статей заполнен owner_id.
+CREATE TABLE IF NOT EXISTS lexicon_entries (
+    id SERIAL PRIMARY KEY,
+    word TEXT NOT NULL,
+    word_key TEXT NOT NULL,                        -- lower(word), по нему ищутся дубликаты
+    ipa TEXT NOT NULL DEFAULT '',
+    translations TEXT[] NOT NULL DEFAULT '{}',     -- первый перевод основной
+    audio TEXT NOT NULL DEFAULT '',
+    part_of_speech VARCHAR(20) NOT NULL DEFAULT '',
+    topic TEXT NOT NULL DEFAULT '',
+    owner_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS lexicon_entries_shared_uniq
+    ON lexicon_entries (word_key, ipa) WHERE owner_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS lexicon_entries_owned_uniq
+    ON lexicon_entries (owner_id, word_key, ipa) WHERE owner_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS word_exercise_entries (
+    exercise_id INTEGER NOT NULL REFERENCES word_exercises(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL CONSTRAINT exercise_entry_position CHECK (position >= 0),
+    entry_id INTEGER NOT NULL REFERENCES lexicon_entries(id),
+    -- озвучка и перевод упражнения, если они отличаются от статьи: статья хранит первые
+    -- значения, упражнение показывает свои поверх них
+    audio TEXT NOT NULL DEFAULT '',
+    translation TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (exercise_id, position)
+);
+
+CREATE INDEX IF NOT EXISTS word_exercise_entries_entry_idx ON word_exercise_entries (entry_id);
+
+-- перенос параллельных массивов: позиции без слова отбрасываются, недостающие элементы
+-- соседних массивов считаются пустыми. new_position - позиция слова после этого
+CREATE TEMP TABLE lexicon_items AS
+SELECT e.id AS exercise_id,
+       t.pos - 1 AS position,
+       ROW_NUMBER() OVER (PARTITION BY e.id ORDER BY t.pos) - 1 AS new_position,
+       btrim(t.word) AS word,
+       lower(btrim(t.word)) AS word_key,
+       btrim(COALESCE(e.transcriptions[t.pos], '')) AS ipa,
+       btrim(COALESCE(e.translations[t.pos], '')) AS translation,
+       COALESCE(e.audio[t.pos], '') AS audio,
+       m.owner_id
+FROM word_exercises e
+LEFT JOIN word_modules m ON m.id = e.module_id
+CROSS JOIN LATERAL unnest(e.words) WITH ORDINALITY AS t(word, pos)
+WHERE btrim(COALESCE(t.word, '')) <> '';
+
+INSERT INTO lexicon_entries (word, word_key, ipa, translations, audio, owner_id)
+SELECT (array_agg(i.word ORDER BY i.exercise_id, i.position))[1],
+       i.word_key,
+       i.ipa,
+       ARRAY(SELECT t.translation
+             FROM lexicon_items t
+             WHERE t.word_key = i.word_key AND t.ipa = i.ipa
+               AND t.owner_id IS NOT DISTINCT FROM i.owner_id AND t.translation <> ''
+             GROUP BY t.translation
+             ORDER BY MIN(t.exercise_id), MIN(t.position)),
+       COALESCE((array_agg(i.audio ORDER BY i.exercise_id, i.position) FILTER (WHERE i.audio <> ''))[1], ''),
+       i.owner_id
+FROM lexicon_items i
+GROUP BY i.word_key, i.ipa, i.owner_id;
+
+-- упражнения, чьи перевод или озвучка не совпали с объединённой статьёй, сохраняют свои
+INSERT INTO word_exercise_entries (exercise_id, position, entry_id, audio, translation)
+SELECT i.exercise_id, i.new_position, l.id,
+       CASE WHEN i.audio <> '' AND i.audio <> l.audio THEN i.audio ELSE '' END,
+       CASE WHEN i.translation <> '' AND i.translation <> COALESCE(l.translations[1], '') THEN i.translation ELSE '' END
+FROM lexicon_items i
+JOIN lexicon_entries l ON l.word_key = i.word_key AND l.ipa = i.ipa AND l.owner_id IS NOT DISTINCT FROM i.owner_id;
+
+-- закладки хранят индекс слова в прежних массивах: переводим их на новые позиции, закладки
+-- на отброшенные пустые слова удаляем. Уникальный индекс снимается на время сдвига, чтобы
+-- соседние закладки не конфликтовали посреди обновления
+DELETE FROM bookmarks b
+WHERE b.word_exercise_id IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM lexicon_items i WHERE i.exercise_id = b.word_exercise_id AND i.position = b.word_index);
+
+DROP INDEX IF EXISTS bookmarks_word_uniq;
+
+UPDATE bookmarks b
+SET word_index = i.new_position
+FROM lexicon_items i
+WHERE i.exercise_id = b.word_exercise_id AND i.position = b.word_index AND i.new_position <> i.position;
+
+CREATE UNIQUE INDEX IF NOT EXISTS bookmarks_word_uniq
+    ON bookmarks (user_id, word_exercise_id, word_index) WHERE word_exercise_id IS NOT NULL;
+
+DROP TABLE lexicon_items;
+
+ALTER TABLE word_exercises
+    DROP COLUMN IF EXISTS words,
+    DROP COLUMN IF EXISTS transcriptions,
+    DROP COLUMN IF EXISTS audio,
+    DROP COLUMN IF EXISTS translations;
+
+-- прежний вид упражнения с параллельными массивами собирается из словаря, длины массивов
+-- теперь всегда совпадают
+CREATE OR REPLACE VIEW word_exercise_cards AS
+SELECT e.id,
+       e.exercise_type,
+       e.module_id,
+       ARRAY(SELECT l.word FROM word_exercise_entries x JOIN lexicon_entries l ON l.id = x.entry_id
+             WHERE x.exercise_id = e.id ORDER BY x.position) AS words,
+       ARRAY(SELECT l.ipa FROM word_exercise_entries x JOIN lexicon_entries l ON l.id = x.entry_id
+             WHERE x.exercise_id = e.id ORDER BY x.position) AS transcriptions,
+       ARRAY(SELECT COALESCE(NULLIF(x.audio, ''), l.audio) FROM word_exercise_entries x JOIN lexicon_entries l ON l.id = x.entry_id
+             WHERE x.exercise_id = e.id ORDER BY x.position) AS audio,
+       ARRAY(SELECT COALESCE(NULLIF(x.translation, ''), l.translations[1], '') FROM word_exercise_entries x JOIN lexicon_entries l ON l.id = x.entry_id
+             WHERE x.exercise_id = e.id ORDER BY x.position) AS translations
+FROM word_exercises e;
@@ -0,0 +1,26 @@
+-- транскрипция хранится в двух видах: ipa/transcription - нормализованная запись для показа,
+-- phonemes - фонемы в написании инвентаря для оценки произношения и подбора советов.
+-- Существующие записи заполняются при старте сервиса, пока phonemes пуст.
+ALTER TABLE lexicon_entries
+    ADD COLUMN IF NOT EXISTS phonemes TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE phrase_exercises
+    ADD COLUMN IF NOT EXISTS phonemes TEXT[] NOT NULL DEFAULT '{}';
+
+-- фонемы каждого слова упражнения собираются в строку через пробел:
+-- двумерный массив из слов разной длины в Postgres не построить
+CREATE OR REPLACE VIEW word_exercise_cards AS
+SELECT e.id,
+       e.exercise_type,
+       e.module_id,
+       ARRAY(SELECT l.word FROM word_exercise_entries x JOIN lexicon_entries l ON l.id = x.entry_id
+             WHERE x.exercise_id = e.id ORDER BY x.position) AS words,
+       ARRAY(SELECT l.ipa FROM word_exercise_entries x JOIN lexicon_entries l ON l.id = x.entry_id
+             WHERE x.exercise_id = e.id ORDER BY x.position) AS transcriptions,
+       ARRAY(SELECT l.audio FROM word_exercise_entries x JOIN lexicon_entries l ON l.id = x.entry_id
+             WHERE x.exercise_id = e.id ORDER BY x.position) AS audio,
+       ARRAY(SELECT COALESCE(l.translations[1], '') FROM word_exercise_entries x JOIN lexicon_entries l ON l.id = x.entry_id
+             WHERE x.exercise_id = e.id ORDER BY x.position) AS translations,
+       ARRAY(SELECT array_to_string(l.phonemes, ' ') FROM word_exercise_entries x JOIN lexicon_entries l ON l.id = x.entry_id
+             WHERE x.exercise_id = e.id ORDER BY x.position) AS phonemes
+FROM word_exercises e;
@@ -0,0 +1,34 @@
+-- у фонемы может быть несколько советов: на разных языках объяснения, разной сложности,
+-- в заданном редактором порядке. Ключом становится id, phonema остаётся обычной колонкой.
+ALTER TABLE word_tip DROP CONSTRAINT IF EXISTS word_tip_pkey;
+
+ALTER TABLE word_tip
+    ADD COLUMN IF NOT EXISTS id SERIAL PRIMARY KEY,
+    ADD COLUMN IF NOT EXISTS locale VARCHAR(10) NOT NULL DEFAULT 'ru',
+    ADD COLUMN IF NOT EXISTS position INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS difficulty VARCHAR(20) NOT NULL DEFAULT 'beginner'
+        CONSTRAINT word_tip_difficulty CHECK (difficulty IN ('beginner', 'intermediate', 'advanced')),
+    ADD COLUMN IF NOT EXISTS example_words TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+UPDATE word_tip SET tip_text = '' WHERE tip_text IS NULL;
+UPDATE word_tip SET tip_audio_link = '' WHERE tip_audio_link IS NULL;
+UPDATE word_tip SET tip_video_link = '' WHERE tip_video_link IS NULL;
+
+ALTER TABLE word_tip
+    ALTER COLUMN phonema SET NOT NULL,
+    ALTER COLUMN tip_text SET NOT NULL,
+    ALTER COLUMN tip_audio_link SET NOT NULL,
+    ALTER COLUMN tip_audio_link SET DEFAULT '',
+    ALTER COLUMN tip_video_link SET NOT NULL,
+    ALTER COLUMN tip_video_link SET DEFAULT '';
+
+-- советы ищутся по фонемам транскрипций, поэтому ключи приводятся к написанию инвентаря
+UPDATE word_tip SET phonema = replace(btrim(phonema, ' /[]'), ':', 'ː');
+UPDATE word_tip t SET phonema = a.canonical
+FROM (VALUES ('g', 'ɡ'), ('r', 'ɹ'), ('ʧ', 'tʃ'), ('ʤ', 'dʒ'), ('ɝ', 'ɜː'), ('ɚ', 'ə'), ('ɜ', 'ɜː'),
+             ('e', 'ɛ'), ('o', 'ɔ'), ('a', 'æ'), ('ɐ', 'ʌ'), ('ɫ', 'l'), ('ʍ', 'w')) AS a(alias, canonical)
+WHERE t.phonema = a.alias;
+
+CREATE INDEX IF NOT EXISTS word_tip_phonema_idx ON word_tip (phonema, locale, position, id);
@@ -0,0 +1,106 @@
+-- поиск по материалам: полнотекстовый по русской и английской морфологии и по триграммам
+-- для опечаток. У каждой таблицы две вычисляемые колонки: search_vector для @@ и
+-- search_text (нижний регистр) для word_similarity.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- array_to_string только STABLE, а вычисляемым колонкам нужны IMMUTABLE-выражения
+CREATE OR REPLACE FUNCTION search_join(TEXT[]) RETURNS TEXT
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE AS $$ SELECT array_to_string($1, ' ') $$;
+
+-- названия модулей бывают на обоих языках
+ALTER TABLE word_modules
+    ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+        to_tsvector('english', title) || to_tsvector('russian', title)) STORED,
+    ADD COLUMN IF NOT EXISTS search_text TEXT GENERATED ALWAYS AS (lower(title)) STORED;
+
+ALTER TABLE phrase_modules
+    ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+        to_tsvector('english', title) || to_tsvector('russian', title)) STORED,
+    ADD COLUMN IF NOT EXISTS search_text TEXT GENERATED ALWAYS AS (lower(title)) STORED;
+
+ALTER TABLE lexicon_entries
+    ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', word), 'A') ||
+        setweight(to_tsvector('russian', search_join(translations)), 'B')) STORED,
+    ADD COLUMN IF NOT EXISTS search_text TEXT GENERATED ALWAYS AS (
+        lower(word || ' ' || search_join(translations))) STORED;
+
+ALTER TABLE phrase_exercises
+    ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', COALESCE(sentence, '')), 'A') ||
+        setweight(to_tsvector('russian', COALESCE(translate, '')), 'B')) STORED,
+    ADD COLUMN IF NOT EXISTS search_text TEXT GENERATED ALWAYS AS (
+        lower(COALESCE(sentence, '') || ' ' || COALESCE(translate, ''))) STORED;
+
+-- советы пишутся на разных языках; в конфигурации russian латиница тоже стеммится по-английски
+ALTER TABLE word_tip
+    ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', tip_text), 'B') ||
+        setweight(to_tsvector('english', search_join(example_words)), 'C')) STORED,
+    ADD COLUMN IF NOT EXISTS search_text TEXT GENERATED ALWAYS AS (
+        lower(tip_text || ' ' || search_join(example_words))) STORED;
+
+CREATE INDEX IF NOT EXISTS word_modules_search_idx ON word_modules USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS word_modules_trgm_idx ON word_modules USING GIN (search_text gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS phrase_modules_search_idx ON phrase_modules USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS phrase_modules_trgm_idx ON phrase_modules USING GIN (search_text gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS lexicon_entries_search_idx ON lexicon_entries USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS lexicon_entries_trgm_idx ON lexicon_entries USING GIN (search_text gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS phrase_exercises_search_idx ON phrase_exercises USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS phrase_exercises_trgm_idx ON phrase_exercises USING GIN (search_text gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS word_tip_search_idx ON word_tip USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS word_tip_trgm_idx ON word_tip USING GIN (search_text gin_trgm_ops);
+
+-- фонемы для поиска по транскрипции: через пробел и с пробелами по краям,
+-- чтобы /t/ не находился внутри /tʃ/
+CREATE INDEX IF NOT EXISTS lexicon_entries_phonemes_trgm_idx
+    ON lexicon_entries USING GIN ((' ' || search_join(phonemes) || ' ') gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS phrase_exercises_phonemes_trgm_idx
+    ON phrase_exercises USING GIN ((' ' || search_join(phonemes) || ' ') gin_trgm_ops);
+
+-- все найденные материалы в одном виде. owner_id заполнен у личных наборов и их слов:
+-- такие документы видны только владельцу. Слово ведёт в первый модуль, где оно встречается.
+CREATE OR REPLACE VIEW search_documents AS
+SELECT CASE WHEN m.owner_id IS NULL THEN 'word_module' ELSE 'deck' END AS kind,
+       m.id,
+       m.id AS module_id,
+       m.owner_id,
+       m.title,
+       '' AS subtitle,
+       '' AS transcription,
+       '' AS phoneme_text,
+       m.search_vector,
+       m.search_text
+FROM word_modules m
+UNION ALL
+SELECT 'phrase_module', m.id, m.id, NULL, m.title, '', '', '', m.search_vector, m.search_text
+FROM phrase_modules m
+UNION ALL
+SELECT 'word',
+       l.id,
+       (SELECT e.module_id FROM word_exercise_entries x JOIN word_exercises e ON e.id = x.exercise_id
+        WHERE x.entry_id = l.id ORDER BY e.module_id, e.id LIMIT 1),
+       l.owner_id,
+       l.word,
+       COALESCE(l.translations[1], ''),
+       l.ipa,
+       ' ' || search_join(l.phonemes) || ' ',
+       l.search_vector,
+       l.search_text
+FROM lexicon_entries l
+UNION ALL
+SELECT 'phrase',
+       p.id,
+       p.module_id,
+       NULL,
+       COALESCE(p.sentence, ''),
+       COALESCE(p.translate, ''),
+       COALESCE(p.transcription, ''),
+       ' ' || search_join(p.phonemes) || ' ',
+       p.search_vector,
+       p.search_text
+FROM phrase_exercises p
+UNION ALL
+SELECT 'tip', t.id, NULL, NULL, t.phonema, t.tip_text, '/' || t.phonema || '/', ' ' || t.phonema || ' ',
+       t.search_vector, t.search_text
+FROM word_tip t;
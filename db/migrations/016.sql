@@ -0,0 +1,20 @@
+-- переводы на другие языки учеников. Перевод на основном языке (ru) остаётся в
+-- lexicon_entries.translations и phrase_exercises.translate: он же запасной, если
+-- перевода на языке ученика нет.
+CREATE TABLE IF NOT EXISTS lexicon_translations (
+    entry_id INTEGER NOT NULL REFERENCES lexicon_entries(id) ON DELETE CASCADE,
+    locale VARCHAR(10) NOT NULL,
+    translations TEXT[] NOT NULL DEFAULT '{}',     -- первый перевод основной
+    PRIMARY KEY (entry_id, locale)
+);
+
+CREATE TABLE IF NOT EXISTS phrase_translations (
+    exercise_id INTEGER NOT NULL REFERENCES phrase_exercises(id) ON DELETE CASCADE,
+    locale VARCHAR(10) NOT NULL,
+    translation TEXT NOT NULL,
+    PRIMARY KEY (exercise_id, locale)
+);
+
+-- язык переводов, выбранный в профиле; NULL - по Accept-Language
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS content_locale VARCHAR(10);
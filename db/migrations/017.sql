@@ -0,0 +1,25 @@
+-- варианты произношения с разным акцентом. Транскрипция и озвучка в самих статьях и
+-- упражнениях остаются основными: они отдаются, если варианта с нужным акцентом нет.
+CREATE TABLE IF NOT EXISTS lexicon_variants (
+    entry_id INTEGER NOT NULL REFERENCES lexicon_entries(id) ON DELETE CASCADE,
+    accent VARCHAR(5) NOT NULL CONSTRAINT lexicon_variant_accent CHECK (accent IN ('us', 'uk')),
+    ipa TEXT NOT NULL,
+    phonemes TEXT[] NOT NULL DEFAULT '{}',
+    audio TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (entry_id, accent)
+);
+
+CREATE TABLE IF NOT EXISTS phrase_variants (
+    exercise_id INTEGER NOT NULL REFERENCES phrase_exercises(id) ON DELETE CASCADE,
+    accent VARCHAR(5) NOT NULL CONSTRAINT phrase_variant_accent CHECK (accent IN ('us', 'uk')),
+    transcription TEXT NOT NULL,
+    phonemes TEXT[] NOT NULL DEFAULT '{}',
+    audio TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (exercise_id, accent)
+);
+
+-- акцент, выбранный в профиле; NULL - основной вариант
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS accent VARCHAR(5);
@@ -0,0 +1,65 @@
+-- примеры употребления слов: отдельные предложения, не привязанные к модулям
+CREATE TABLE IF NOT EXISTS example_sentences (
+    id SERIAL PRIMARY KEY,
+    sentence TEXT NOT NULL,
+    translation TEXT NOT NULL DEFAULT '',
+    audio TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- слова предложений фразовых упражнений и примеров. Для каждого слова хранятся все ключи
+-- словаря, которыми может оказаться его начальная форма (cats -> cats, cat), поэтому связь
+-- со статьями появляется сама, в каком бы порядке ни добавлялись слова и предложения.
+-- Существующие фразы разбираются при старте сервиса.
+CREATE TABLE IF NOT EXISTS sentence_words (
+    phrase_exercise_id INTEGER REFERENCES phrase_exercises(id) ON DELETE CASCADE,
+    example_id INTEGER REFERENCES example_sentences(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL CONSTRAINT sentence_word_position CHECK (position >= 0),
+    form TEXT NOT NULL,                            -- слово как оно написано в предложении
+    word_key TEXT NOT NULL,
+    CONSTRAINT sentence_word_single_source CHECK ((phrase_exercise_id IS NULL) <> (example_id IS NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS sentence_words_phrase_uniq
+    ON sentence_words (phrase_exercise_id, position, word_key) WHERE phrase_exercise_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS sentence_words_example_uniq
+    ON sentence_words (example_id, position, word_key) WHERE example_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS sentence_words_key_idx ON sentence_words (word_key);
+CREATE INDEX IF NOT EXISTS lexicon_entries_key_idx ON lexicon_entries (word_key);
+
+-- предложения, где встречаются слова статей словаря: фразовые упражнения и отдельные
+-- примеры. Слово, встретившееся в предложении несколько раз, даёт одну строку.
+CREATE OR REPLACE VIEW lexicon_examples AS
+SELECT w.entry_id,
+       'phrase'::text AS kind,
+       p.id,
+       p.module_id,
+       COALESCE(p.sentence, '') AS sentence,
+       COALESCE(p.translate, '') AS translation,
+       p.audio,
+       w.form
+FROM (
+    SELECT DISTINCT ON (l.id, s.phrase_exercise_id) l.id AS entry_id, s.phrase_exercise_id, s.form
+    FROM sentence_words s
+    JOIN lexicon_entries l ON l.word_key = s.word_key
+    WHERE s.phrase_exercise_id IS NOT NULL
+    ORDER BY l.id, s.phrase_exercise_id, s.position
+) w
+JOIN phrase_exercises p ON p.id = w.phrase_exercise_id
+UNION ALL
+SELECT w.entry_id,
+       'sentence'::text,
+       e.id,
+       NULL::integer,
+       e.sentence,
+       e.translation,
+       e.audio,
+       w.form
+FROM (
+    SELECT DISTINCT ON (l.id, s.example_id) l.id AS entry_id, s.example_id, s.form
+    FROM sentence_words s
+    JOIN lexicon_entries l ON l.word_key = s.word_key
+    WHERE s.example_id IS NOT NULL
+    ORDER BY l.id, s.example_id, s.position
+) w
+JOIN example_sentences e ON e.id = w.example_id;
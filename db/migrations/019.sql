@@ -0,0 +1,90 @@
+-- темы материалов: travel, food, th-sounds. slug - постоянное имя темы для фильтров,
+-- title - название для показа
+CREATE TABLE IF NOT EXISTS topics (
+    id SERIAL PRIMARY KEY,
+    slug TEXT NOT NULL UNIQUE CONSTRAINT topic_slug CHECK (slug ~ '^[a-z0-9]+(-[a-z0-9]+)*$'),
+    title TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- тема привязывается к модулю, упражнению или статье словаря; ровно одна из ссылок
+-- заполнена и совпадает с kind. target_id нужен для уникальности привязки.
+CREATE TABLE IF NOT EXISTS topic_links (
+    topic_id INTEGER NOT NULL REFERENCES topics(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL,
+    word_module_id INTEGER REFERENCES word_modules(id) ON DELETE CASCADE,
+    phrase_module_id INTEGER REFERENCES phrase_modules(id) ON DELETE CASCADE,
+    word_exercise_id INTEGER REFERENCES word_exercises(id) ON DELETE CASCADE,
+    phrase_exercise_id INTEGER REFERENCES phrase_exercises(id) ON DELETE CASCADE,
+    entry_id INTEGER REFERENCES lexicon_entries(id) ON DELETE CASCADE,
+    target_id INTEGER GENERATED ALWAYS AS
+        (COALESCE(word_module_id, phrase_module_id, word_exercise_id, phrase_exercise_id, entry_id)) STORED,
+    CONSTRAINT topic_link_single_target
+        CHECK (num_nonnulls(word_module_id, phrase_module_id, word_exercise_id, phrase_exercise_id, entry_id) = 1),
+    CONSTRAINT topic_link_kind CHECK (CASE kind
+        WHEN 'word_module' THEN word_module_id IS NOT NULL
+        WHEN 'phrase_module' THEN phrase_module_id IS NOT NULL
+        WHEN 'word_exercise' THEN word_exercise_id IS NOT NULL
+        WHEN 'phrase_exercise' THEN phrase_exercise_id IS NOT NULL
+        WHEN 'word' THEN entry_id IS NOT NULL
+        ELSE false END),
+    PRIMARY KEY (topic_id, kind, target_id)
+);
+
+CREATE INDEX IF NOT EXISTS topic_links_target_idx ON topic_links (kind, target_id);
+
+-- прежние темы статей словаря из lexicon_entries.topic
+INSERT INTO topics (slug, title)
+SELECT DISTINCT ON (slug) slug, btrim(topic)
+FROM (
+    SELECT topic, btrim(regexp_replace(lower(btrim(topic)), '[^a-z0-9]+', '-', 'g'), '-') AS slug
+    FROM lexicon_entries
+    WHERE owner_id IS NULL
+) t
+WHERE slug <> ''
+ORDER BY slug, topic
+ON CONFLICT (slug) DO NOTHING;
+
+INSERT INTO topic_links (topic_id, kind, entry_id)
+SELECT t.id, 'word', l.id
+FROM lexicon_entries l
+JOIN topics t ON t.slug = btrim(regexp_replace(lower(btrim(l.topic)), '[^a-z0-9]+', '-', 'g'), '-')
+WHERE l.owner_id IS NULL
+ON CONFLICT DO NOTHING;
+
+-- темы упражнения: свои, темы его модуля и, для словесных упражнений, темы его слов
+CREATE OR REPLACE VIEW exercise_topics AS
+SELECT 'word'::text AS exercise_type, l.word_exercise_id AS exercise_id, l.topic_id
+FROM topic_links l
+WHERE l.word_exercise_id IS NOT NULL
+UNION
+SELECT 'word', e.id, l.topic_id
+FROM word_exercises e
+JOIN topic_links l ON l.word_module_id = e.module_id
+UNION
+SELECT 'word', x.exercise_id, l.topic_id
+FROM word_exercise_entries x
+JOIN topic_links l ON l.entry_id = x.entry_id
+UNION
+SELECT 'phrase', l.phrase_exercise_id, l.topic_id
+FROM topic_links l
+WHERE l.phrase_exercise_id IS NOT NULL
+UNION
+SELECT 'phrase', e.id, l.topic_id
+FROM phrase_exercises e
+JOIN topic_links l ON l.phrase_module_id = e.module_id;
+
+-- слова общих модулей по темам. Слова с темой, которых ещё нет в упражнениях, тоже
+-- входят в тему, но выучить их пока нельзя (exercise_id пуст).
+CREATE OR REPLACE VIEW topic_words AS
+SELECT t.topic_id, x.entry_id, x.exercise_id
+FROM exercise_topics t
+JOIN word_exercise_entries x ON x.exercise_id = t.exercise_id
+JOIN word_exercises e ON e.id = x.exercise_id
+JOIN word_modules m ON m.id = e.module_id
+WHERE t.exercise_type = 'word' AND m.owner_id IS NULL
+UNION
+SELECT l.topic_id, l.entry_id, NULL::integer
+FROM topic_links l
+JOIN lexicon_entries le ON le.id = l.entry_id
+WHERE le.owner_id IS NULL;
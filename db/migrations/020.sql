@@ -0,0 +1,6 @@
+-- результат попытки - только "completed" или "failed"
+ALTER TABLE exercise_attempts
+    DROP CONSTRAINT IF EXISTS attempt_result_value;
+
+ALTER TABLE exercise_attempts
+    ADD CONSTRAINT attempt_result_value CHECK (result IN ('completed', 'failed'));
@@ -0,0 +1,21 @@
+-- озвучка и перевод, которые автор упражнения задал для уже существующей статьи словаря.
+-- Колонки создаются в 012.sql при переносе упражнений; здесь к виду добавляются фонемы
+ALTER TABLE word_exercise_entries
+    ADD COLUMN IF NOT EXISTS audio TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS translation TEXT NOT NULL DEFAULT '';
+
+CREATE OR REPLACE VIEW word_exercise_cards AS
+SELECT e.id,
+       e.exercise_type,
+       e.module_id,
+       ARRAY(SELECT l.word FROM word_exercise_entries x JOIN lexicon_entries l ON l.id = x.entry_id
+             WHERE x.exercise_id = e.id ORDER BY x.position) AS words,
+       ARRAY(SELECT l.ipa FROM word_exercise_entries x JOIN lexicon_entries l ON l.id = x.entry_id
+             WHERE x.exercise_id = e.id ORDER BY x.position) AS transcriptions,
+       ARRAY(SELECT COALESCE(NULLIF(x.audio, ''), l.audio) FROM word_exercise_entries x JOIN lexicon_entries l ON l.id = x.entry_id
+             WHERE x.exercise_id = e.id ORDER BY x.position) AS audio,
+       ARRAY(SELECT COALESCE(NULLIF(x.translation, ''), l.translations[1], '') FROM word_exercise_entries x JOIN lexicon_entries l ON l.id = x.entry_id
+             WHERE x.exercise_id = e.id ORDER BY x.position) AS translations,
+       ARRAY(SELECT array_to_string(l.phonemes, ' ') FROM word_exercise_entries x JOIN lexicon_entries l ON l.id = x.entry_id
+             WHERE x.exercise_id = e.id ORDER BY x.position) AS phonemes
+FROM word_exercises e;
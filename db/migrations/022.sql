@@ -0,0 +1,10 @@
+-- транскрипции, сохранённые до нормализации записи, ещё хранят косые черты, "g" и ":".
+-- Фонемы сбрасываются, чтобы заполнение при старте сервиса заново прошло все записи:
+-- переписало транскрипцию в нормализованном виде и слило статьи словаря, совпавшие после этого
+UPDATE lexicon_entries
+SET phonemes = '{}'
+WHERE ipa <> '';
+
+UPDATE phrase_exercises
+SET phonemes = '{}'
+WHERE COALESCE(transcription, '') <> '';
@@ -0,0 +1,90 @@
+-- поиск по переводам на языках учеников. Для kk и uz в Postgres нет морфологии,
+-- поэтому переводы индексируются конфигурацией simple, а опечатки ловят триграммы
+ALTER TABLE lexicon_translations
+    ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', search_join(translations)), 'B')) STORED,
+    ADD COLUMN IF NOT EXISTS search_text TEXT GENERATED ALWAYS AS (lower(search_join(translations))) STORED;
+
+ALTER TABLE phrase_translations
+    ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', translation), 'B')) STORED,
+    ADD COLUMN IF NOT EXISTS search_text TEXT GENERATED ALWAYS AS (lower(translation)) STORED;
+
+CREATE INDEX IF NOT EXISTS lexicon_translations_search_idx ON lexicon_translations USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS lexicon_translations_trgm_idx ON lexicon_translations USING GIN (search_text gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS phrase_translations_search_idx ON phrase_translations USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS phrase_translations_trgm_idx ON phrase_translations USING GIN (search_text gin_trgm_ops);
+
+-- слово и фраза попадают в поиск ещё раз с каждым своим переводом; без фонем,
+-- чтобы поиск по транскрипции не находил их повторно
+CREATE OR REPLACE VIEW search_documents AS
+SELECT CASE WHEN m.owner_id IS NULL THEN 'word_module' ELSE 'deck' END AS kind,
+       m.id,
+       m.id AS module_id,
+       m.owner_id,
+       m.title,
+       '' AS subtitle,
+       '' AS transcription,
+       '' AS phoneme_text,
+       m.search_vector,
+       m.search_text
+FROM word_modules m
+UNION ALL
+SELECT 'phrase_module', m.id, m.id, NULL, m.title, '', '', '', m.search_vector, m.search_text
+FROM phrase_modules m
+UNION ALL
+SELECT 'word',
+       l.id,
+       (SELECT e.module_id FROM word_exercise_entries x JOIN word_exercises e ON e.id = x.exercise_id
+        WHERE x.entry_id = l.id ORDER BY e.module_id, e.id LIMIT 1),
+       l.owner_id,
+       l.word,
+       COALESCE(l.translations[1], ''),
+       l.ipa,
+       ' ' || search_join(l.phonemes) || ' ',
+       l.search_vector,
+       l.search_text
+FROM lexicon_entries l
+UNION ALL
+SELECT 'phrase',
+       p.id,
+       p.module_id,
+       NULL,
+       COALESCE(p.sentence, ''),
+       COALESCE(p.translate, ''),
+       COALESCE(p.transcription, ''),
+       ' ' || search_join(p.phonemes) || ' ',
+       p.search_vector,
+       p.search_text
+FROM phrase_exercises p
+UNION ALL
+SELECT 'tip', t.id, NULL, NULL, t.phonema, t.tip_text, '/' || t.phonema || '/', ' ' || t.phonema || ' ',
+       t.search_vector, t.search_text
+FROM word_tip t
+UNION ALL
+SELECT 'word',
+       l.id,
+       (SELECT e.module_id FROM word_exercise_entries x JOIN word_exercises e ON e.id = x.exercise_id
+        WHERE x.entry_id = l.id ORDER BY e.module_id, e.id LIMIT 1),
+       l.owner_id,
+       l.word,
+       COALESCE(t.translations[1], ''),
+       l.ipa,
+       '',
+       t.search_vector,
+       t.search_text
+FROM lexicon_translations t
+JOIN lexicon_entries l ON l.id = t.entry_id
+UNION ALL
+SELECT 'phrase',
+       p.id,
+       p.module_id,
+       NULL,
+       COALESCE(p.sentence, ''),
+       t.translation,
+       COALESCE(p.transcription, ''),
+       '',
+       t.search_vector,
+       t.search_text
+FROM phrase_translations t
+JOIN phrase_exercises p ON p.id = t.exercise_id;
@@ -0,0 +1,4 @@
+-- дружба взаимная: строка friendships - заявка user_id пользователю friend_id, друзьями
+-- пользователи становятся, когда есть и встречная строка. Прежние односторонние подписки
+-- остаются заявками, которые можно принять, добавив отправителя в ответ
+CREATE INDEX IF NOT EXISTS friendships_friend_idx ON friendships (friend_id);
@@ -0,0 +1,3 @@
+-- темы статей словаря перенесены в topic_links (019.sql), старая колонка больше не читается
+ALTER TABLE lexicon_entries
+    DROP COLUMN IF EXISTS topic;
@@ -0,0 +1,103 @@
+package delivery
+
+import (
+	"errors"
+	"github.com/TeaStealers-backend-sem4/internal/activity"
+	"github.com/TeaStealers-backend-sem4/internal/activity/repo"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	"github.com/TeaStealers-backend-sem4/pkg/middleware"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+	"net/http"
+	"time"
+)
+
+type ActivityHandler struct {
+	uc     activity.ActivityUsecase
+	logger logger.Logger
+}
+
+func NewActivityHandler(uc activity.ActivityUsecase, logr logger.Logger) *ActivityHandler {
+	return &ActivityHandler{uc: uc, logger: logr}
+}
+
+func (h *ActivityHandler) GetActivityHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	var from, to time.Time
+	var err error
+	if value := r.URL.Query().Get("from"); value != "" {
+		if from, err = time.Parse(repo.DateLayout, value); err != nil {
+			utils.WriteError(w, http.StatusBadRequest, "from must be YYYY-MM-DD")
+			return
+		}
+	}
+	if value := r.URL.Query().Get("to"); value != "" {
+		if to, err = time.Parse(repo.DateLayout, value); err != nil {
+			utils.WriteError(w, http.StatusBadRequest, "to must be YYYY-MM-DD")
+			return
+		}
+	}
+
+	calendar, err := h.uc.GetActivity(r.Context(), UUID, from, to)
+	if err != nil {
+		if errors.Is(err, activity.ErrInvalidData) {
+			utils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetActivityHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "error get activity")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusOK, calendar); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetActivityHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "GetActivityHandler")
+}
+
+func (h *ActivityHandler) UpdateSettingsHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	settings := models.ActivitySettings{}
+	if err := utils.ReadRequestData(r, &settings); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "UpdateSettingsHandler", err, http.StatusBadRequest)
+		utils.WriteError(w, http.StatusBadRequest, "incorrect data format")
+		return
+	}
+
+	saved, err := h.uc.UpdateSettings(r.Context(), UUID, &settings)
+	if err != nil {
+		if errors.Is(err, activity.ErrInvalidData) {
+			utils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "UpdateSettingsHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "error save activity settings")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusOK, saved); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "UpdateSettingsHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "UpdateSettingsHandler")
+}
@@ -0,0 +1,18 @@
+package activity
+
+import (
+	"context"
+	"errors"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/satori/uuid"
+	"time"
+)
+
+var ErrInvalidData = errors.New("invalid data")
+
+type ActivityUsecase interface {
+	OnAttempt(ctx context.Context, tx models.Transaction, attempt *models.ExerciseAttempt, status string) error
+
+	GetActivity(ctx context.Context, userID uuid.UUID, from, to time.Time) (*models.ActivityCalendar, error)
+	UpdateSettings(ctx context.Context, userID uuid.UUID, settings *models.ActivitySettings) (*models.ActivitySettings, error)
+}
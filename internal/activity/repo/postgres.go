@@ -0,0 +1,208 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/lib/pq"
+	"github.com/satori/uuid"
+	"time"
+)
+
+// DateLayout - формат, в котором дни передаются в базу: так дата не зависит от часового пояса сессии.
+const DateLayout = "2006-01-02"
+
+type ActivityRepo struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewRepository(db *sql.DB, logger logger.Logger) *ActivityRepo {
+	return &ActivityRepo{db: db, logger: logger}
+}
+
+func (r *ActivityRepo) BeginTx(ctx context.Context) (models.Transaction, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func (r *ActivityRepo) GetTimezone(ctx context.Context, tx models.Transaction, userID uuid.UUID) (string, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var timezone string
+	if err := tx.QueryRowContext(ctx, SelectUserTimezoneSql, userID).Scan(&timezone); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetTimezone", err)
+		return "", fmt.Errorf("failed to get user timezone: %w", err)
+	}
+	return timezone, nil
+}
+
+func (r *ActivityRepo) UpdateTimezone(ctx context.Context, tx models.Transaction, userID uuid.UUID, timezone string) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	if _, err := tx.ExecContext(ctx, UpdateUserTimezoneSql, userID, timezone); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "UpdateTimezone", err)
+		return fmt.Errorf("failed to update user timezone: %w", err)
+	}
+	return nil
+}
+
+// GetStreak создаёт серию пользователя при первом обращении. С forUpdate строка
+// блокируется до конца транзакции.
+func (r *ActivityRepo) GetStreak(ctx context.Context, tx models.Transaction, userID uuid.UUID, forUpdate bool) (*models.StreakState, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	if _, err := tx.ExecContext(ctx, InsertStreakSql, userID); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetStreak", err)
+		return nil, fmt.Errorf("failed to create streak: %w", err)
+	}
+
+	query := SelectStreakSql
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
+
+	state := models.StreakState{UserID: userID}
+	var lastGoalDay sql.NullTime
+	err := tx.QueryRowContext(ctx, query, userID).Scan(
+		&state.GoalType,
+		&state.GoalValue,
+		&state.CurrentStreak,
+		&state.LongestStreak,
+		&state.Freezes,
+		&lastGoalDay,
+	)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetStreak", err)
+		return nil, fmt.Errorf("failed to get streak: %w", err)
+	}
+	if lastGoalDay.Valid {
+		day := lastGoalDay.Time
+		state.LastGoalDay = &day
+	}
+
+	return &state, nil
+}
+
+func (r *ActivityRepo) UpdateStreak(ctx context.Context, tx models.Transaction, state *models.StreakState) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var lastGoalDay *string
+	if state.LastGoalDay != nil {
+		day := state.LastGoalDay.Format(DateLayout)
+		lastGoalDay = &day
+	}
+
+	_, err := tx.ExecContext(ctx, UpdateStreakSql,
+		state.UserID,
+		state.CurrentStreak,
+		state.LongestStreak,
+		state.Freezes,
+		lastGoalDay,
+	)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "UpdateStreak", err)
+		return fmt.Errorf("failed to update streak: %w", err)
+	}
+	return nil
+}
+
+func (r *ActivityRepo) UpdateGoal(ctx context.Context, tx models.Transaction, userID uuid.UUID, goalType string, goalValue int) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	if _, err := tx.ExecContext(ctx, UpdateGoalSql, userID, goalType, goalValue); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "UpdateGoal", err)
+		return fmt.Errorf("failed to update daily goal: %w", err)
+	}
+	return nil
+}
+
+// AddDailyActivity засчитывает попытку в активность дня и возвращает итог дня.
+func (r *ActivityRepo) AddDailyActivity(ctx context.Context, tx models.Transaction, userID uuid.UUID, day time.Time, completed bool, durationMs int) (*models.ActivityDay, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	completedCount := 0
+	if completed {
+		completedCount = 1
+	}
+
+	var result models.ActivityDay
+	err := tx.QueryRowContext(ctx, AddDailyActivitySql, userID, day.Format(DateLayout), completedCount, durationMs).Scan(
+		&result.Day,
+		&result.Exercises,
+		&result.Completed,
+		&result.DurationMs,
+		&result.GoalMet,
+		&result.Frozen,
+	)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "AddDailyActivity", err)
+		return nil, fmt.Errorf("failed to add daily activity: %w", err)
+	}
+	return &result, nil
+}
+
+func (r *ActivityRepo) MarkGoalMet(ctx context.Context, tx models.Transaction, userID uuid.UUID, day time.Time) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	if _, err := tx.ExecContext(ctx, MarkGoalMetSql, userID, day.Format(DateLayout)); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "MarkGoalMet", err)
+		return fmt.Errorf("failed to mark goal met: %w", err)
+	}
+	return nil
+}
+
+// InsertFrozenDays отмечает пропущенные дни, закрытые заморозками серии.
+func (r *ActivityRepo) InsertFrozenDays(ctx context.Context, tx models.Transaction, userID uuid.UUID, days []time.Time) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	if len(days) == 0 {
+		return nil
+	}
+
+	dates := make([]string, len(days))
+	for i, day := range days {
+		dates[i] = day.Format(DateLayout)
+	}
+
+	if _, err := tx.ExecContext(ctx, InsertFrozenDaysSql, userID, pq.Array(dates)); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "InsertFrozenDays", err)
+		return fmt.Errorf("failed to insert frozen days: %w", err)
+	}
+	return nil
+}
+
+func (r *ActivityRepo) GetActivityDays(ctx context.Context, tx models.Transaction, userID uuid.UUID, from, to time.Time) ([]models.ActivityDay, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	rows, err := tx.QueryContext(ctx, SelectActivityDaysSql, userID, from.Format(DateLayout), to.Format(DateLayout))
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetActivityDays", err)
+		return nil, fmt.Errorf("failed to query activity days: %w", err)
+	}
+	defer rows.Close()
+
+	days := make([]models.ActivityDay, 0)
+	for rows.Next() {
+		var day models.ActivityDay
+		if err := rows.Scan(&day.Day, &day.Exercises, &day.Completed, &day.DurationMs, &day.GoalMet, &day.Frozen); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetActivityDays", err)
+			return nil, fmt.Errorf("failed to scan activity day: %w", err)
+		}
+		day.Date = day.Day.Format(DateLayout)
+		days = append(days, day)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetActivityDays", err)
+		return nil, fmt.Errorf("error after iterating activity days: %w", err)
+	}
+
+	return days, nil
+}
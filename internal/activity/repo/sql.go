@@ -0,0 +1,59 @@
+package repo
+
+const (
+	SelectUserTimezoneSql = `
+        SELECT timezone FROM users WHERE id = $1
+    `
+
+	UpdateUserTimezoneSql = `
+        UPDATE users SET timezone = $2 WHERE id = $1
+    `
+
+	InsertStreakSql = `
+        INSERT INTO user_streaks (user_id) VALUES ($1)
+        ON CONFLICT (user_id) DO NOTHING
+    `
+
+	SelectStreakSql = `
+        SELECT goal_type, goal_value, current_streak, longest_streak, freezes, last_goal_day
+        FROM user_streaks
+        WHERE user_id = $1
+    `
+
+	UpdateStreakSql = `
+        UPDATE user_streaks
+        SET current_streak = $2, longest_streak = $3, freezes = $4, last_goal_day = $5
+        WHERE user_id = $1
+    `
+
+	UpdateGoalSql = `
+        UPDATE user_streaks SET goal_type = $2, goal_value = $3 WHERE user_id = $1
+    `
+
+	AddDailyActivitySql = `
+        INSERT INTO daily_activity (user_id, day, exercises, completed, duration_ms)
+        VALUES ($1, $2, 1, $3, $4)
+        ON CONFLICT (user_id, day)
+        DO UPDATE SET exercises = daily_activity.exercises + 1,
+                      completed = daily_activity.completed + EXCLUDED.completed,
+                      duration_ms = daily_activity.duration_ms + EXCLUDED.duration_ms
+        RETURNING day, exercises, completed, duration_ms, goal_met, frozen
+    `
+
+	MarkGoalMetSql = `
+        UPDATE daily_activity SET goal_met = TRUE WHERE user_id = $1 AND day = $2
+    `
+
+	InsertFrozenDaysSql = `
+        INSERT INTO daily_activity (user_id, day, frozen)
+        SELECT $1, d, TRUE FROM unnest($2::date[]) AS d
+        ON CONFLICT (user_id, day) DO UPDATE SET frozen = TRUE
+    `
+
+	SelectActivityDaysSql = `
+        SELECT day, exercises, completed, duration_ms, goal_met, frozen
+        FROM daily_activity
+        WHERE user_id = $1 AND day BETWEEN $2 AND $3
+        ORDER BY day
+    `
+)
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"time"
+)
+
+// location возвращает часовой пояс пользователя, а для неизвестного - UTC.
+func location(timezone string) *time.Location {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// dayIn возвращает календарный день момента t в поясе loc как полночь UTC,
+// чтобы разница между днями не зависела от перехода на летнее время.
+func dayIn(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+func goalMet(state *models.StreakState, day *models.ActivityDay) bool {
+	if state.GoalType == models.GoalMinutes {
+		return day.DurationMs >= int64(state.GoalValue)*int64(time.Minute/time.Millisecond)
+	}
+	return day.Exercises >= state.GoalValue
+}
+
+// extendStreak засчитывает выполненную в day цель. Пропущенные с прошлой цели дни
+// закрываются заморозками, если их хватает, иначе серия начинается заново.
+// Возвращает дни, закрытые заморозками.
+func extendStreak(state *models.StreakState, day time.Time, freezeEvery, maxFreezes int) []time.Time {
+	var frozen []time.Time
+
+	if state.LastGoalDay == nil {
+		state.CurrentStreak = 1
+	} else {
+		gap := daysBetween(*state.LastGoalDay, day)
+		if gap <= 0 {
+			return nil
+		}
+
+		missed := gap - 1
+		if missed <= state.Freezes {
+			for i := 1; i <= missed; i++ {
+				frozen = append(frozen, state.LastGoalDay.AddDate(0, 0, i))
+			}
+			state.Freezes -= missed
+			state.CurrentStreak++
+		} else {
+			state.CurrentStreak = 1
+		}
+	}
+
+	state.LastGoalDay = &day
+	state.LongestStreak = max(state.LongestStreak, state.CurrentStreak)
+	if freezeEvery > 0 && state.CurrentStreak%freezeEvery == 0 && state.Freezes < maxFreezes {
+		state.Freezes++
+	}
+
+	return frozen
+}
+
+// currentStreak возвращает серию на сегодня: она жива, пока пропущенные дни до сегодняшнего
+// можно закрыть оставшимися заморозками.
+func currentStreak(state *models.StreakState, today time.Time) int {
+	if state.LastGoalDay == nil {
+		return 0
+	}
+	missed := daysBetween(*state.LastGoalDay, today) - 1
+	if missed > state.Freezes {
+		return 0
+	}
+	return state.CurrentStreak
+}
@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/activity"
+	"github.com/TeaStealers-backend-sem4/internal/activity/repo"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/config"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+	"time"
+)
+
+const (
+	defaultCalendarDays = 90
+	maxCalendarDays     = 366
+	maxGoalExercises    = 500
+	maxGoalMinutes      = 600
+)
+
+type ActivityUsecase struct {
+	repo   *repo.ActivityRepo
+	logger logger.Logger
+	cfg    *config.Config
+}
+
+func NewActivityUsecase(repo *repo.ActivityRepo, logger logger.Logger, cfg *config.Config) *ActivityUsecase {
+	return &ActivityUsecase{repo: repo, logger: logger, cfg: cfg}
+}
+
+// OnAttempt засчитывает попытку в активность текущего дня пользователя и, когда дневная
+// цель впервые за день выполнена, продлевает серию.
+func (uc *ActivityUsecase) OnAttempt(ctx context.Context, tx models.Transaction, attempt *models.ExerciseAttempt, _ string) error {
+	timezone, err := uc.repo.GetTimezone(ctx, tx, attempt.UserID)
+	if err != nil {
+		return err
+	}
+	today := dayIn(time.Now(), location(timezone))
+
+	state, err := uc.repo.GetStreak(ctx, tx, attempt.UserID, true)
+	if err != nil {
+		return err
+	}
+
+	day, err := uc.repo.AddDailyActivity(ctx, tx, attempt.UserID, today, attempt.Result == "completed", attempt.DurationMs)
+	if err != nil {
+		return err
+	}
+	if day.GoalMet || !goalMet(state, day) {
+		return nil
+	}
+
+	if err := uc.repo.MarkGoalMet(ctx, tx, attempt.UserID, today); err != nil {
+		return err
+	}
+
+	frozen := extendStreak(state, today, uc.cfg.Streak.FreezeEvery, uc.cfg.Streak.MaxFreezes)
+	if err := uc.repo.InsertFrozenDays(ctx, tx, attempt.UserID, frozen); err != nil {
+		return err
+	}
+	if err := uc.repo.UpdateStreak(ctx, tx, state); err != nil {
+		return err
+	}
+
+	uc.logger.LogInfo(utils.GetRequestIDFromCtx(ctx), logger.UsecaseLayer, "OnAttempt",
+		fmt.Sprintf("daily goal met, streak %d", state.CurrentStreak))
+	return nil
+}
+
+// GetActivity возвращает календарь активности за [from, to] и состояние серии. Нулевые
+// границы заменяются последними defaultCalendarDays днями в часовом поясе пользователя.
+func (uc *ActivityUsecase) GetActivity(ctx context.Context, userID uuid.UUID, from, to time.Time) (*models.ActivityCalendar, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	tx, err := uc.repo.BeginTx(ctx)
+	if err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetActivity",
+			fmt.Errorf("failed to begin transaction: %w", err))
+		return nil, errors.New("failed to start transaction")
+	}
+	defer tx.Rollback()
+
+	timezone, err := uc.repo.GetTimezone(ctx, tx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get activity: %w", err)
+	}
+	today := dayIn(time.Now(), location(timezone))
+
+	if to.IsZero() {
+		to = today
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -(defaultCalendarDays - 1))
+	}
+	if from.After(to) {
+		return nil, fmt.Errorf("%w: from must not be after to", activity.ErrInvalidData)
+	}
+	if daysBetween(from, to) >= maxCalendarDays {
+		return nil, fmt.Errorf("%w: range must not exceed %d days", activity.ErrInvalidData, maxCalendarDays)
+	}
+
+	state, err := uc.repo.GetStreak(ctx, tx, userID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get activity: %w", err)
+	}
+
+	days, err := uc.repo.GetActivityDays(ctx, tx, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get activity: %w", err)
+	}
+
+	todayActivity := models.ActivityDay{Day: today, Date: today.Format(repo.DateLayout)}
+	if daysToday, err := uc.repo.GetActivityDays(ctx, tx, userID, today, today); err != nil {
+		return nil, fmt.Errorf("failed to get activity: %w", err)
+	} else if len(daysToday) > 0 {
+		todayActivity = daysToday[0]
+	}
+
+	if err := tx.Commit(); err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetActivity",
+			fmt.Errorf("failed to commit transaction: %w", err))
+		return nil, errors.New("failed to get activity")
+	}
+
+	return &models.ActivityCalendar{
+		Timezone:      timezone,
+		GoalType:      state.GoalType,
+		GoalValue:     state.GoalValue,
+		CurrentStreak: currentStreak(state, today),
+		LongestStreak: state.LongestStreak,
+		Freezes:       state.Freezes,
+		Today:         todayActivity,
+		Days:          days,
+	}, nil
+}
+
+// UpdateSettings меняет часовой пояс и дневную цель; незаданные поля остаются прежними.
+func (uc *ActivityUsecase) UpdateSettings(ctx context.Context, userID uuid.UUID, settings *models.ActivitySettings) (*models.ActivitySettings, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	if settings.Timezone != nil {
+		if _, err := time.LoadLocation(*settings.Timezone); err != nil || *settings.Timezone == "" || *settings.Timezone == "Local" {
+			return nil, fmt.Errorf("%w: unknown timezone %q", activity.ErrInvalidData, *settings.Timezone)
+		}
+	}
+
+	tx, err := uc.repo.BeginTx(ctx)
+	if err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "UpdateSettings",
+			fmt.Errorf("failed to begin transaction: %w", err))
+		return nil, errors.New("failed to start transaction")
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	state, err := uc.repo.GetStreak(ctx, tx, userID, true)
+	if err != nil {
+		return nil, errors.New("failed to save activity settings")
+	}
+
+	goalType, goalValue := state.GoalType, state.GoalValue
+	if settings.GoalType != nil {
+		goalType = *settings.GoalType
+	}
+	if settings.GoalValue != nil {
+		goalValue = *settings.GoalValue
+	}
+
+	switch {
+	case goalType != models.GoalExercises && goalType != models.GoalMinutes:
+		err = fmt.Errorf("%w: goal_type must be exercises or minutes", activity.ErrInvalidData)
+	case goalType == models.GoalExercises && (goalValue <= 0 || goalValue > maxGoalExercises):
+		err = fmt.Errorf("%w: goal_value must be between 1 and %d exercises", activity.ErrInvalidData, maxGoalExercises)
+	case goalType == models.GoalMinutes && (goalValue <= 0 || goalValue > maxGoalMinutes):
+		err = fmt.Errorf("%w: goal_value must be between 1 and %d minutes", activity.ErrInvalidData, maxGoalMinutes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = uc.repo.UpdateGoal(ctx, tx, userID, goalType, goalValue); err != nil {
+		return nil, errors.New("failed to save activity settings")
+	}
+
+	if settings.Timezone != nil {
+		if err = uc.repo.UpdateTimezone(ctx, tx, userID, *settings.Timezone); err != nil {
+			return nil, errors.New("failed to save activity settings")
+		}
+	}
+
+	timezone, err := uc.repo.GetTimezone(ctx, tx, userID)
+	if err != nil {
+		return nil, errors.New("failed to save activity settings")
+	}
+
+	if err = tx.Commit(); err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "UpdateSettings",
+			fmt.Errorf("failed to commit transaction: %w", err))
+		return nil, errors.New("failed to save activity settings")
+	}
+
+	return &models.ActivitySettings{Timezone: &timezone, GoalType: &goalType, GoalValue: &goalValue}, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/TeaStealers-backend-sem4/internal/models"
 	"github.com/TeaStealers-backend-sem4/pkg/config"
 	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	"github.com/TeaStealers-backend-sem4/pkg/phonetics"
 	"github.com/TeaStealers-backend-sem4/pkg/utils"
 	"net/http"
 	"path/filepath"
@@ -17,10 +18,11 @@ import (
 type AudioHandler struct {
 	logger logger.Logger
 	cfg    *config.Config
+	scorer *phonetics.Scorer
 }
 
-func NewAudioHandler(cfg *config.Config, logr logger.Logger) *AudioHandler {
-	return &AudioHandler{cfg: cfg, logger: logr}
+func NewAudioHandler(cfg *config.Config, logr logger.Logger, scorer *phonetics.Scorer) *AudioHandler {
+	return &AudioHandler{cfg: cfg, logger: logr, scorer: scorer}
 }
 
 func (h *AudioHandler) TranscribeWordHandler(w http.ResponseWriter, r *http.Request) {
@@ -78,6 +80,10 @@ func (h *AudioHandler) TranscribeWordHandler(w http.ResponseWriter, r *http.Requ
 
 	fmt.Printf("ml transcription %s", mlAns.Transcription)
 
+	if expected := r.FormValue("expected"); expected != "" {
+		mlAns.Score = h.scorer.Score(utils.ParseStringArray(expected), mlAns.Transcription)
+	}
+
 	if err := utils.WriteResponse(w, http.StatusOK, mlAns); err != nil {
 		h.logger.LogError(requestId, logger.DeliveryLayer, "TranslateAudio", err)
 		utils.WriteError(w, http.StatusInternalServerError, err.Error())
@@ -46,14 +46,19 @@ func (r *AuthRepo) GetUserByLogin(ctx context.Context, login string) (*models.Us
 }
 
 func (r *AuthRepo) GetUserByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
-	query := `SELECT id, email, name, passwordhash, levelupdate FROM users WHERE id = $1`
+	query := `SELECT id, email, name, passwordhash, levelupdate, start_word_module FROM users WHERE id = $1`
 
 	res := r.db.QueryRowContext(ctx, query, id)
 
 	user := &models.User{}
-	if err := res.Scan(&user.ID, &user.Email, &user.Name, &user.PasswordHash, &user.LevelUpdate); err != nil {
+	var startModule sql.NullInt64
+	if err := res.Scan(&user.ID, &user.Email, &user.Name, &user.PasswordHash, &user.LevelUpdate, &startModule); err != nil {
 		return nil, err
 	}
+	if startModule.Valid {
+		module := int(startModule.Int64)
+		user.StartWordModule = &module
+	}
 
 	return user, nil
 }
@@ -0,0 +1,285 @@
+package delivery
+
+import (
+	"errors"
+	"github.com/TeaStealers-backend-sem4/internal/classroom"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	"github.com/TeaStealers-backend-sem4/pkg/middleware"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/gorilla/mux"
+	"github.com/satori/uuid"
+	"net/http"
+	"strconv"
+)
+
+type ClassroomHandler struct {
+	uc        classroom.ClassroomUsecase
+	logger    logger.Logger
+	minClient *utils.FileStorageClient
+}
+
+func NewClassroomHandler(uc classroom.ClassroomUsecase, logr logger.Logger, minCl *utils.FileStorageClient) *ClassroomHandler {
+	return &ClassroomHandler{uc: uc, logger: logr, minClient: minCl}
+}
+
+// writeError переводит ошибки usecase в коды ответа.
+func (h *ClassroomHandler) writeError(w http.ResponseWriter, requestId, method string, err error, message string) {
+	switch {
+	case errors.Is(err, classroom.ErrNotFound):
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, method, err, http.StatusNotFound)
+		utils.WriteError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, classroom.ErrForbidden):
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, method, err, http.StatusForbidden)
+		utils.WriteError(w, http.StatusForbidden, "access denied")
+	case errors.Is(err, classroom.ErrInvalidData):
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, method, err, http.StatusBadRequest)
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, method, err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, message)
+	}
+}
+
+func (h *ClassroomHandler) writeResponse(w http.ResponseWriter, requestId, method string, status int, data any) {
+	if err := utils.WriteResponse(w, status, data); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, method, err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, method)
+}
+
+func parseClassID(r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	return id, err == nil && id > 0
+}
+
+func (h *ClassroomHandler) CreateClassHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	data := models.ClassCreate{}
+	if err := utils.ReadRequestData(r, &data); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "CreateClassHandler", err, http.StatusBadRequest)
+		utils.WriteError(w, http.StatusBadRequest, "incorrect data format")
+		return
+	}
+
+	class, err := h.uc.CreateClass(r.Context(), UUID, &data)
+	if err != nil {
+		h.writeError(w, requestId, "CreateClassHandler", err, "error create class")
+		return
+	}
+
+	h.writeResponse(w, requestId, "CreateClassHandler", http.StatusCreated, class)
+}
+
+func (h *ClassroomHandler) GetClassesHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	classes, err := h.uc.GetClasses(r.Context(), UUID)
+	if err != nil {
+		h.writeError(w, requestId, "GetClassesHandler", err, "error get classes")
+		return
+	}
+
+	h.writeResponse(w, requestId, "GetClassesHandler", http.StatusOK, classes)
+}
+
+func (h *ClassroomHandler) RegenerateJoinCodeHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	classID, ok := parseClassID(r)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "invalid class ID format")
+		return
+	}
+
+	class, err := h.uc.RegenerateJoinCode(r.Context(), UUID, classID)
+	if err != nil {
+		h.writeError(w, requestId, "RegenerateJoinCodeHandler", err, "error regenerate join code")
+		return
+	}
+
+	h.writeResponse(w, requestId, "RegenerateJoinCodeHandler", http.StatusOK, class)
+}
+
+func (h *ClassroomHandler) JoinClassHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	data := models.JoinClassRequest{}
+	if err := utils.ReadRequestData(r, &data); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "JoinClassHandler", err, http.StatusBadRequest)
+		utils.WriteError(w, http.StatusBadRequest, "incorrect data format")
+		return
+	}
+
+	class, err := h.uc.JoinClass(r.Context(), UUID, data.Code)
+	if err != nil {
+		h.writeError(w, requestId, "JoinClassHandler", err, "error join class")
+		return
+	}
+
+	h.writeResponse(w, requestId, "JoinClassHandler", http.StatusOK, class)
+}
+
+func (h *ClassroomHandler) RemoveStudentHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	classID, ok := parseClassID(r)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "invalid class ID format")
+		return
+	}
+	studentID, err := uuid.FromString(mux.Vars(r)["student_id"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "invalid student ID format")
+		return
+	}
+
+	if err := h.uc.RemoveStudent(r.Context(), UUID, classID, studentID); err != nil {
+		h.writeError(w, requestId, "RemoveStudentHandler", err, "error remove student")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "RemoveStudentHandler")
+}
+
+func (h *ClassroomHandler) GetAssignmentsHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	classID, ok := parseClassID(r)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "invalid class ID format")
+		return
+	}
+
+	assignments, err := h.uc.GetAssignments(r.Context(), UUID, classID)
+	if err != nil {
+		h.writeError(w, requestId, "GetAssignmentsHandler", err, "error get assignments")
+		return
+	}
+
+	h.writeResponse(w, requestId, "GetAssignmentsHandler", http.StatusOK, assignments)
+}
+
+func (h *ClassroomHandler) CreateAssignmentHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	classID, ok := parseClassID(r)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "invalid class ID format")
+		return
+	}
+
+	data := models.AssignmentCreate{}
+	if err := utils.ReadRequestData(r, &data); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "CreateAssignmentHandler", err, http.StatusBadRequest)
+		utils.WriteError(w, http.StatusBadRequest, "incorrect data format")
+		return
+	}
+
+	assignment, err := h.uc.CreateAssignment(r.Context(), UUID, classID, &data)
+	if err != nil {
+		h.writeError(w, requestId, "CreateAssignmentHandler", err, "error create assignment")
+		return
+	}
+
+	h.writeResponse(w, requestId, "CreateAssignmentHandler", http.StatusCreated, assignment)
+}
+
+func (h *ClassroomHandler) DeleteAssignmentHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	classID, ok := parseClassID(r)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "invalid class ID format")
+		return
+	}
+	assignmentID, err := strconv.Atoi(mux.Vars(r)["assignment_id"])
+	if err != nil || assignmentID <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "invalid assignment ID format")
+		return
+	}
+
+	if err := h.uc.DeleteAssignment(r.Context(), UUID, classID, assignmentID); err != nil {
+		h.writeError(w, requestId, "DeleteAssignmentHandler", err, "error delete assignment")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "DeleteAssignmentHandler")
+}
+
+func (h *ClassroomHandler) GetDashboardHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	classID, ok := parseClassID(r)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "invalid class ID format")
+		return
+	}
+
+	dashboard, err := h.uc.GetDashboard(r.Context(), UUID, classID)
+	if err != nil {
+		h.writeError(w, requestId, "GetDashboardHandler", err, "error get class dashboard")
+		return
+	}
+
+	h.writeResponse(w, requestId, "GetDashboardHandler", http.StatusOK, dashboard)
+}
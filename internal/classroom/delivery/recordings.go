@@ -0,0 +1,96 @@
+package delivery
+
+import (
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	"github.com/TeaStealers-backend-sem4/pkg/middleware"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/gorilla/mux"
+	"github.com/satori/uuid"
+	"net/http"
+	"strconv"
+)
+
+const (
+	defaultRecordingsLimit = 20
+	maxRecordingsLimit     = 100
+)
+
+func (h *ClassroomHandler) GetRecordingsHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	query := r.URL.Query()
+	classID := 0
+	if value := query.Get("class_id"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			utils.WriteError(w, http.StatusBadRequest, "invalid class ID format")
+			return
+		}
+		classID = parsed
+	}
+	limit := defaultRecordingsLimit
+	if value := query.Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			utils.WriteError(w, http.StatusBadRequest, "limit must be positive int")
+			return
+		}
+		limit = min(parsed, maxRecordingsLimit)
+	}
+
+	queue, err := h.uc.GetRecordings(r.Context(), UUID, classID, query.Get("status"), limit)
+	if err != nil {
+		h.writeError(w, requestId, "GetRecordingsHandler", err, "error get recordings")
+		return
+	}
+
+	for i := range queue.Recordings {
+		rec := &queue.Recordings[i]
+		link, err := h.minClient.GetFileLink(rec.RecordingID)
+		if err != nil {
+			h.logger.LogError(requestId, logger.DeliveryLayer, "GetRecordingsHandler", err)
+			continue
+		}
+		rec.RecordingURL = link
+	}
+
+	h.writeResponse(w, requestId, "GetRecordingsHandler", http.StatusOK, queue)
+}
+
+func (h *ClassroomHandler) GradeRecordingHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	attemptID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || attemptID <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "invalid recording ID format")
+		return
+	}
+
+	grade := models.RecordingGrade{}
+	if err := utils.ReadRequestData(r, &grade); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GradeRecordingHandler", err, http.StatusBadRequest)
+		utils.WriteError(w, http.StatusBadRequest, "incorrect data format")
+		return
+	}
+
+	result, err := h.uc.GradeRecording(r.Context(), UUID, attemptID, &grade)
+	if err != nil {
+		h.writeError(w, requestId, "GradeRecordingHandler", err, "error grade recording")
+		return
+	}
+
+	h.writeResponse(w, requestId, "GradeRecordingHandler", http.StatusOK, result)
+}
@@ -0,0 +1,31 @@
+package classroom
+
+import (
+	"context"
+	"errors"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/satori/uuid"
+)
+
+var (
+	ErrNotFound    = errors.New("not found")
+	ErrForbidden   = errors.New("forbidden")
+	ErrInvalidData = errors.New("invalid data")
+)
+
+type ClassroomUsecase interface {
+	CreateClass(ctx context.Context, teacherID uuid.UUID, class *models.ClassCreate) (*models.Class, error)
+	GetClasses(ctx context.Context, userID uuid.UUID) (*models.ClassList, error)
+	RegenerateJoinCode(ctx context.Context, teacherID uuid.UUID, classID int) (*models.Class, error)
+	JoinClass(ctx context.Context, userID uuid.UUID, code string) (*models.Class, error)
+	RemoveStudent(ctx context.Context, userID uuid.UUID, classID int, studentID uuid.UUID) error
+
+	GetAssignments(ctx context.Context, userID uuid.UUID, classID int) (*models.AssignmentList, error)
+	CreateAssignment(ctx context.Context, teacherID uuid.UUID, classID int, assignment *models.AssignmentCreate) (*models.Assignment, error)
+	DeleteAssignment(ctx context.Context, teacherID uuid.UUID, classID, assignmentID int) error
+
+	GetDashboard(ctx context.Context, teacherID uuid.UUID, classID int) (*models.ClassDashboard, error)
+
+	GetRecordings(ctx context.Context, teacherID uuid.UUID, classID int, status string, limit int) (*models.RecordingQueue, error)
+	GradeRecording(ctx context.Context, teacherID uuid.UUID, attemptID int, grade *models.RecordingGrade) (*models.RecordingGrade, error)
+}
@@ -0,0 +1,462 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+	"time"
+)
+
+type ClassroomRepo struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewRepository(db *sql.DB, logger logger.Logger) *ClassroomRepo {
+	return &ClassroomRepo{db: db, logger: logger}
+}
+
+// CreateClass возвращает false, если код приглашения уже занят другим классом.
+func (r *ClassroomRepo) CreateClass(ctx context.Context, class *models.Class) (bool, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	err := r.db.QueryRowContext(ctx, InsertClassSql, class.TeacherID, class.Title, class.JoinCode).Scan(&class.ID, &class.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		r.logger.LogError(requestId, logger.RepositoryLayer, "CreateClass", err)
+		return false, fmt.Errorf("failed to create class: %w", err)
+	}
+	return true, nil
+}
+
+// UpdateJoinCode возвращает false, если код уже занят.
+func (r *ClassroomRepo) UpdateJoinCode(ctx context.Context, classID int, code string) (bool, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	res, err := r.db.ExecContext(ctx, UpdateJoinCodeSql, classID, code)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "UpdateJoinCode", err)
+		return false, fmt.Errorf("failed to update join code: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to update join code: %w", err)
+	}
+	return affected > 0, nil
+}
+
+func scanClass(row interface{ Scan(dest ...any) error }) (*models.Class, error) {
+	var class models.Class
+	if err := row.Scan(&class.ID, &class.TeacherID, &class.Title, &class.JoinCode, &class.CreatedAt, &class.Students); err != nil {
+		return nil, err
+	}
+	return &class, nil
+}
+
+// GetClass возвращает nil, если класса нет.
+func (r *ClassroomRepo) GetClass(ctx context.Context, classID int) (*models.Class, error) {
+	return r.getClass(ctx, "GetClass", SelectClassSql, classID)
+}
+
+// GetClassByCode возвращает nil, если класса с таким кодом нет.
+func (r *ClassroomRepo) GetClassByCode(ctx context.Context, code string) (*models.Class, error) {
+	return r.getClass(ctx, "GetClassByCode", SelectClassByCodeSql, code)
+}
+
+func (r *ClassroomRepo) getClass(ctx context.Context, method, query string, arg any) (*models.Class, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	class, err := scanClass(r.db.QueryRowContext(ctx, query, arg))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		r.logger.LogError(requestId, logger.RepositoryLayer, method, err)
+		return nil, fmt.Errorf("failed to get class: %w", err)
+	}
+	return class, nil
+}
+
+func (r *ClassroomRepo) GetTeacherClasses(ctx context.Context, teacherID uuid.UUID) ([]models.Class, error) {
+	return r.getClasses(ctx, "GetTeacherClasses", SelectTeacherClassesSql, teacherID)
+}
+
+func (r *ClassroomRepo) GetEnrolledClasses(ctx context.Context, userID uuid.UUID) ([]models.Class, error) {
+	return r.getClasses(ctx, "GetEnrolledClasses", SelectEnrolledClassesSql, userID)
+}
+
+func (r *ClassroomRepo) getClasses(ctx context.Context, method, query string, userID uuid.UUID) ([]models.Class, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, method, err)
+		return nil, fmt.Errorf("failed to query classes: %w", err)
+	}
+	defer rows.Close()
+
+	classes := make([]models.Class, 0)
+	for rows.Next() {
+		class, err := scanClass(rows)
+		if err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, method, err)
+			return nil, fmt.Errorf("failed to scan class: %w", err)
+		}
+		classes = append(classes, *class)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, method, err)
+		return nil, fmt.Errorf("error after iterating classes: %w", err)
+	}
+
+	return classes, nil
+}
+
+func (r *ClassroomRepo) IsMember(ctx context.Context, classID int, userID uuid.UUID) (bool, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var member bool
+	if err := r.db.QueryRowContext(ctx, IsClassMemberSql, classID, userID).Scan(&member); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "IsMember", err)
+		return false, fmt.Errorf("failed to check class membership: %w", err)
+	}
+	return member, nil
+}
+
+func (r *ClassroomRepo) AddMember(ctx context.Context, classID int, userID uuid.UUID) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	if _, err := r.db.ExecContext(ctx, InsertClassMemberSql, classID, userID); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "AddMember", err)
+		return fmt.Errorf("failed to add class member: %w", err)
+	}
+	return nil
+}
+
+// RemoveMember возвращает false, если пользователь не состоял в классе.
+func (r *ClassroomRepo) RemoveMember(ctx context.Context, classID int, userID uuid.UUID) (bool, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	res, err := r.db.ExecContext(ctx, DeleteClassMemberSql, classID, userID)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "RemoveMember", err)
+		return false, fmt.Errorf("failed to remove class member: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to remove class member: %w", err)
+	}
+	return affected > 0, nil
+}
+
+func (r *ClassroomRepo) GetStudents(ctx context.Context, classID int) ([]models.ClassStudent, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, SelectClassStudentsSql, classID)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetStudents", err)
+		return nil, fmt.Errorf("failed to query class students: %w", err)
+	}
+	defer rows.Close()
+
+	students := make([]models.ClassStudent, 0)
+	for rows.Next() {
+		var s models.ClassStudent
+		if err := rows.Scan(&s.UserID, &s.Name, &s.Email, &s.JoinedAt); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetStudents", err)
+			return nil, fmt.Errorf("failed to scan class student: %w", err)
+		}
+		students = append(students, s)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetStudents", err)
+		return nil, fmt.Errorf("error after iterating class students: %w", err)
+	}
+
+	return students, nil
+}
+
+// GetModuleTitle возвращает nil, если модуля такого типа нет.
+func (r *ClassroomRepo) GetModuleTitle(ctx context.Context, moduleType string, moduleID int) (*string, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var title string
+	if err := r.db.QueryRowContext(ctx, SelectModuleTitleSql, moduleType, moduleID).Scan(&title); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetModuleTitle", err)
+		return nil, fmt.Errorf("failed to get module: %w", err)
+	}
+	return &title, nil
+}
+
+// CreateAssignment назначает модуль классу; повторное назначение того же модуля меняет срок сдачи.
+func (r *ClassroomRepo) CreateAssignment(ctx context.Context, assignment *models.Assignment) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	err := r.db.QueryRowContext(ctx, InsertAssignmentSql,
+		assignment.ClassID,
+		assignment.ModuleType,
+		assignment.ModuleID,
+		assignment.DueAt,
+	).Scan(&assignment.ID, &assignment.CreatedAt)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "CreateAssignment", err)
+		return fmt.Errorf("failed to create assignment: %w", err)
+	}
+	return nil
+}
+
+// DeleteAssignment возвращает false, если у класса нет такого задания.
+func (r *ClassroomRepo) DeleteAssignment(ctx context.Context, classID, assignmentID int) (bool, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	res, err := r.db.ExecContext(ctx, DeleteAssignmentSql, assignmentID, classID)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "DeleteAssignment", err)
+		return false, fmt.Errorf("failed to delete assignment: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete assignment: %w", err)
+	}
+	return affected > 0, nil
+}
+
+func (r *ClassroomRepo) GetAssignments(ctx context.Context, classID int) ([]models.Assignment, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, SelectAssignmentsSql, classID)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetAssignments", err)
+		return nil, fmt.Errorf("failed to query assignments: %w", err)
+	}
+	defer rows.Close()
+
+	assignments := make([]models.Assignment, 0)
+	for rows.Next() {
+		var a models.Assignment
+		if err := rows.Scan(&a.ID, &a.ClassID, &a.ModuleType, &a.ModuleID, &a.ModuleTitle, &a.DueAt, &a.CreatedAt); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetAssignments", err)
+			return nil, fmt.Errorf("failed to scan assignment: %w", err)
+		}
+		assignments = append(assignments, a)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetAssignments", err)
+		return nil, fmt.Errorf("error after iterating assignments: %w", err)
+	}
+
+	return assignments, nil
+}
+
+// GetClassProgress возвращает прогресс учеников класса по заданиям: ученик -> задание -> сводка.
+func (r *ClassroomRepo) GetClassProgress(ctx context.Context, classID int) (map[uuid.UUID]map[int]models.StudentAssignmentProgress, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, SelectClassProgressSql, classID)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetClassProgress", err)
+		return nil, fmt.Errorf("failed to query class progress: %w", err)
+	}
+	defer rows.Close()
+
+	progress := make(map[uuid.UUID]map[int]models.StudentAssignmentProgress)
+	for rows.Next() {
+		var userID uuid.UUID
+		var p models.StudentAssignmentProgress
+		var averageScore sql.NullFloat64
+		var lastAttemptAt, completedAt sql.NullTime
+
+		if err := rows.Scan(
+			&userID,
+			&p.AssignmentID,
+			&p.Total,
+			&p.Completed,
+			&p.Failed,
+			&p.Attempts,
+			&averageScore,
+			&lastAttemptAt,
+			&completedAt,
+		); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetClassProgress", err)
+			return nil, fmt.Errorf("failed to scan class progress: %w", err)
+		}
+		if averageScore.Valid {
+			p.AverageScore = &averageScore.Float64
+		}
+		if lastAttemptAt.Valid {
+			p.LastAttemptAt = timePtr(lastAttemptAt.Time)
+		}
+		if completedAt.Valid {
+			p.CompletedAt = timePtr(completedAt.Time)
+		}
+
+		if progress[userID] == nil {
+			progress[userID] = make(map[int]models.StudentAssignmentProgress)
+		}
+		progress[userID][p.AssignmentID] = p
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetClassProgress", err)
+		return nil, fmt.Errorf("error after iterating class progress: %w", err)
+	}
+
+	return progress, nil
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func (r *ClassroomRepo) BeginTx(ctx context.Context) (models.Transaction, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// GetRecordings возвращает очередь записей учеников учителя: неоценённые или уже оценённые им.
+// classID = 0 означает все классы учителя.
+func (r *ClassroomRepo) GetRecordings(ctx context.Context, teacherID uuid.UUID, classID int, status string, limit int) ([]models.RecordingReview, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	query := SelectPendingRecordingsSql
+	if status == models.RecordingsGraded {
+		query = SelectGradedRecordingsSql
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, teacherID, classID, limit)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetRecordings", err)
+		return nil, fmt.Errorf("failed to query recordings: %w", err)
+	}
+	defer rows.Close()
+
+	recordings := make([]models.RecordingReview, 0)
+	for rows.Next() {
+		var rec models.RecordingReview
+		var gradeScore sql.NullInt64
+		var gradeResult, gradeComment sql.NullString
+		var gradedAt sql.NullTime
+
+		if err := rows.Scan(
+			&rec.AttemptID,
+			&rec.StudentID,
+			&rec.StudentName,
+			&rec.ExerciseID,
+			&rec.ExerciseType,
+			&rec.ExerciseText,
+			&rec.AutoResult,
+			&rec.AutoScore,
+			&rec.Transcription,
+			&rec.RecordingID,
+			&rec.CreatedAt,
+			&gradeScore,
+			&gradeResult,
+			&gradeComment,
+			&gradedAt,
+		); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetRecordings", err)
+			return nil, fmt.Errorf("failed to scan recording: %w", err)
+		}
+		if gradeScore.Valid {
+			score := int(gradeScore.Int64)
+			rec.Grade = &models.RecordingGrade{
+				Score:    &score,
+				Result:   gradeResult.String,
+				Comment:  gradeComment.String,
+				GradedAt: gradedAt.Time,
+			}
+		}
+		recordings = append(recordings, rec)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetRecordings", err)
+		return nil, fmt.Errorf("error after iterating recordings: %w", err)
+	}
+
+	return recordings, nil
+}
+
+// GetRecordingAttemptForUpdate блокирует попытку с записью до конца транзакции. Возвращает nil,
+// если попытки нет или к ней не приложена запись.
+func (r *ClassroomRepo) GetRecordingAttemptForUpdate(ctx context.Context, tx models.Transaction, attemptID int) (*models.ExerciseAttempt, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	attempt := models.ExerciseAttempt{ID: attemptID}
+	err := tx.QueryRowContext(ctx, SelectRecordingAttemptSql, attemptID).Scan(
+		&attempt.UserID,
+		&attempt.ExerciseID,
+		&attempt.ExerciseType,
+		&attempt.Result,
+		&attempt.Score,
+		&attempt.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetRecordingAttemptForUpdate", err)
+		return nil, fmt.Errorf("failed to get recording attempt: %w", err)
+	}
+	return &attempt, nil
+}
+
+// IsTeacherOf проверяет, что studentID к моменту at уже учился хотя бы в одном классе teacherID.
+func (r *ClassroomRepo) IsTeacherOf(ctx context.Context, tx models.Transaction, teacherID, studentID uuid.UUID, at time.Time) (bool, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var teaches bool
+	if err := tx.QueryRowContext(ctx, IsTeacherOfSql, teacherID, studentID, at).Scan(&teaches); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "IsTeacherOf", err)
+		return false, fmt.Errorf("failed to check teacher: %w", err)
+	}
+	return teaches, nil
+}
+
+// SaveRecordingGrade сохраняет оценку учителя, переписывает ею результат попытки и статус упражнения.
+func (r *ClassroomRepo) SaveRecordingGrade(ctx context.Context, tx models.Transaction, teacherID uuid.UUID, attempt *models.ExerciseAttempt, grade *models.RecordingGrade) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	err := tx.QueryRowContext(ctx, UpsertRecordingGradeSql,
+		attempt.ID,
+		teacherID,
+		*grade.Score,
+		grade.Result,
+		grade.Comment,
+		attempt.Result,
+		attempt.Score,
+	).Scan(&grade.GradedAt)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "SaveRecordingGrade", err)
+		return fmt.Errorf("failed to save recording grade: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, UpdateGradedAttemptSql, attempt.ID, grade.Result, *grade.Score); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "SaveRecordingGrade", err)
+		return fmt.Errorf("failed to update graded attempt: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, UpdateGradedProgressSql, attempt.UserID, attempt.ExerciseID, attempt.ExerciseType, grade.Result)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "SaveRecordingGrade", err)
+		return fmt.Errorf("failed to update graded progress: %w", err)
+	}
+
+	return nil
+}
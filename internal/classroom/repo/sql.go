@@ -0,0 +1,213 @@
+package repo
+
+const (
+	InsertClassSql = `
+        INSERT INTO classes (teacher_id, title, join_code) VALUES ($1, $2, $3)
+        ON CONFLICT (join_code) DO NOTHING
+        RETURNING id, created_at
+    `
+
+	UpdateJoinCodeSql = `
+        UPDATE classes SET join_code = $2 WHERE id = $1
+        AND NOT EXISTS (SELECT 1 FROM classes WHERE join_code = $2)
+    `
+
+	SelectClassSql = `
+        SELECT c.id, c.teacher_id, c.title, c.join_code, c.created_at,
+               (SELECT COUNT(*) FROM class_members m WHERE m.class_id = c.id)
+        FROM classes c
+        WHERE c.id = $1
+    `
+
+	SelectClassByCodeSql = `
+        SELECT c.id, c.teacher_id, c.title, c.join_code, c.created_at,
+               (SELECT COUNT(*) FROM class_members m WHERE m.class_id = c.id)
+        FROM classes c
+        WHERE c.join_code = $1
+    `
+
+	SelectTeacherClassesSql = `
+        SELECT c.id, c.teacher_id, c.title, c.join_code, c.created_at,
+               (SELECT COUNT(*) FROM class_members m WHERE m.class_id = c.id)
+        FROM classes c
+        WHERE c.teacher_id = $1
+        ORDER BY c.created_at, c.id
+    `
+
+	SelectEnrolledClassesSql = `
+        SELECT c.id, c.teacher_id, c.title, '', c.created_at,
+               (SELECT COUNT(*) FROM class_members m WHERE m.class_id = c.id)
+        FROM classes c
+        JOIN class_members cm ON cm.class_id = c.id
+        WHERE cm.user_id = $1
+        ORDER BY cm.joined_at, c.id
+    `
+
+	IsClassMemberSql = `
+        SELECT EXISTS (SELECT 1 FROM class_members WHERE class_id = $1 AND user_id = $2)
+    `
+
+	InsertClassMemberSql = `
+        INSERT INTO class_members (class_id, user_id) VALUES ($1, $2)
+        ON CONFLICT (class_id, user_id) DO NOTHING
+    `
+
+	DeleteClassMemberSql = `
+        DELETE FROM class_members WHERE class_id = $1 AND user_id = $2
+    `
+
+	SelectClassStudentsSql = `
+        SELECT u.id, u.name, u.email, m.joined_at
+        FROM class_members m
+        JOIN users u ON u.id = m.user_id
+        WHERE m.class_id = $1 AND NOT u.isDeleted
+        ORDER BY u.name, u.id
+    `
+
+	SelectModuleTitleSql = `
+        SELECT title FROM word_modules WHERE $1 = 'word' AND id = $2 AND owner_id IS NULL
+        UNION ALL
+        SELECT title FROM phrase_modules WHERE $1 = 'phrase' AND id = $2
+    `
+
+	InsertAssignmentSql = `
+        INSERT INTO class_assignments (class_id, module_type, module_id, due_at)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (class_id, module_type, module_id) DO UPDATE SET due_at = EXCLUDED.due_at
+        RETURNING id, created_at
+    `
+
+	DeleteAssignmentSql = `
+        DELETE FROM class_assignments WHERE id = $1 AND class_id = $2
+    `
+
+	SelectAssignmentsSql = `
+        SELECT a.id, a.class_id, a.module_type, a.module_id, COALESCE(wm.title, pm.title, ''), a.due_at, a.created_at
+        FROM class_assignments a
+        LEFT JOIN word_modules wm ON a.module_type = 'word' AND wm.id = a.module_id
+        LEFT JOIN phrase_modules pm ON a.module_type = 'phrase' AND pm.id = a.module_id
+        WHERE a.class_id = $1
+        ORDER BY a.due_at NULLS LAST, a.id
+    `
+
+	// прогресс каждого ученика класса по каждому назначенному модулю; пропущенные
+	// после входного теста упражнения считаются пройденными
+	SelectClassProgressSql = `
+        WITH assignments AS (
+            SELECT id, module_type, module_id FROM class_assignments WHERE class_id = $1
+        ), exercises AS (
+            SELECT a.id AS assignment_id, 'word' AS exercise_type, e.id AS exercise_id
+            FROM assignments a
+            JOIN word_exercises e ON a.module_type = 'word' AND e.module_id = a.module_id
+            UNION ALL
+            SELECT a.id, 'phrase', e.id
+            FROM assignments a
+            JOIN phrase_exercises e ON a.module_type = 'phrase' AND e.module_id = a.module_id
+        )
+        SELECT m.user_id, a.id,
+               COUNT(e.exercise_id),
+               COUNT(p.id) FILTER (WHERE p.status IN ('completed', 'skipped')),
+               COUNT(p.id) FILTER (WHERE p.status = 'failed'),
+               COALESCE(SUM(p.attempts), 0),
+               AVG(p.best_score)::float8,
+               MAX(p.last_attempt_at),
+               MAX(p.updated_at) FILTER (WHERE p.status IN ('completed', 'skipped'))
+        FROM class_members m
+        CROSS JOIN assignments a
+        LEFT JOIN exercises e ON e.assignment_id = a.id
+        LEFT JOIN exercise_progress p
+            ON p.user_id = m.user_id AND p.exercise_id = e.exercise_id AND p.exercise_type = e.exercise_type
+        WHERE m.class_id = $1
+        GROUP BY m.user_id, a.id
+    `
+
+	// последняя неоценённая запись ученика по каждому упражнению; учитывается только то,
+	// что записано после вступления в класс учителя
+	SelectPendingRecordingsSql = `
+        WITH latest AS (
+            SELECT DISTINCT ON (a.user_id, a.exercise_type, a.exercise_id)
+                   a.id, a.user_id, a.exercise_id, a.exercise_type, a.result, a.score,
+                   COALESCE(a.transcription, '') AS transcription, a.recording_id, a.created_at
+            FROM exercise_attempts a
+            WHERE a.recording_id IS NOT NULL
+              AND EXISTS (
+                  SELECT 1
+                  FROM class_members m
+                  JOIN classes c ON c.id = m.class_id
+                  WHERE m.user_id = a.user_id AND c.teacher_id = $1
+                    AND ($2 = 0 OR c.id = $2) AND a.created_at >= m.joined_at
+              )
+            ORDER BY a.user_id, a.exercise_type, a.exercise_id, a.created_at DESC, a.id DESC
+        )
+        SELECT l.id, l.user_id, u.name, l.exercise_id, l.exercise_type,
+               COALESCE(array_to_string(we.words, ', '), pe.sentence, ''),
+               l.result, l.score, l.transcription, l.recording_id, l.created_at,
+               NULL::int, NULL::varchar, NULL::text, NULL::timestamp
+        FROM latest l
+        JOIN users u ON u.id = l.user_id
+        LEFT JOIN word_exercise_cards we ON l.exercise_type = 'word' AND we.id = l.exercise_id
+        LEFT JOIN phrase_exercises pe ON l.exercise_type = 'phrase' AND pe.id = l.exercise_id
+        WHERE NOT EXISTS (SELECT 1 FROM recording_grades g WHERE g.attempt_id = l.id)
+        ORDER BY l.created_at, l.id
+        LIMIT $3
+    `
+
+	SelectGradedRecordingsSql = `
+        SELECT a.id, a.user_id, u.name, a.exercise_id, a.exercise_type,
+               COALESCE(array_to_string(we.words, ', '), pe.sentence, ''),
+               g.auto_result, g.auto_score, COALESCE(a.transcription, ''), a.recording_id, a.created_at,
+               g.score, g.result, g.comment, g.graded_at
+        FROM recording_grades g
+        JOIN exercise_attempts a ON a.id = g.attempt_id
+        JOIN users u ON u.id = a.user_id
+        LEFT JOIN word_exercise_cards we ON a.exercise_type = 'word' AND we.id = a.exercise_id
+        LEFT JOIN phrase_exercises pe ON a.exercise_type = 'phrase' AND pe.id = a.exercise_id
+        WHERE g.teacher_id = $1
+          AND ($2 = 0 OR EXISTS (SELECT 1 FROM class_members m WHERE m.class_id = $2 AND m.user_id = a.user_id))
+        ORDER BY g.graded_at DESC, a.id DESC
+        LIMIT $3
+    `
+
+	SelectRecordingAttemptSql = `
+        SELECT a.user_id, a.exercise_id, a.exercise_type, a.result, a.score, a.created_at
+        FROM exercise_attempts a
+        WHERE a.id = $1 AND a.recording_id IS NOT NULL
+        FOR UPDATE
+    `
+
+	// как и в очереди записей, учитель отвечает только за то, что записано после вступления
+	// ученика в его класс
+	IsTeacherOfSql = `
+        SELECT EXISTS (
+            SELECT 1
+            FROM class_members m
+            JOIN classes c ON c.id = m.class_id
+            WHERE c.teacher_id = $1 AND m.user_id = $2 AND m.joined_at <= $3
+        )
+    `
+
+	// при повторной оценке автоматический результат остаётся прежним
+	UpsertRecordingGradeSql = `
+        INSERT INTO recording_grades (attempt_id, teacher_id, score, result, comment, auto_result, auto_score)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        ON CONFLICT (attempt_id) DO UPDATE SET teacher_id = EXCLUDED.teacher_id,
+                                               score = EXCLUDED.score,
+                                               result = EXCLUDED.result,
+                                               comment = EXCLUDED.comment,
+                                               graded_at = CURRENT_TIMESTAMP
+        RETURNING graded_at
+    `
+
+	UpdateGradedAttemptSql = `
+        UPDATE exercise_attempts SET result = $2, score = $3 WHERE id = $1
+    `
+
+	UpdateGradedProgressSql = `
+        UPDATE exercise_progress
+        SET status = $4,
+            best_score = (SELECT MAX(score) FROM exercise_attempts
+                          WHERE user_id = $1 AND exercise_id = $2 AND exercise_type = $3),
+            updated_at = CURRENT_TIMESTAMP
+        WHERE user_id = $1 AND exercise_id = $2 AND exercise_type = $3
+    `
+)
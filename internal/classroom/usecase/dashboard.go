@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+	"math"
+	"time"
+)
+
+// GetDashboard сводит прогресс каждого ученика класса по каждому заданию. Доступен только учителю класса.
+func (uc *ClassroomUsecase) GetDashboard(ctx context.Context, teacherID uuid.UUID, classID int) (*models.ClassDashboard, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	class, err := uc.ownClass(ctx, teacherID, classID)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := uc.repo.GetAssignments(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+	students, err := uc.repo.GetStudents(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+	progress, err := uc.repo.GetClassProgress(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	dashboard := &models.ClassDashboard{
+		Class:       *class,
+		Assignments: make([]models.AssignmentSummary, len(assignments)),
+		Students:    make([]models.StudentProgress, 0, len(students)),
+	}
+	for i, assignment := range assignments {
+		dashboard.Assignments[i].Assignment = assignment
+	}
+
+	completionSums := make([]float64, len(assignments))
+	scoreSums := make([]float64, len(assignments))
+	scoreCounts := make([]int, len(assignments))
+
+	for _, student := range students {
+		row := models.StudentProgress{
+			Student:     student,
+			Assignments: make([]models.StudentAssignmentProgress, 0, len(assignments)),
+		}
+
+		done, total := 0, 0
+		for i, assignment := range assignments {
+			p, ok := progress[student.UserID][assignment.ID]
+			if !ok {
+				p = models.StudentAssignmentProgress{AssignmentID: assignment.ID}
+			}
+			p.CompletionPercent = completionPercent(p.Completed, p.Total)
+			p.Status = assignmentStatus(&p, assignment.DueAt, now)
+			if p.Status != models.AssignmentCompleted {
+				p.CompletedAt = nil
+			}
+			row.Assignments = append(row.Assignments, p)
+
+			done += p.Completed
+			total += p.Total
+
+			summary := &dashboard.Assignments[i]
+			switch p.Status {
+			case models.AssignmentCompleted:
+				summary.StudentsCompleted++
+			case models.AssignmentOverdue:
+				summary.StudentsOverdue++
+			}
+			completionSums[i] += p.CompletionPercent
+			if p.AverageScore != nil {
+				scoreSums[i] += *p.AverageScore
+				scoreCounts[i]++
+			}
+		}
+		row.CompletionPercent = completionPercent(done, total)
+
+		dashboard.Students = append(dashboard.Students, row)
+	}
+
+	for i := range dashboard.Assignments {
+		summary := &dashboard.Assignments[i]
+		if len(students) > 0 {
+			summary.CompletionPercent = math.Round(completionSums[i]*10/float64(len(students))) / 10
+		}
+		if scoreCounts[i] > 0 {
+			average := math.Round(scoreSums[i]*10/float64(scoreCounts[i])) / 10
+			summary.AverageScore = &average
+		}
+	}
+
+	uc.logger.LogInfo(requestId, logger.UsecaseLayer, "GetDashboard",
+		fmt.Sprintf("class %d: %d students, %d assignments", classID, len(students), len(assignments)))
+
+	return dashboard, nil
+}
+
+// assignmentStatus: задание выполнено, когда пройдены все упражнения модуля; не выполненное
+// к сроку считается просроченным.
+func assignmentStatus(p *models.StudentAssignmentProgress, dueAt *time.Time, now time.Time) string {
+	switch {
+	case p.Total > 0 && p.Completed >= p.Total:
+		return models.AssignmentCompleted
+	case dueAt != nil && now.After(*dueAt):
+		return models.AssignmentOverdue
+	case p.Attempts > 0 || p.Completed > 0:
+		return models.AssignmentInProgress
+	default:
+		return models.AssignmentNotStarted
+	}
+}
+
+func completionPercent(done, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(done)*1000/float64(total)) / 10
+}
@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/classroom"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+	"strings"
+)
+
+const maxGradeComment = 2000
+
+// GetRecordings возвращает записи произношения учеников учителя: в очереди на проверку
+// или уже проверенные им. С classID очередь ограничивается одним его классом.
+func (uc *ClassroomUsecase) GetRecordings(ctx context.Context, teacherID uuid.UUID, classID int, status string, limit int) (*models.RecordingQueue, error) {
+	if status == "" {
+		status = models.RecordingsPending
+	}
+	if status != models.RecordingsPending && status != models.RecordingsGraded {
+		return nil, fmt.Errorf("%w: status must be pending or graded", classroom.ErrInvalidData)
+	}
+	if classID != 0 {
+		if _, err := uc.ownClass(ctx, teacherID, classID); err != nil {
+			return nil, err
+		}
+	}
+
+	recordings, err := uc.repo.GetRecordings(ctx, teacherID, classID, status, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &models.RecordingQueue{Recordings: recordings}, nil
+}
+
+// GradeRecording сохраняет оценку учителя: она заменяет автоматический результат попытки
+// и статус упражнения в exercise_progress, а ученик получает уведомление.
+func (uc *ClassroomUsecase) GradeRecording(ctx context.Context, teacherID uuid.UUID, attemptID int, grade *models.RecordingGrade) (result *models.RecordingGrade, err error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	if grade.Score == nil || *grade.Score < 0 || *grade.Score > 100 {
+		return nil, fmt.Errorf("%w: score must be 0-100", classroom.ErrInvalidData)
+	}
+	switch grade.Result {
+	case "":
+		grade.Result = "failed"
+		if *grade.Score >= uc.cfg.Scoring.PassThreshold {
+			grade.Result = "completed"
+		}
+	case "completed", "failed":
+	default:
+		return nil, fmt.Errorf("%w: result must be completed or failed", classroom.ErrInvalidData)
+	}
+	grade.Comment = strings.TrimSpace(grade.Comment)
+	if len([]rune(grade.Comment)) > maxGradeComment {
+		return nil, fmt.Errorf("%w: comment is longer than %d characters", classroom.ErrInvalidData, maxGradeComment)
+	}
+
+	tx, err := uc.repo.BeginTx(ctx)
+	if err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GradeRecording",
+			fmt.Errorf("failed to begin transaction: %w", err))
+		return nil, errors.New("failed to start transaction")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	attempt, err := uc.repo.GetRecordingAttemptForUpdate(ctx, tx, attemptID)
+	if err != nil {
+		return nil, err
+	}
+	if attempt == nil {
+		err = fmt.Errorf("%w: recording %d", classroom.ErrNotFound, attemptID)
+		return nil, err
+	}
+	teaches, err := uc.repo.IsTeacherOf(ctx, tx, teacherID, attempt.UserID, attempt.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if !teaches {
+		err = fmt.Errorf("%w: recording %d was not made in this teacher's class", classroom.ErrForbidden, attemptID)
+		return nil, err
+	}
+
+	if err = uc.repo.SaveRecordingGrade(ctx, tx, teacherID, attempt, grade); err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(map[string]any{
+		"attempt_id":    attempt.ID,
+		"exercise_id":   attempt.ExerciseID,
+		"exercise_type": attempt.ExerciseType,
+		"score":         *grade.Score,
+		"result":        grade.Result,
+		"comment":       grade.Comment,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode notification: %w", err)
+	}
+	body := fmt.Sprintf("Оценка: %d", *grade.Score)
+	if grade.Comment != "" {
+		body += ". " + grade.Comment
+	}
+	err = uc.notifier.Notify(ctx, tx, &models.Notification{
+		UserID:  attempt.UserID,
+		Type:    models.NotificationRecordingGraded,
+		Title:   "Учитель проверил ваше произношение",
+		Body:    body,
+		Payload: payload,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GradeRecording",
+			fmt.Errorf("failed to commit transaction: %w", err))
+		return nil, errors.New("failed to commit transaction")
+	}
+
+	uc.logger.LogInfo(requestId, logger.UsecaseLayer, "GradeRecording",
+		fmt.Sprintf("recording %d graded %d (%s)", attemptID, *grade.Score, grade.Result))
+
+	return grade, nil
+}
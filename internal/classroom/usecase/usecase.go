@@ -0,0 +1,254 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/classroom"
+	"github.com/TeaStealers-backend-sem4/internal/classroom/repo"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/internal/notification"
+	"github.com/TeaStealers-backend-sem4/pkg/config"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// без похожих друг на друга символов: 0/O, 1/I
+	joinCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	joinCodeLength   = 8
+	joinCodeAttempts = 5
+	maxClassTitle    = 100
+)
+
+type ClassroomUsecase struct {
+	repo     *repo.ClassroomRepo
+	notifier notification.Notifier
+	logger   logger.Logger
+	cfg      *config.Config
+}
+
+func NewClassroomUsecase(repo *repo.ClassroomRepo, notifier notification.Notifier, logger logger.Logger, cfg *config.Config) *ClassroomUsecase {
+	return &ClassroomUsecase{repo: repo, notifier: notifier, logger: logger, cfg: cfg}
+}
+
+func newJoinCode() (string, error) {
+	buf := make([]byte, joinCodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	for i, b := range buf {
+		buf[i] = joinCodeAlphabet[int(b)%len(joinCodeAlphabet)]
+	}
+	return string(buf), nil
+}
+
+// CreateClass создаёт класс, в котором teacherID становится учителем, и выдаёт ему код приглашения.
+func (uc *ClassroomUsecase) CreateClass(ctx context.Context, teacherID uuid.UUID, data *models.ClassCreate) (*models.Class, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	title := strings.TrimSpace(data.Title)
+	if title == "" || utf8.RuneCountInString(title) > maxClassTitle {
+		return nil, fmt.Errorf("%w: title must be 1-%d characters", classroom.ErrInvalidData, maxClassTitle)
+	}
+
+	class := &models.Class{TeacherID: teacherID, Title: title}
+	for i := 0; i < joinCodeAttempts; i++ {
+		code, err := newJoinCode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate join code: %w", err)
+		}
+		class.JoinCode = code
+
+		created, err := uc.repo.CreateClass(ctx, class)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			uc.logger.LogInfo(requestId, logger.UsecaseLayer, "CreateClass", fmt.Sprintf("class %d created", class.ID))
+			return class, nil
+		}
+	}
+
+	return nil, errors.New("failed to generate unique join code")
+}
+
+func (uc *ClassroomUsecase) GetClasses(ctx context.Context, userID uuid.UUID) (*models.ClassList, error) {
+	teaching, err := uc.repo.GetTeacherClasses(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	enrolled, err := uc.repo.GetEnrolledClasses(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ClassList{Teaching: teaching, Enrolled: enrolled}, nil
+}
+
+// ownClass возвращает класс, если его ведёт teacherID.
+func (uc *ClassroomUsecase) ownClass(ctx context.Context, teacherID uuid.UUID, classID int) (*models.Class, error) {
+	class, err := uc.repo.GetClass(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+	if class == nil {
+		return nil, fmt.Errorf("%w: class %d", classroom.ErrNotFound, classID)
+	}
+	if class.TeacherID != teacherID {
+		return nil, fmt.Errorf("%w: class %d belongs to another teacher", classroom.ErrForbidden, classID)
+	}
+	return class, nil
+}
+
+// RegenerateJoinCode заменяет код приглашения, например если старый попал к посторонним.
+// Уже вступившие ученики остаются в классе.
+func (uc *ClassroomUsecase) RegenerateJoinCode(ctx context.Context, teacherID uuid.UUID, classID int) (*models.Class, error) {
+	class, err := uc.ownClass(ctx, teacherID, classID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < joinCodeAttempts; i++ {
+		code, err := newJoinCode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate join code: %w", err)
+		}
+
+		updated, err := uc.repo.UpdateJoinCode(ctx, classID, code)
+		if err != nil {
+			return nil, err
+		}
+		if updated {
+			class.JoinCode = code
+			return class, nil
+		}
+	}
+
+	return nil, errors.New("failed to generate unique join code")
+}
+
+func (uc *ClassroomUsecase) JoinClass(ctx context.Context, userID uuid.UUID, code string) (*models.Class, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return nil, fmt.Errorf("%w: code is required", classroom.ErrInvalidData)
+	}
+
+	class, err := uc.repo.GetClassByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if class == nil {
+		return nil, fmt.Errorf("%w: no class with this code", classroom.ErrNotFound)
+	}
+	if class.TeacherID == userID {
+		return nil, fmt.Errorf("%w: teacher cannot join own class", classroom.ErrInvalidData)
+	}
+
+	if err := uc.repo.AddMember(ctx, class.ID, userID); err != nil {
+		return nil, err
+	}
+
+	uc.logger.LogInfo(requestId, logger.UsecaseLayer, "JoinClass", fmt.Sprintf("user joined class %d", class.ID))
+
+	class.JoinCode = ""
+	return class, nil
+}
+
+// RemoveStudent исключает ученика из класса. Учитель может исключить любого ученика,
+// ученик - только выйти сам.
+func (uc *ClassroomUsecase) RemoveStudent(ctx context.Context, userID uuid.UUID, classID int, studentID uuid.UUID) error {
+	if userID != studentID {
+		if _, err := uc.ownClass(ctx, userID, classID); err != nil {
+			return err
+		}
+	}
+
+	removed, err := uc.repo.RemoveMember(ctx, classID, studentID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return fmt.Errorf("%w: student is not in class %d", classroom.ErrNotFound, classID)
+	}
+	return nil
+}
+
+// GetAssignments доступен учителю класса и его ученикам.
+func (uc *ClassroomUsecase) GetAssignments(ctx context.Context, userID uuid.UUID, classID int) (*models.AssignmentList, error) {
+	class, err := uc.repo.GetClass(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+	if class == nil {
+		return nil, fmt.Errorf("%w: class %d", classroom.ErrNotFound, classID)
+	}
+	if class.TeacherID != userID {
+		member, err := uc.repo.IsMember(ctx, classID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !member {
+			return nil, fmt.Errorf("%w: not a member of class %d", classroom.ErrForbidden, classID)
+		}
+	}
+
+	assignments, err := uc.repo.GetAssignments(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.AssignmentList{Assignments: assignments}, nil
+}
+
+func (uc *ClassroomUsecase) CreateAssignment(ctx context.Context, teacherID uuid.UUID, classID int, data *models.AssignmentCreate) (*models.Assignment, error) {
+	if data.ModuleType != "word" && data.ModuleType != "phrase" {
+		return nil, fmt.Errorf("%w: module_type must be word or phrase", classroom.ErrInvalidData)
+	}
+	if data.ModuleID <= 0 {
+		return nil, fmt.Errorf("%w: module_id is required", classroom.ErrInvalidData)
+	}
+
+	if _, err := uc.ownClass(ctx, teacherID, classID); err != nil {
+		return nil, err
+	}
+
+	title, err := uc.repo.GetModuleTitle(ctx, data.ModuleType, data.ModuleID)
+	if err != nil {
+		return nil, err
+	}
+	if title == nil {
+		return nil, fmt.Errorf("%w: %s module %d", classroom.ErrNotFound, data.ModuleType, data.ModuleID)
+	}
+
+	assignment := &models.Assignment{
+		ClassID:     classID,
+		ModuleType:  data.ModuleType,
+		ModuleID:    data.ModuleID,
+		ModuleTitle: *title,
+		DueAt:       data.DueAt,
+	}
+	if err := uc.repo.CreateAssignment(ctx, assignment); err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+func (uc *ClassroomUsecase) DeleteAssignment(ctx context.Context, teacherID uuid.UUID, classID, assignmentID int) error {
+	if _, err := uc.ownClass(ctx, teacherID, classID); err != nil {
+		return err
+	}
+
+	deleted, err := uc.repo.DeleteAssignment(ctx, classID, assignmentID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return fmt.Errorf("%w: assignment %d", classroom.ErrNotFound, assignmentID)
+	}
+	return nil
+}
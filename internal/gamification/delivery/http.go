@@ -0,0 +1,44 @@
+package delivery
+
+import (
+	"github.com/TeaStealers-backend-sem4/internal/gamification"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	"github.com/TeaStealers-backend-sem4/pkg/middleware"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+	"net/http"
+)
+
+type GamificationHandler struct {
+	uc     gamification.GamificationUsecase
+	logger logger.Logger
+}
+
+func NewGamificationHandler(uc gamification.GamificationUsecase, logr logger.Logger) *GamificationHandler {
+	return &GamificationHandler{uc: uc, logger: logr}
+}
+
+func (h *GamificationHandler) GetAchievementsHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	achievements, err := h.uc.GetAchievements(r.Context(), UUID)
+	if err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetAchievementsHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "error get achievements")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusOK, achievements); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetAchievementsHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "GetAchievementsHandler")
+}
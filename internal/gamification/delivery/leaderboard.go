@@ -0,0 +1,161 @@
+package delivery
+
+import (
+	"errors"
+	"github.com/TeaStealers-backend-sem4/internal/gamification"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	"github.com/TeaStealers-backend-sem4/pkg/middleware"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/gorilla/mux"
+	"github.com/satori/uuid"
+	"net/http"
+)
+
+func (h *GamificationHandler) GetLeaderboardHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	leaderboard, err := h.uc.GetLeaderboard(r.Context(), UUID, mux.Vars(r)["scope"])
+	if err != nil {
+		if errors.Is(err, gamification.ErrInvalidData) {
+			h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetLeaderboardHandler", err, http.StatusBadRequest)
+			utils.WriteError(w, http.StatusBadRequest, "scope must be global, friends or weekly")
+			return
+		}
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetLeaderboardHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "error get leaderboard")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusOK, leaderboard); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetLeaderboardHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "GetLeaderboardHandler")
+}
+
+func (h *GamificationHandler) UpdateLeaderboardSettingsHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	var settings models.LeaderboardSettings
+	if err := utils.ReadRequestData(r, &settings); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "UpdateLeaderboardSettingsHandler", err, http.StatusBadRequest)
+		utils.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.uc.UpdateLeaderboardSettings(r.Context(), UUID, &settings); err != nil {
+		if errors.Is(err, gamification.ErrInvalidData) {
+			h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "UpdateLeaderboardSettingsHandler", err, http.StatusBadRequest)
+			utils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "UpdateLeaderboardSettingsHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "error update leaderboard settings")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusOK, settings); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "UpdateLeaderboardSettingsHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "UpdateLeaderboardSettingsHandler")
+}
+
+func (h *GamificationHandler) AddFriendHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	var req models.FriendRequest
+	if err := utils.ReadRequestData(r, &req); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "AddFriendHandler", err, http.StatusBadRequest)
+		utils.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.uc.AddFriend(r.Context(), UUID, req.Email); err != nil {
+		h.writeFriendError(w, requestId, "AddFriendHandler", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "AddFriendHandler")
+}
+
+func (h *GamificationHandler) RemoveFriendHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	if err := h.uc.RemoveFriend(r.Context(), UUID, r.URL.Query().Get("email")); err != nil {
+		h.writeFriendError(w, requestId, "RemoveFriendHandler", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "RemoveFriendHandler")
+}
+
+func (h *GamificationHandler) GetFriendRequestsHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	requests, err := h.uc.GetFriendRequests(r.Context(), UUID)
+	if err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetFriendRequestsHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "error get friend requests")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusOK, requests); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetFriendRequestsHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "GetFriendRequestsHandler")
+}
+
+func (h *GamificationHandler) writeFriendError(w http.ResponseWriter, requestId, method string, err error) {
+	switch {
+	case errors.Is(err, gamification.ErrNotFound):
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, method, err, http.StatusNotFound)
+		utils.WriteError(w, http.StatusNotFound, "friend not found")
+	case errors.Is(err, gamification.ErrInvalidData):
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, method, err, http.StatusBadRequest)
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, method, err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "error update friends")
+	}
+}
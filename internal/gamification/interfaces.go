@@ -0,0 +1,25 @@
+package gamification
+
+import (
+	"context"
+	"errors"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/satori/uuid"
+)
+
+var (
+	ErrNotFound    = errors.New("not found")
+	ErrInvalidData = errors.New("invalid data")
+)
+
+type GamificationUsecase interface {
+	OnAttempt(ctx context.Context, tx models.Transaction, attempt *models.ExerciseAttempt, status string) error
+
+	GetAchievements(ctx context.Context, userID uuid.UUID) (*models.AchievementList, error)
+
+	GetLeaderboard(ctx context.Context, userID uuid.UUID, scope string) (*models.Leaderboard, error)
+	UpdateLeaderboardSettings(ctx context.Context, userID uuid.UUID, settings *models.LeaderboardSettings) error
+	AddFriend(ctx context.Context, userID uuid.UUID, email string) error
+	RemoveFriend(ctx context.Context, userID uuid.UUID, email string) error
+	GetFriendRequests(ctx context.Context, userID uuid.UUID) (*models.FriendRequestList, error)
+}
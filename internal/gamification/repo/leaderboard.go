@@ -0,0 +1,212 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+)
+
+func (r *GamificationRepo) AddWeeklyXP(ctx context.Context, tx models.Transaction, userID uuid.UUID, weekStart string, xp int) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	if _, err := tx.ExecContext(ctx, AddWeeklyXPSql, userID, weekStart, xp); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "AddWeeklyXP", err)
+		return fmt.Errorf("failed to add weekly xp: %w", err)
+	}
+	return nil
+}
+
+// JoinLeague записывает пользователя в младшую лигу, если он ещё ни в одной не состоит.
+func (r *GamificationRepo) JoinLeague(ctx context.Context, tx models.Transaction, userID uuid.UUID) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	if _, err := tx.ExecContext(ctx, InsertUserLeagueSql, userID); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "JoinLeague", err)
+		return fmt.Errorf("failed to join league: %w", err)
+	}
+	return nil
+}
+
+func (r *GamificationRepo) GetUserTier(ctx context.Context, userID uuid.UUID) (int, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var tier int
+	if err := r.db.QueryRowContext(ctx, SelectUserTierSql, userID).Scan(&tier); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetUserTier", err)
+		return 0, fmt.Errorf("failed to get user tier: %w", err)
+	}
+	return tier, nil
+}
+
+// GetLeaderboard возвращает первые limit участников таблицы без мест: места расставляет usecase.
+// weekStart и tier используются только для недельной лиги.
+func (r *GamificationRepo) GetLeaderboard(ctx context.Context, scope string, userID uuid.UUID, limit int, weekStart string, tier int) ([]models.LeaderboardEntry, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var rows *sql.Rows
+	var err error
+	switch scope {
+	case models.ScopeGlobal:
+		rows, err = r.db.QueryContext(ctx, SelectGlobalLeaderboardSql, userID, limit)
+	case models.ScopeFriends:
+		rows, err = r.db.QueryContext(ctx, SelectFriendsLeaderboardSql, userID, limit)
+	case models.ScopeWeekly:
+		rows, err = r.db.QueryContext(ctx, SelectWeeklyLeaderboardSql, userID, weekStart, tier, limit)
+	default:
+		return nil, fmt.Errorf("unknown leaderboard scope %q", scope)
+	}
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetLeaderboard", err)
+		return nil, fmt.Errorf("failed to query leaderboard: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]models.LeaderboardEntry, 0)
+	for rows.Next() {
+		var entry models.LeaderboardEntry
+		if err := rows.Scan(&entry.UserID, &entry.Name, &entry.XP, &entry.Level); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetLeaderboard", err)
+			return nil, fmt.Errorf("failed to scan leaderboard entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetLeaderboard", err)
+		return nil, fmt.Errorf("error after iterating leaderboard: %w", err)
+	}
+
+	return entries, nil
+}
+
+// GetLeaderboardRank считает место пользователя в таблице: число видимых участников
+// с большим опытом плюс один.
+func (r *GamificationRepo) GetLeaderboardRank(ctx context.Context, scope string, userID uuid.UUID, weekStart string, tier int) (*models.LeaderboardEntry, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var row *sql.Row
+	switch scope {
+	case models.ScopeGlobal:
+		row = r.db.QueryRowContext(ctx, SelectGlobalRankSql, userID)
+	case models.ScopeFriends:
+		row = r.db.QueryRowContext(ctx, SelectFriendsRankSql, userID)
+	case models.ScopeWeekly:
+		row = r.db.QueryRowContext(ctx, SelectWeeklyRankSql, userID, weekStart, tier)
+	default:
+		return nil, fmt.Errorf("unknown leaderboard scope %q", scope)
+	}
+
+	entry := models.LeaderboardEntry{UserID: userID, IsMe: true}
+	if err := row.Scan(&entry.Name, &entry.XP, &entry.Level, &entry.Rank); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetLeaderboardRank", err)
+		return nil, fmt.Errorf("failed to get leaderboard rank: %w", err)
+	}
+	return &entry, nil
+}
+
+func (r *GamificationRepo) UpdateLeaderboardOptOut(ctx context.Context, userID uuid.UUID, optOut bool) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	if _, err := r.db.ExecContext(ctx, UpdateLeaderboardOptOutSql, userID, optOut); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "UpdateLeaderboardOptOut", err)
+		return fmt.Errorf("failed to update leaderboard opt-out: %w", err)
+	}
+	return nil
+}
+
+// GetUserIDByEmail возвращает nil, если активного пользователя с такой почтой нет.
+func (r *GamificationRepo) GetUserIDByEmail(ctx context.Context, email string) (*uuid.UUID, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var id uuid.UUID
+	if err := r.db.QueryRowContext(ctx, SelectUserByEmailSql, email).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetUserIDByEmail", err)
+		return nil, fmt.Errorf("failed to get user by email: %w", err)
+	}
+	return &id, nil
+}
+
+func (r *GamificationRepo) AddFriend(ctx context.Context, userID, friendID uuid.UUID) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	if _, err := r.db.ExecContext(ctx, InsertFriendSql, userID, friendID); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "AddFriend", err)
+		return fmt.Errorf("failed to add friend: %w", err)
+	}
+	return nil
+}
+
+// RemoveFriend возвращает false, если такого друга не было.
+func (r *GamificationRepo) RemoveFriend(ctx context.Context, userID uuid.UUID, email string) (bool, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	res, err := r.db.ExecContext(ctx, DeleteFriendByEmailSql, userID, email)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "RemoveFriend", err)
+		return false, fmt.Errorf("failed to remove friend: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to remove friend: %w", err)
+	}
+	return affected > 0, nil
+}
+
+func (r *GamificationRepo) GetFriendRequests(ctx context.Context, userID uuid.UUID) ([]models.IncomingFriendRequest, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, SelectFriendRequestsSql, userID)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetFriendRequests", err)
+		return nil, fmt.Errorf("failed to query friend requests: %w", err)
+	}
+	defer rows.Close()
+
+	requests := make([]models.IncomingFriendRequest, 0)
+	for rows.Next() {
+		var request models.IncomingFriendRequest
+		if err := rows.Scan(&request.Email, &request.Name, &request.CreatedAt); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetFriendRequests", err)
+			return nil, fmt.Errorf("failed to scan friend request: %w", err)
+		}
+		requests = append(requests, request)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetFriendRequests", err)
+		return nil, fmt.Errorf("error after iterating friend requests: %w", err)
+	}
+
+	return requests, nil
+}
+
+// RollOverLeagues подводит итоги недели weekStart в лигах. Каждая неделя обрабатывается
+// один раз: возвращает false, если итоги уже подведены.
+func (r *GamificationRepo) RollOverLeagues(ctx context.Context, tx models.Transaction, weekStart string, promote, demote, maxTier int) (bool, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var week string
+	if err := tx.QueryRowContext(ctx, InsertLeagueRolloverSql, weekStart).Scan(&week); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		r.logger.LogError(requestId, logger.RepositoryLayer, "RollOverLeagues", err)
+		return false, fmt.Errorf("failed to mark league rollover: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, RollOverLeaguesSql, weekStart, promote, demote, maxTier); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "RollOverLeagues", err)
+		return false, fmt.Errorf("failed to roll over leagues: %w", err)
+	}
+
+	r.logger.LogInfo(requestId, logger.RepositoryLayer, "RollOverLeagues", fmt.Sprintf("leagues rolled over for week %s", weekStart))
+	return true, nil
+}
@@ -0,0 +1,175 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+	"time"
+)
+
+type GamificationRepo struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewRepository(db *sql.DB, logger logger.Logger) *GamificationRepo {
+	return &GamificationRepo{db: db, logger: logger}
+}
+
+func (r *GamificationRepo) BeginTx(ctx context.Context) (models.Transaction, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// CountAttempts возвращает число попыток пользователя по упражнению.
+func (r *GamificationRepo) CountAttempts(ctx context.Context, tx models.Transaction, userID uuid.UUID, exerciseID int, exerciseType string) (int, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var total int
+	if err := tx.QueryRowContext(ctx, CountAttemptsSql, userID, exerciseID, exerciseType).Scan(&total); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "CountAttempts", err)
+		return 0, fmt.Errorf("failed to count attempts: %w", err)
+	}
+	return total, nil
+}
+
+// IsPersonalExercise сообщает, входит ли упражнение в личный набор слов.
+func (r *GamificationRepo) IsPersonalExercise(ctx context.Context, tx models.Transaction, exerciseID int, exerciseType string) (bool, error) {
+	if exerciseType != "word" {
+		return false, nil
+	}
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var personal bool
+	if err := tx.QueryRowContext(ctx, SelectPersonalExerciseSql, exerciseID).Scan(&personal); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "IsPersonalExercise", err)
+		return false, fmt.Errorf("failed to check exercise owner: %w", err)
+	}
+	return personal, nil
+}
+
+// GetModuleCompletion возвращает модуль упражнения и пройден ли он целиком.
+// Для упражнения без модуля возвращается 0.
+func (r *GamificationRepo) GetModuleCompletion(ctx context.Context, tx models.Transaction, userID uuid.UUID, exerciseID int, exerciseType string) (int, bool, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	query := SelectWordModuleCompletedSql
+	if exerciseType == "phrase" {
+		query = SelectPhraseModuleCompletedSql
+	}
+
+	var moduleID int
+	var completed bool
+	if err := tx.QueryRowContext(ctx, query, userID, exerciseID).Scan(&moduleID, &completed); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, false, nil
+		}
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetModuleCompletion", err)
+		return 0, false, fmt.Errorf("failed to check module completion: %w", err)
+	}
+	return moduleID, completed, nil
+}
+
+// AddXPEvent записывает начисление опыта. Возвращает false, если такое начисление уже было.
+func (r *GamificationRepo) AddXPEvent(ctx context.Context, tx models.Transaction, userID uuid.UUID, source, ref string, xp int) (bool, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var id int
+	if err := tx.QueryRowContext(ctx, InsertXPEventSql, userID, source, ref, xp).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		r.logger.LogError(requestId, logger.RepositoryLayer, "AddXPEvent", err)
+		return false, fmt.Errorf("failed to add xp event: %w", err)
+	}
+	return true, nil
+}
+
+// AddUserXP прибавляет опыт пользователю и возвращает новый итог.
+func (r *GamificationRepo) AddUserXP(ctx context.Context, tx models.Transaction, userID uuid.UUID, xp int) (int, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var total int
+	if err := tx.QueryRowContext(ctx, AddUserXPSql, userID, xp).Scan(&total); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "AddUserXP", err)
+		return 0, fmt.Errorf("failed to add user xp: %w", err)
+	}
+	return total, nil
+}
+
+func (r *GamificationRepo) UpdateUserLevel(ctx context.Context, tx models.Transaction, userID uuid.UUID, level int) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	if _, err := tx.ExecContext(ctx, UpdateUserLevelSql, userID, level); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "UpdateUserLevel", err)
+		return fmt.Errorf("failed to update user level: %w", err)
+	}
+	return nil
+}
+
+func (r *GamificationRepo) GetAchievementStats(ctx context.Context, tx models.Transaction, userID uuid.UUID) (*models.AchievementStats, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var stats models.AchievementStats
+	err := tx.QueryRowContext(ctx, SelectAchievementStatsSql, userID).Scan(
+		&stats.ExercisesCompleted,
+		&stats.PerfectScores,
+		&stats.WordModulesCompleted,
+		&stats.PhraseModulesCompleted,
+		&stats.LongestStreak,
+		&stats.XP,
+	)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetAchievementStats", err)
+		return nil, fmt.Errorf("failed to get achievement stats: %w", err)
+	}
+	return &stats, nil
+}
+
+func (r *GamificationRepo) UnlockAchievement(ctx context.Context, tx models.Transaction, userID uuid.UUID, code string) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	if _, err := tx.ExecContext(ctx, InsertAchievementSql, userID, code); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "UnlockAchievement", err)
+		return fmt.Errorf("failed to unlock achievement: %w", err)
+	}
+	return nil
+}
+
+// GetUserAchievements возвращает время открытия каждого полученного достижения.
+func (r *GamificationRepo) GetUserAchievements(ctx context.Context, tx models.Transaction, userID uuid.UUID) (map[string]time.Time, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	rows, err := tx.QueryContext(ctx, SelectUserAchievementsSql, userID)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetUserAchievements", err)
+		return nil, fmt.Errorf("failed to query achievements: %w", err)
+	}
+	defer rows.Close()
+
+	unlocked := make(map[string]time.Time)
+	for rows.Next() {
+		var code string
+		var at time.Time
+		if err := rows.Scan(&code, &at); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetUserAchievements", err)
+			return nil, fmt.Errorf("failed to scan achievement: %w", err)
+		}
+		unlocked[code] = at
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetUserAchievements", err)
+		return nil, fmt.Errorf("error after iterating achievements: %w", err)
+	}
+
+	return unlocked, nil
+}
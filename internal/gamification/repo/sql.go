@@ -0,0 +1,242 @@
+package repo
+
+const (
+	CountAttemptsSql = `
+        SELECT COUNT(*)
+        FROM exercise_attempts
+        WHERE user_id = $1 AND exercise_id = $2 AND exercise_type = $3
+    `
+
+	// упражнение из личного набора слов: ученик сам пополняет наборы, поэтому опыта за них нет
+	SelectPersonalExerciseSql = `
+        SELECT EXISTS (
+            SELECT 1
+            FROM word_exercises e
+            JOIN word_modules m ON m.id = e.module_id
+            WHERE e.id = $1 AND m.owner_id IS NOT NULL
+        )
+    `
+
+	// модуль пройден, если в нём не осталось упражнений без статуса completed или skipped;
+	// личные наборы слов за модули не считаются
+	SelectWordModuleCompletedSql = `
+        SELECT e.module_id,
+               NOT EXISTS (
+                   SELECT 1
+                   FROM word_exercises m
+                   LEFT JOIN exercise_progress p
+                       ON p.exercise_id = m.id AND p.exercise_type = 'word' AND p.user_id = $1
+                   WHERE m.module_id = e.module_id AND COALESCE(p.status, 'none') NOT IN ('completed', 'skipped')
+               )
+        FROM word_exercises e
+        JOIN word_modules wm ON wm.id = e.module_id
+        WHERE e.id = $2 AND wm.owner_id IS NULL
+    `
+
+	SelectPhraseModuleCompletedSql = `
+        SELECT e.module_id,
+               NOT EXISTS (
+                   SELECT 1
+                   FROM phrase_exercises m
+                   LEFT JOIN exercise_progress p
+                       ON p.exercise_id = m.id AND p.exercise_type = 'phrase' AND p.user_id = $1
+                   WHERE m.module_id = e.module_id AND COALESCE(p.status, 'none') NOT IN ('completed', 'skipped')
+               )
+        FROM phrase_exercises e
+        WHERE e.id = $2 AND e.module_id IS NOT NULL
+    `
+
+	InsertXPEventSql = `
+        INSERT INTO xp_events (user_id, source, ref, xp)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (user_id, source, ref) DO NOTHING
+        RETURNING id
+    `
+
+	AddUserXPSql = `
+        INSERT INTO user_xp (user_id, xp)
+        VALUES ($1, $2)
+        ON CONFLICT (user_id)
+        DO UPDATE SET xp = user_xp.xp + EXCLUDED.xp, updated_at = CURRENT_TIMESTAMP
+        RETURNING xp
+    `
+
+	UpdateUserLevelSql = `
+        UPDATE user_xp SET level = $2 WHERE user_id = $1
+    `
+
+	// упражнения личных наборов слов в достижения не засчитываются
+	SelectAchievementStatsSql = `
+        WITH personal AS (
+            SELECT e.id
+            FROM word_exercises e
+            JOIN word_modules m ON m.id = e.module_id
+            WHERE m.owner_id = $1
+        )
+        SELECT
+            (SELECT COUNT(*) FROM exercise_progress
+             WHERE user_id = $1 AND status = 'completed'
+               AND NOT (exercise_type = 'word' AND exercise_id IN (SELECT id FROM personal))),
+            (SELECT COUNT(*) FROM exercise_attempts
+             WHERE user_id = $1 AND score = 100
+               AND NOT (exercise_type = 'word' AND exercise_id IN (SELECT id FROM personal))),
+            (SELECT COUNT(*) FROM xp_events WHERE user_id = $1 AND source = 'word_module_completed'),
+            (SELECT COUNT(*) FROM xp_events WHERE user_id = $1 AND source = 'phrase_module_completed'),
+            (SELECT COALESCE(MAX(longest_streak), 0) FROM user_streaks WHERE user_id = $1),
+            (SELECT COALESCE(MAX(xp), 0) FROM user_xp WHERE user_id = $1)
+    `
+
+	InsertAchievementSql = `
+        INSERT INTO user_achievements (user_id, code)
+        VALUES ($1, $2)
+        ON CONFLICT (user_id, code) DO NOTHING
+    `
+
+	SelectUserAchievementsSql = `
+        SELECT code, unlocked_at FROM user_achievements WHERE user_id = $1
+    `
+
+	AddWeeklyXPSql = `
+        INSERT INTO weekly_xp (user_id, week_start, xp)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (user_id, week_start) DO UPDATE SET xp = weekly_xp.xp + EXCLUDED.xp
+    `
+
+	InsertUserLeagueSql = `
+        INSERT INTO user_leagues (user_id) VALUES ($1)
+        ON CONFLICT (user_id) DO NOTHING
+    `
+
+	SelectUserTierSql = `
+        SELECT COALESCE((SELECT tier FROM user_leagues WHERE user_id = $1), 0)
+    `
+
+	SelectGlobalLeaderboardSql = `
+        SELECT x.user_id, u.name, x.xp, x.level
+        FROM user_xp x
+        JOIN users u ON u.id = x.user_id
+        WHERE NOT u.isDeleted AND (NOT u.leaderboard_opt_out OR u.id = $1)
+        ORDER BY x.xp DESC, u.name, x.user_id
+        LIMIT $2
+    `
+
+	SelectGlobalRankSql = `
+        SELECT u.name, COALESCE(x.xp, 0), COALESCE(x.level, 1),
+               (SELECT COUNT(*)
+                FROM user_xp o
+                JOIN users ou ON ou.id = o.user_id
+                WHERE o.xp > COALESCE(x.xp, 0) AND NOT ou.isDeleted AND NOT ou.leaderboard_opt_out) + 1
+        FROM users u
+        LEFT JOIN user_xp x ON x.user_id = u.id
+        WHERE u.id = $1
+    `
+
+	SelectFriendsLeaderboardSql = `
+        SELECT u.id, u.name, COALESCE(x.xp, 0) AS xp, COALESCE(x.level, 1)
+        FROM users u
+        LEFT JOIN user_xp x ON x.user_id = u.id
+        WHERE NOT u.isDeleted
+          AND (u.id = $1 OR (u.id IN (SELECT f.friend_id
+                                      FROM friendships f
+                                      JOIN friendships b ON b.user_id = f.friend_id AND b.friend_id = f.user_id
+                                      WHERE f.user_id = $1) AND NOT u.leaderboard_opt_out))
+        ORDER BY xp DESC, u.name, u.id
+        LIMIT $2
+    `
+
+	SelectFriendsRankSql = `
+        SELECT u.name, COALESCE(x.xp, 0), COALESCE(x.level, 1),
+               (SELECT COUNT(*)
+                FROM friendships f
+                JOIN friendships b ON b.user_id = f.friend_id AND b.friend_id = f.user_id
+                JOIN users fu ON fu.id = f.friend_id
+                JOIN user_xp o ON o.user_id = f.friend_id
+                WHERE f.user_id = $1 AND o.xp > COALESCE(x.xp, 0) AND NOT fu.isDeleted AND NOT fu.leaderboard_opt_out) + 1
+        FROM users u
+        LEFT JOIN user_xp x ON x.user_id = u.id
+        WHERE u.id = $1
+    `
+
+	SelectWeeklyLeaderboardSql = `
+        SELECT w.user_id, u.name, w.xp, COALESCE(x.level, 1)
+        FROM weekly_xp w
+        JOIN user_leagues l ON l.user_id = w.user_id
+        JOIN users u ON u.id = w.user_id
+        LEFT JOIN user_xp x ON x.user_id = w.user_id
+        WHERE w.week_start = $2 AND l.tier = $3
+          AND NOT u.isDeleted AND (NOT u.leaderboard_opt_out OR u.id = $1)
+        ORDER BY w.xp DESC, u.name, w.user_id
+        LIMIT $4
+    `
+
+	SelectWeeklyRankSql = `
+        SELECT u.name, COALESCE(w.xp, 0), COALESCE(x.level, 1),
+               (SELECT COUNT(*)
+                FROM weekly_xp o
+                JOIN user_leagues ol ON ol.user_id = o.user_id
+                JOIN users ou ON ou.id = o.user_id
+                WHERE o.week_start = $2 AND ol.tier = $3 AND o.xp > COALESCE(w.xp, 0)
+                  AND NOT ou.isDeleted AND NOT ou.leaderboard_opt_out) + 1
+        FROM users u
+        LEFT JOIN weekly_xp w ON w.user_id = u.id AND w.week_start = $2
+        LEFT JOIN user_xp x ON x.user_id = u.id
+        WHERE u.id = $1
+    `
+
+	UpdateLeaderboardOptOutSql = `
+        UPDATE users SET leaderboard_opt_out = $2 WHERE id = $1
+    `
+
+	InsertFriendSql = `
+        INSERT INTO friendships (user_id, friend_id) VALUES ($1, $2)
+        ON CONFLICT (user_id, friend_id) DO NOTHING
+    `
+
+	SelectUserByEmailSql = `
+        SELECT id FROM users WHERE email = $1 AND NOT isDeleted
+    `
+
+	// удаляет дружбу целиком, а также свою или встречную заявку
+	DeleteFriendByEmailSql = `
+        DELETE FROM friendships
+        WHERE (user_id = $1 AND friend_id = (SELECT id FROM users WHERE email = $2))
+           OR (friend_id = $1 AND user_id = (SELECT id FROM users WHERE email = $2))
+    `
+
+	// входящие заявки, на которые пользователь ещё не ответил встречной
+	SelectFriendRequestsSql = `
+        SELECT u.email, u.name, f.created_at
+        FROM friendships f
+        JOIN users u ON u.id = f.user_id
+        WHERE f.friend_id = $1 AND NOT u.isDeleted
+          AND NOT EXISTS (SELECT 1 FROM friendships b WHERE b.user_id = $1 AND b.friend_id = f.user_id)
+        ORDER BY f.created_at DESC, u.email
+    `
+
+	InsertLeagueRolloverSql = `
+        INSERT INTO league_rollovers (week_start) VALUES ($1)
+        ON CONFLICT (week_start) DO NOTHING
+        RETURNING week_start
+    `
+
+	// лучшие promote участников лиги поднимаются на уровень выше, худшие demote и не
+	// набравшие опыта за неделю опускаются; при пересечении повышение важнее
+	RollOverLeaguesSql = `
+        WITH ranked AS (
+            SELECT l.user_id, l.tier, COALESCE(w.xp, 0) AS xp,
+                   ROW_NUMBER() OVER (PARTITION BY l.tier ORDER BY COALESCE(w.xp, 0) DESC, l.user_id) AS pos,
+                   COUNT(*) OVER (PARTITION BY l.tier) AS size
+            FROM user_leagues l
+            LEFT JOIN weekly_xp w ON w.user_id = l.user_id AND w.week_start = $1
+        )
+        UPDATE user_leagues l
+        SET tier = CASE
+                WHEN r.xp > 0 AND r.pos <= $2 AND r.tier < $4 THEN r.tier + 1
+                WHEN r.tier > 0 AND (r.xp = 0 OR r.pos > r.size - $3) THEN r.tier - 1
+                ELSE r.tier
+            END,
+            updated_at = CURRENT_TIMESTAMP
+        FROM ranked r
+        WHERE r.user_id = l.user_id
+    `
+)
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"math"
+)
+
+// Опыт за события упражнений.
+const (
+	xpWordCompleted   = 10
+	xpPhraseCompleted = 15
+	xpFirstTry        = 5
+	xpPerfectScore    = 5
+	xpModuleCompleted = 50
+
+	// опыт, нужный для уровня L, равен levelXPStep * (L-1)^2
+	levelXPStep = 100
+)
+
+type achievementRule struct {
+	Code        string
+	Title       string
+	Description string
+	Threshold   int
+	Metric      func(stats *models.AchievementStats) int
+}
+
+func exercisesCompleted(s *models.AchievementStats) int     { return s.ExercisesCompleted }
+func perfectScores(s *models.AchievementStats) int          { return s.PerfectScores }
+func wordModulesCompleted(s *models.AchievementStats) int   { return s.WordModulesCompleted }
+func phraseModulesCompleted(s *models.AchievementStats) int { return s.PhraseModulesCompleted }
+func longestStreak(s *models.AchievementStats) int          { return s.LongestStreak }
+func totalXP(s *models.AchievementStats) int                { return s.XP }
+
+// achievements - каталог достижений. Код сохраняется в базе, поэтому менять его нельзя,
+// а новые достижения откроются у пользователей при следующей попытке.
+var achievements = []achievementRule{
+	{"first_exercise", "Первый шаг", "Пройдите первое упражнение", 1, exercisesCompleted},
+	{"exercises_100", "Сотня", "Пройдите 100 упражнений", 100, exercisesCompleted},
+	{"exercises_500", "Марафонец", "Пройдите 500 упражнений", 500, exercisesCompleted},
+	{"perfect_1", "Чистое произношение", "Получите 100 баллов за произношение", 1, perfectScores},
+	{"perfect_25", "Диктор", "Получите 100 баллов за произношение 25 раз", 25, perfectScores},
+	{"word_modules_1", "Словарный запас", "Пройдите модуль слов", 1, wordModulesCompleted},
+	{"word_modules_5", "Знаток слов", "Пройдите 5 модулей слов", 5, wordModulesCompleted},
+	{"phrase_modules_1", "Первая фраза", "Пройдите модуль фраз", 1, phraseModulesCompleted},
+	{"phrase_modules_5", "Собеседник", "Пройдите 5 модулей фраз", 5, phraseModulesCompleted},
+	{"streak_3", "Втягиваюсь", "Выполняйте дневную цель 3 дня подряд", 3, longestStreak},
+	{"streak_10", "Привычка", "Выполняйте дневную цель 10 дней подряд", 10, longestStreak},
+	{"streak_30", "Железная воля", "Выполняйте дневную цель 30 дней подряд", 30, longestStreak},
+	{"xp_1000", "Опытный", "Наберите 1000 опыта", 1000, totalXP},
+}
+
+// levelForXP возвращает уровень и границы опыта текущего и следующего уровней.
+func levelForXP(xp int) (int, int, int) {
+	level := int(math.Sqrt(float64(max(xp, 0))/levelXPStep)) + 1
+	return level, levelXPStep * (level - 1) * (level - 1), levelXPStep * level * level
+}
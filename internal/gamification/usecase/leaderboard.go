@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/gamification"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+	"strings"
+	"time"
+)
+
+const weekLayout = "2006-01-02"
+
+// leagueNames - лиги от младшей к старшей; индекс совпадает с tier в user_leagues.
+var leagueNames = []string{"bronze", "silver", "gold", "sapphire", "ruby", "diamond"}
+
+// weekStart возвращает понедельник (UTC) недели, в которую попадает t.
+func weekStart(t time.Time) time.Time {
+	t = t.UTC()
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
+
+// addWeeklyXP добавляет опыт в текущую неделю и при первом начислении записывает пользователя в лигу.
+func (uc *GamificationUsecase) addWeeklyXP(ctx context.Context, tx models.Transaction, userID uuid.UUID, xp int) error {
+	if err := uc.repo.AddWeeklyXP(ctx, tx, userID, weekStart(time.Now()).Format(weekLayout), xp); err != nil {
+		return err
+	}
+	return uc.repo.JoinLeague(ctx, tx, userID)
+}
+
+// GetLeaderboard возвращает первые участники таблицы scope и место самого пользователя,
+// даже если он в них не попал. Участники с равным опытом делят место.
+func (uc *GamificationUsecase) GetLeaderboard(ctx context.Context, userID uuid.UUID, scope string) (*models.Leaderboard, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	if scope != models.ScopeGlobal && scope != models.ScopeFriends && scope != models.ScopeWeekly {
+		return nil, fmt.Errorf("%w: unknown scope %q", gamification.ErrInvalidData, scope)
+	}
+
+	result := &models.Leaderboard{Scope: scope}
+	week, tier := "", 0
+	if scope == models.ScopeWeekly {
+		var err error
+		if tier, err = uc.repo.GetUserTier(ctx, userID); err != nil {
+			return nil, fmt.Errorf("failed to get leaderboard: %w", err)
+		}
+		tier = min(max(tier, 0), len(leagueNames)-1)
+		week = weekStart(time.Now()).Format(weekLayout)
+		result.League = &models.League{
+			Tier:         tier,
+			Name:         leagueNames[tier],
+			WeekStart:    week,
+			PromoteTop:   uc.cfg.Leaderboard.PromoteCount,
+			DemoteBottom: uc.cfg.Leaderboard.DemoteCount,
+		}
+	}
+
+	entries, err := uc.repo.GetLeaderboard(ctx, scope, userID, uc.cfg.Leaderboard.Size, week, tier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
+	}
+	me, err := uc.repo.GetLeaderboardRank(ctx, scope, userID, week, tier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
+	}
+
+	for i := range entries {
+		entry := &entries[i]
+		entry.Rank = i + 1
+		if i > 0 && entries[i-1].XP == entry.XP {
+			entry.Rank = entries[i-1].Rank
+		}
+		if entry.UserID == userID {
+			entry.IsMe = true
+			entry.Rank = me.Rank
+		}
+	}
+	result.Entries = entries
+	result.Me = *me
+
+	uc.logger.LogInfo(requestId, logger.UsecaseLayer, "GetLeaderboard",
+		fmt.Sprintf("%s leaderboard: %d entries, caller rank %d", scope, len(entries), me.Rank))
+
+	return result, nil
+}
+
+func (uc *GamificationUsecase) UpdateLeaderboardSettings(ctx context.Context, userID uuid.UUID, settings *models.LeaderboardSettings) error {
+	if settings.OptOut == nil {
+		return fmt.Errorf("%w: opt_out is required", gamification.ErrInvalidData)
+	}
+	if err := uc.repo.UpdateLeaderboardOptOut(ctx, userID, *settings.OptOut); err != nil {
+		return fmt.Errorf("failed to update leaderboard settings: %w", err)
+	}
+	return nil
+}
+
+// AddFriend отправляет заявку в друзья по почте или принимает встречную заявку: в таблице друзей
+// пользователи видят друг друга, только когда добавили друг друга оба. Ответ не зависит от того,
+// есть ли пользователь с такой почтой; повторное добавление ничего не меняет.
+func (uc *GamificationUsecase) AddFriend(ctx context.Context, userID uuid.UUID, email string) error {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return fmt.Errorf("%w: email is required", gamification.ErrInvalidData)
+	}
+
+	friendID, err := uc.repo.GetUserIDByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to add friend: %w", err)
+	}
+	if friendID == nil {
+		return nil
+	}
+	if *friendID == userID {
+		return fmt.Errorf("%w: cannot add yourself", gamification.ErrInvalidData)
+	}
+
+	if err := uc.repo.AddFriend(ctx, userID, *friendID); err != nil {
+		return fmt.Errorf("failed to add friend: %w", err)
+	}
+	return nil
+}
+
+// RemoveFriend удаляет друга, отзывает свою заявку или отклоняет входящую.
+func (uc *GamificationUsecase) RemoveFriend(ctx context.Context, userID uuid.UUID, email string) error {
+	removed, err := uc.repo.RemoveFriend(ctx, userID, strings.TrimSpace(email))
+	if err != nil {
+		return fmt.Errorf("failed to remove friend: %w", err)
+	}
+	if !removed {
+		return fmt.Errorf("%w: friend %s", gamification.ErrNotFound, email)
+	}
+	return nil
+}
+
+func (uc *GamificationUsecase) GetFriendRequests(ctx context.Context, userID uuid.UUID) (*models.FriendRequestList, error) {
+	requests, err := uc.repo.GetFriendRequests(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get friend requests: %w", err)
+	}
+	return &models.FriendRequestList{Requests: requests}, nil
+}
+
+// RollOverLeagues подводит итоги прошедшей недели: лучшие участники каждой лиги поднимаются,
+// худшие и неактивные опускаются. Повторный вызов за ту же неделю ничего не делает.
+func (uc *GamificationUsecase) RollOverLeagues(ctx context.Context) (err error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	tx, err := uc.repo.BeginTx(ctx)
+	if err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "RollOverLeagues",
+			fmt.Errorf("failed to begin transaction: %w", err))
+		return errors.New("failed to start transaction")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	previous := weekStart(time.Now()).AddDate(0, 0, -7).Format(weekLayout)
+	if _, err = uc.repo.RollOverLeagues(ctx, tx, previous,
+		uc.cfg.Leaderboard.PromoteCount, uc.cfg.Leaderboard.DemoteCount, len(leagueNames)-1); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "RollOverLeagues",
+			fmt.Errorf("failed to commit transaction: %w", err))
+		return errors.New("failed to commit transaction")
+	}
+	return nil
+}
+
+// RunLeagueRollover периодически подводит итоги недели, пока не отменён ctx.
+func (uc *GamificationUsecase) RunLeagueRollover(ctx context.Context) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+	ticker := time.NewTicker(uc.cfg.Leaderboard.RolloverInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := uc.RollOverLeagues(ctx); err != nil && ctx.Err() == nil {
+			uc.logger.LogError(requestId, logger.UsecaseLayer, "RunLeagueRollover", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
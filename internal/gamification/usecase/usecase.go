@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/gamification/repo"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/config"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+)
+
+type GamificationUsecase struct {
+	repo   *repo.GamificationRepo
+	logger logger.Logger
+	cfg    *config.Config
+}
+
+func NewGamificationUsecase(repo *repo.GamificationRepo, logger logger.Logger, cfg *config.Config) *GamificationUsecase {
+	return &GamificationUsecase{repo: repo, logger: logger, cfg: cfg}
+}
+
+type xpAward struct {
+	source string
+	ref    string
+	xp     int
+}
+
+// OnAttempt начисляет опыт за первое прохождение упражнения, прохождение с первой попытки,
+// идеальный балл и завершение модуля, затем открывает достигнутые достижения. Каждое
+// начисление и достижение записывается один раз, поэтому повторные попытки ничего не дают.
+// Упражнения личных наборов слов ученик добавляет сам, за них опыт и достижения не даются.
+func (uc *GamificationUsecase) OnAttempt(ctx context.Context, tx models.Transaction, attempt *models.ExerciseAttempt, status string) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	personal, err := uc.repo.IsPersonalExercise(ctx, tx, attempt.ExerciseID, attempt.ExerciseType)
+	if err != nil {
+		return err
+	}
+	if personal {
+		return nil
+	}
+
+	if attempt.Result == "completed" {
+		awards, err := uc.attemptAwards(ctx, tx, attempt)
+		if err != nil {
+			return err
+		}
+
+		gained := 0
+		for _, award := range awards {
+			added, err := uc.repo.AddXPEvent(ctx, tx, attempt.UserID, award.source, award.ref, award.xp)
+			if err != nil {
+				return err
+			}
+			if added {
+				gained += award.xp
+			}
+		}
+
+		if gained > 0 {
+			total, err := uc.repo.AddUserXP(ctx, tx, attempt.UserID, gained)
+			if err != nil {
+				return err
+			}
+			level, _, _ := levelForXP(total)
+			if err := uc.repo.UpdateUserLevel(ctx, tx, attempt.UserID, level); err != nil {
+				return err
+			}
+			if err := uc.addWeeklyXP(ctx, tx, attempt.UserID, gained); err != nil {
+				return err
+			}
+			uc.logger.LogInfo(requestId, logger.UsecaseLayer, "OnAttempt",
+				fmt.Sprintf("awarded %d xp, total %d, level %d", gained, total, level))
+		}
+	}
+
+	return uc.unlockAchievements(ctx, tx, attempt.UserID)
+}
+
+func (uc *GamificationUsecase) attemptAwards(ctx context.Context, tx models.Transaction, attempt *models.ExerciseAttempt) ([]xpAward, error) {
+	ref := fmt.Sprintf("%s:%d", attempt.ExerciseType, attempt.ExerciseID)
+
+	completedXP := xpWordCompleted
+	if attempt.ExerciseType == "phrase" {
+		completedXP = xpPhraseCompleted
+	}
+	awards := []xpAward{{source: "exercise_completed", ref: ref, xp: completedXP}}
+
+	total, err := uc.repo.CountAttempts(ctx, tx, attempt.UserID, attempt.ExerciseID, attempt.ExerciseType)
+	if err != nil {
+		return nil, err
+	}
+	if total == 1 {
+		awards = append(awards, xpAward{source: "first_try", ref: ref, xp: xpFirstTry})
+	}
+	if attempt.Score != nil && *attempt.Score == 100 {
+		awards = append(awards, xpAward{source: "perfect_score", ref: ref, xp: xpPerfectScore})
+	}
+
+	moduleID, completed, err := uc.repo.GetModuleCompletion(ctx, tx, attempt.UserID, attempt.ExerciseID, attempt.ExerciseType)
+	if err != nil {
+		return nil, err
+	}
+	if completed {
+		awards = append(awards, xpAward{
+			source: attempt.ExerciseType + "_module_completed",
+			ref:    fmt.Sprintf("%s:%d", attempt.ExerciseType, moduleID),
+			xp:     xpModuleCompleted,
+		})
+	}
+
+	return awards, nil
+}
+
+func (uc *GamificationUsecase) unlockAchievements(ctx context.Context, tx models.Transaction, userID uuid.UUID) error {
+	stats, err := uc.repo.GetAchievementStats(ctx, tx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range achievements {
+		if rule.Metric(stats) < rule.Threshold {
+			continue
+		}
+		if err := uc.repo.UnlockAchievement(ctx, tx, userID, rule.Code); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetAchievements возвращает опыт, уровень и весь каталог достижений с прогрессом пользователя.
+func (uc *GamificationUsecase) GetAchievements(ctx context.Context, userID uuid.UUID) (*models.AchievementList, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	tx, err := uc.repo.BeginTx(ctx)
+	if err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetAchievements",
+			fmt.Errorf("failed to begin transaction: %w", err))
+		return nil, errors.New("failed to start transaction")
+	}
+	defer tx.Rollback()
+
+	stats, err := uc.repo.GetAchievementStats(ctx, tx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get achievements: %w", err)
+	}
+	unlocked, err := uc.repo.GetUserAchievements(ctx, tx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get achievements: %w", err)
+	}
+
+	level, levelXP, nextLevelXP := levelForXP(stats.XP)
+	result := &models.AchievementList{
+		XP:           stats.XP,
+		Level:        level,
+		LevelXP:      levelXP,
+		NextLevelXP:  nextLevelXP,
+		Achievements: make([]models.Achievement, 0, len(achievements)),
+	}
+
+	for _, rule := range achievements {
+		achievement := models.Achievement{
+			Code:        rule.Code,
+			Title:       rule.Title,
+			Description: rule.Description,
+			Progress:    min(rule.Metric(stats), rule.Threshold),
+			Threshold:   rule.Threshold,
+		}
+		if at, ok := unlocked[rule.Code]; ok {
+			achievement.Unlocked = true
+			achievement.UnlockedAt = &at
+			achievement.Progress = rule.Threshold
+		}
+		result.Achievements = append(result.Achievements, achievement)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,17 @@
+package models
+
+const (
+	AccentUS = "us"
+	AccentUK = "uk"
+)
+
+var Accents = []string{AccentUS, AccentUK}
+
+// AccentVariant - произношение слова или фразы с определённым акцентом. Пустой Audio
+// при сохранении оставляет прежнюю озвучку варианта, при выдаче - основную.
+type AccentVariant struct {
+	Accent        string   `json:"accent"`
+	Transcription string   `json:"transcription"`
+	Audio         string   `json:"audio"`
+	Phonemes      []string `json:"-"`
+}
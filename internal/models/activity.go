@@ -0,0 +1,48 @@
+package models
+
+import (
+	"github.com/satori/uuid"
+	"time"
+)
+
+const (
+	GoalExercises = "exercises"
+	GoalMinutes   = "minutes"
+)
+
+type ActivityDay struct {
+	Day        time.Time `json:"-"`
+	Date       string    `json:"date"` // YYYY-MM-DD в часовом поясе пользователя
+	Exercises  int       `json:"exercises"`
+	Completed  int       `json:"completed"`
+	DurationMs int64     `json:"duration_ms"`
+	GoalMet    bool      `json:"goal_met"`
+	Frozen     bool      `json:"frozen"`
+}
+
+type StreakState struct {
+	UserID        uuid.UUID
+	GoalType      string
+	GoalValue     int
+	CurrentStreak int
+	LongestStreak int
+	Freezes       int
+	LastGoalDay   *time.Time
+}
+
+type ActivitySettings struct {
+	Timezone  *string `json:"timezone"`
+	GoalType  *string `json:"goal_type"`
+	GoalValue *int    `json:"goal_value"`
+}
+
+type ActivityCalendar struct {
+	Timezone      string        `json:"timezone"`
+	GoalType      string        `json:"goal_type"`
+	GoalValue     int           `json:"goal_value"`
+	CurrentStreak int           `json:"current_streak"`
+	LongestStreak int           `json:"longest_streak"`
+	Freezes       int           `json:"freezes"`
+	Today         ActivityDay   `json:"today"`
+	Days          []ActivityDay `json:"days"`
+}
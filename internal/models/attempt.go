@@ -0,0 +1,23 @@
+package models
+
+import (
+	"github.com/satori/uuid"
+	"time"
+)
+
+type ExerciseAttempt struct {
+	ID            int       `json:"id"`
+	UserID        uuid.UUID `json:"-"`
+	ExerciseID    int       `json:"exercise_id"`
+	ExerciseType  string    `json:"exercise_type"`
+	Result        string    `json:"result"`
+	Score         *int      `json:"score,omitempty"`
+	DurationMs    int       `json:"duration_ms"`
+	Transcription string    `json:"transcription,omitempty"`
+	RecordingID   string    `json:"recording_id,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type AttemptList struct {
+	Attempts []ExerciseAttempt `json:"attempts"`
+}
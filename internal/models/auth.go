@@ -27,6 +27,8 @@ type User struct {
 	Name         string    `json:"name,omitempty"`
 	IsDeleted    bool      `json:"-"`
 	Token        string    `json:"token,omitempty"`
+
+	StartWordModule *int `json:"start_word_module,omitempty"` // выбран вступительным тестом
 }
 
 type UserUpdatePassword struct {
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	BookmarkWord   = "word"
+	BookmarkPhrase = "phrase"
+)
+
+// Bookmark - закладка пользователя на слово внутри упражнения или на фразу.
+// Слово определяется упражнением и позицией в его массиве words.
+type Bookmark struct {
+	ID            int       `json:"id"`
+	Type          string    `json:"type"`
+	ExerciseID    int       `json:"exercise_id"`
+	WordIndex     int       `json:"word_index"`
+	ModuleID      int       `json:"module_id"`
+	Text          string    `json:"text"`
+	Translation   string    `json:"translation"`
+	Transcription string    `json:"transcription"`
+	Audio         string    `json:"audio"`
+	Note          string    `json:"note"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type BookmarkList struct {
+	Bookmarks []Bookmark `json:"bookmarks"`
+}
+
+type BookmarkCreate struct {
+	Type       string `json:"type"`
+	ExerciseID *int   `json:"exercise_id"`
+	WordIndex  int    `json:"word_index"`
+	Note       string `json:"note"`
+}
+
+type BookmarkNote struct {
+	Note string `json:"note"`
+}
+
+// BookmarkFilter - фильтр закладок; ModuleID имеет смысл только вместе с Type,
+// так как номера модулей слов и фраз пересекаются.
+type BookmarkFilter struct {
+	Type     string
+	ModuleID *int
+}
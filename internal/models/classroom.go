@@ -0,0 +1,129 @@
+package models
+
+import (
+	"github.com/satori/uuid"
+	"time"
+)
+
+const (
+	AssignmentNotStarted = "not_started"
+	AssignmentInProgress = "in_progress"
+	AssignmentCompleted  = "completed"
+	AssignmentOverdue    = "overdue"
+)
+
+type Class struct {
+	ID        int       `json:"id"`
+	TeacherID uuid.UUID `json:"teacher_id"`
+	Title     string    `json:"title"`
+	JoinCode  string    `json:"join_code,omitempty"` // виден только учителю
+	Students  int       `json:"students"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ClassList struct {
+	Teaching []Class `json:"teaching"`
+	Enrolled []Class `json:"enrolled"`
+}
+
+type ClassCreate struct {
+	Title string `json:"title"`
+}
+
+type JoinClassRequest struct {
+	Code string `json:"code"`
+}
+
+type Assignment struct {
+	ID          int        `json:"id"`
+	ClassID     int        `json:"class_id"`
+	ModuleType  string     `json:"module_type"` // "word" или "phrase"
+	ModuleID    int        `json:"module_id"`
+	ModuleTitle string     `json:"module_title"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type AssignmentList struct {
+	Assignments []Assignment `json:"assignments"`
+}
+
+type AssignmentCreate struct {
+	ModuleType string     `json:"module_type"`
+	ModuleID   int        `json:"module_id"`
+	DueAt      *time.Time `json:"due_at"`
+}
+
+type ClassStudent struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Name     string    `json:"name"`
+	Email    string    `json:"email"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// StudentAssignmentProgress - сводка exercise_progress ученика по упражнениям назначенного модуля.
+type StudentAssignmentProgress struct {
+	AssignmentID      int        `json:"assignment_id"`
+	Total             int        `json:"total"`
+	Completed         int        `json:"completed"`
+	Failed            int        `json:"failed"`
+	Attempts          int        `json:"attempts"`
+	AverageScore      *float64   `json:"average_score,omitempty"`
+	CompletionPercent float64    `json:"completion_percent"`
+	Status            string     `json:"status"`
+	LastAttemptAt     *time.Time `json:"last_attempt_at,omitempty"`
+	CompletedAt       *time.Time `json:"completed_at,omitempty"`
+}
+
+type StudentProgress struct {
+	Student           ClassStudent                `json:"student"`
+	CompletionPercent float64                     `json:"completion_percent"`
+	Assignments       []StudentAssignmentProgress `json:"assignments"`
+}
+
+type AssignmentSummary struct {
+	Assignment        Assignment `json:"assignment"`
+	StudentsCompleted int        `json:"students_completed"`
+	StudentsOverdue   int        `json:"students_overdue"`
+	CompletionPercent float64    `json:"completion_percent"`
+	AverageScore      *float64   `json:"average_score,omitempty"`
+}
+
+type ClassDashboard struct {
+	Class       Class               `json:"class"`
+	Assignments []AssignmentSummary `json:"assignments"`
+	Students    []StudentProgress   `json:"students"`
+}
+
+const (
+	RecordingsPending = "pending"
+	RecordingsGraded  = "graded"
+)
+
+type RecordingGrade struct {
+	Score    *int      `json:"score"`
+	Result   string    `json:"result,omitempty"` // "completed" или "failed"; по умолчанию по порогу
+	Comment  string    `json:"comment"`
+	GradedAt time.Time `json:"graded_at,omitempty"`
+}
+
+// RecordingReview - запись произношения ученика в очереди учителя.
+type RecordingReview struct {
+	AttemptID     int             `json:"attempt_id"`
+	StudentID     uuid.UUID       `json:"student_id"`
+	StudentName   string          `json:"student_name"`
+	ExerciseID    int             `json:"exercise_id"`
+	ExerciseType  string          `json:"exercise_type"`
+	ExerciseText  string          `json:"exercise_text"`
+	AutoResult    string          `json:"auto_result"`
+	AutoScore     *int            `json:"auto_score,omitempty"`
+	Transcription string          `json:"transcription,omitempty"`
+	RecordingID   string          `json:"-"`
+	RecordingURL  string          `json:"recording_url,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	Grade         *RecordingGrade `json:"grade,omitempty"`
+}
+
+type RecordingQueue struct {
+	Recordings []RecordingReview `json:"recordings"`
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+)
+
+type ModuleProgress struct {
+	ModuleID          int     `json:"module_id"`
+	Title             string  `json:"title"`
+	Deck              bool    `json:"deck,omitempty"` // личный набор слов пользователя
+	Total             int     `json:"total"`
+	Attempted         int     `json:"attempted"`
+	Completed         int     `json:"completed"`
+	Failed            int     `json:"failed"`
+	Skipped           int     `json:"skipped"`
+	Attempts          int     `json:"attempts"`
+	TimeSpentMs       int64   `json:"time_spent_ms"`
+	CompletionPercent float64 `json:"completion_percent"`
+}
+
+// TrackProgress - прогресс по курсу (слова или фразы) целиком и по его модулям.
+type TrackProgress struct {
+	Track             string           `json:"track"`
+	Total             int              `json:"total"`
+	Attempted         int              `json:"attempted"`
+	Completed         int              `json:"completed"`
+	Failed            int              `json:"failed"`
+	Skipped           int              `json:"skipped"`
+	Attempts          int              `json:"attempts"`
+	TimeSpentMs       int64            `json:"time_spent_ms"`
+	CompletionPercent float64          `json:"completion_percent"`
+	Modules           []ModuleProgress `json:"modules,omitempty"`
+}
+
+type RecentWord struct {
+	ExerciseID  int       `json:"exercise_id"`
+	Words       []string  `json:"words"`
+	Result      string    `json:"result"`
+	Score       *int      `json:"score,omitempty"`
+	PractisedAt time.Time `json:"practised_at"`
+}
+
+type ProgressDashboard struct {
+	Totals      TrackProgress `json:"totals"` // сумма по обоим курсам, без разбивки по модулям
+	Word        TrackProgress `json:"word"`
+	Phrase      TrackProgress `json:"phrase"`
+	RecentWords []RecentWord  `json:"recent_words"`
+}
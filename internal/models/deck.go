@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+)
+
+// Deck - личный набор слов пользователя. Слова набора хранятся как упражнения модуля,
+// поэтому проходятся через /word-modules/{id}/exercises так же, как общие модули.
+type Deck struct {
+	ID        int        `json:"id"`
+	Title     string     `json:"title"`
+	Words     int        `json:"words"`
+	CreatedAt time.Time  `json:"created_at"`
+	Exercises []Exercise `json:"exercises,omitempty"`
+}
+
+type DeckList struct {
+	Decks []Deck `json:"decks"`
+}
+
+type DeckCreate struct {
+	Title string `json:"title"`
+}
+
+// DeckWordCreate - слово для личного набора. Аудио либо загружается пользователем (AudioLink),
+// либо синтезируется по слову, если GenerateAudio выставлен. Exercises - виды упражнений,
+// которые создаются для слова, по умолчанию только произношение.
+type DeckWordCreate struct {
+	DeckID        int      `json:"-"`
+	Word          string   `json:"word"`
+	Translation   string   `json:"translation"`
+	Transcription string   `json:"transcription"`
+	Phonemes      []string `json:"-"`
+	AudioLink     string   `json:"-"`
+	GenerateAudio bool     `json:"generate_audio"`
+	Exercises     []string `json:"exercises"`
+}
+
+// DeckWordAdded - упражнения, созданные для слова набора. Id - первое из них.
+type DeckWordAdded struct {
+	Id          *int  `json:"id"`
+	ExerciseIDs []int `json:"exercise_ids"`
+}
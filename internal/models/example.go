@@ -0,0 +1,45 @@
+package models
+
+const (
+	ExamplePhrase   = "phrase"
+	ExampleSentence = "sentence"
+)
+
+// Example - предложение, в котором встречается слово: фразовое упражнение (Kind = phrase,
+// ModuleID - его модуль) или отдельный пример из словаря (Kind = sentence).
+type Example struct {
+	Kind        string `json:"kind"`
+	ID          int    `json:"id"`
+	ModuleID    *int   `json:"module_id,omitempty"`
+	Sentence    string `json:"sentence"`
+	Translation string `json:"translation"`
+	Audio       string `json:"audio"`
+	Form        string `json:"form"`       // слово в том виде, в каком оно стоит в предложении
+	WordIndex   int    `json:"word_index"` // позиция слова в упражнении
+}
+
+type ExampleList struct {
+	Examples []Example `json:"examples"`
+}
+
+// ExampleData - отдельный пример, который автор добавляет в словарь.
+type ExampleData struct {
+	ID          int    `json:"id"`
+	Sentence    string `json:"sentence"`
+	Translation string `json:"translation"`
+	Audio       string `json:"audio"`
+}
+
+// SentenceWord - слово предложения и ключ словаря, которым может оказаться его начальная
+// форма. У одного слова обычно несколько ключей.
+type SentenceWord struct {
+	Position int
+	Form     string
+	Key      string
+}
+
+// StoredSentence - предложение фразового упражнения, ещё не разобранное на слова.
+type StoredSentence struct {
+	ID       int
+	Sentence string
+}
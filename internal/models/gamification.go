@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+)
+
+// AchievementStats - показатели пользователя, по которым открываются достижения.
+type AchievementStats struct {
+	ExercisesCompleted     int
+	PerfectScores          int
+	WordModulesCompleted   int
+	PhraseModulesCompleted int
+	LongestStreak          int
+	XP                     int
+}
+
+type Achievement struct {
+	Code        string     `json:"code"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Progress    int        `json:"progress"`
+	Threshold   int        `json:"threshold"`
+	Unlocked    bool       `json:"unlocked"`
+	UnlockedAt  *time.Time `json:"unlocked_at,omitempty"`
+}
+
+type AchievementList struct {
+	XP           int           `json:"xp"`
+	Level        int           `json:"level"`
+	LevelXP      int           `json:"level_xp"`      // опыт, с которого начался текущий уровень
+	NextLevelXP  int           `json:"next_level_xp"` // опыт, нужный для следующего уровня
+	Achievements []Achievement `json:"achievements"`
+}
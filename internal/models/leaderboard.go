@@ -0,0 +1,55 @@
+package models
+
+import (
+	"github.com/satori/uuid"
+	"time"
+)
+
+const (
+	ScopeGlobal  = "global"
+	ScopeFriends = "friends"
+	ScopeWeekly  = "weekly"
+)
+
+type LeaderboardEntry struct {
+	UserID uuid.UUID `json:"-"`
+	Rank   int       `json:"rank"`
+	Name   string    `json:"name"`
+	XP     int       `json:"xp"`
+	Level  int       `json:"level"`
+	IsMe   bool      `json:"is_me"`
+}
+
+type League struct {
+	Tier         int    `json:"tier"`
+	Name         string `json:"name"`
+	WeekStart    string `json:"week_start"`
+	PromoteTop   int    `json:"promote_top"`
+	DemoteBottom int    `json:"demote_bottom"`
+}
+
+type Leaderboard struct {
+	Scope   string             `json:"scope"`
+	League  *League            `json:"league,omitempty"`
+	Entries []LeaderboardEntry `json:"entries"`
+	Me      LeaderboardEntry   `json:"me"` // место вызывающего, даже если он не попал в entries
+}
+
+type LeaderboardSettings struct {
+	OptOut *bool `json:"opt_out"`
+}
+
+type FriendRequest struct {
+	Email string `json:"email"`
+}
+
+// IncomingFriendRequest - заявка в друзья, которую пользователь ещё не принял.
+type IncomingFriendRequest struct {
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type FriendRequestList struct {
+	Requests []IncomingFriendRequest `json:"requests"`
+}
@@ -0,0 +1,15 @@
+package models
+
+// LexiconEntry - словарная статья: единственное место хранения написания слова, его
+// транскрипции, переводов и озвучки. Упражнения ссылаются на статьи по позициям.
+type LexiconEntry struct {
+	ID           int      `json:"id"`
+	Word         string   `json:"word"`
+	IPA          string   `json:"ipa"`
+	Translations []string `json:"translations"`
+	Audio        string   `json:"audio"`
+	PartOfSpeech string   `json:"part_of_speech,omitempty"`
+	Phonemes     []string `json:"phonemes,omitempty"`
+	// LocalizedTranslations - перевод на другие языки; Translations - на основном
+	LocalizedTranslations map[string]string `json:"-"`
+}
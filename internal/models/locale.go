@@ -0,0 +1,15 @@
+package models
+
+// DefaultContentLocale - основной язык переводов. Он хранится в самих статьях словаря и
+// упражнениях и подставляется, если перевода на языке ученика нет.
+const DefaultContentLocale = "ru"
+
+// ContentLocales - языки, на которые переводятся материалы.
+var ContentLocales = []string{"ru", "kk", "uz"}
+
+// ContentSettings - настройки материалов в профиле. Пустое значение сбрасывает выбор:
+// язык снова определяется по Accept-Language, произношение - основное.
+type ContentSettings struct {
+	Locale *string `json:"locale"`
+	Accent *string `json:"accent"`
+}
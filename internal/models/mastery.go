@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+)
+
+type PhonemeMastery struct {
+	Phoneme    string    `json:"phoneme"`
+	Accuracy   float64   `json:"accuracy"`
+	Weight     float64   `json:"-"`
+	TrendValue float64   `json:"trend_value"`
+	Trend      string    `json:"trend"` // improving, declining, stable
+	Attempts   int       `json:"attempts"`
+	Correct    int       `json:"correct"`
+	Weak       bool      `json:"weak"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	Tip        *TipData  `json:"tip,omitempty"`
+}
+
+type PhonemeMasteryList struct {
+	Phonemes []PhonemeMastery `json:"phonemes"`
+}
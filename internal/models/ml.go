@@ -4,4 +4,6 @@ type MlAnswer struct {
 	Transcription string `json:"transcription,omitempty"`
 	Text          string `json:"text,omitempty"`
 	MlError       string `json:"error,omitempty"`
+
+	Score *PronunciationScore `json:"score,omitempty"`
 }
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"encoding/json"
+	"github.com/satori/uuid"
+	"time"
+)
+
+const NotificationRecordingGraded = "recording_graded"
+
+type Notification struct {
+	ID        int             `json:"id"`
+	UserID    uuid.UUID       `json:"-"`
+	Type      string          `json:"type"`
+	Title     string          `json:"title"`
+	Body      string          `json:"body"`
+	Payload   json.RawMessage `json:"payload"`
+	ReadAt    *time.Time      `json:"read_at,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type NotificationList struct {
+	Unread        int            `json:"unread"`
+	Notifications []Notification `json:"notifications"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"github.com/satori/uuid"
+	"time"
+)
+
+// PlacementTest - состояние вступительного теста. Low и High - границы бинарного поиска
+// по ModuleIDs: модули левее Low считаются освоенными, начиная с High - нет.
+type PlacementTest struct {
+	ID                int                 `json:"id"`
+	UserID            uuid.UUID           `json:"-"`
+	Status            string              `json:"status"`
+	ModuleIDs         []int               `json:"-"`
+	Low               int                 `json:"-"`
+	High              int                 `json:"-"`
+	ProbeItems        int                 `json:"-"`
+	ProbePassed       int                 `json:"-"`
+	Answered          int                 `json:"answered"`
+	Correct           int                 `json:"correct"`
+	MaxItems          int                 `json:"max_items"`
+	CurrentExerciseID *int                `json:"-"`
+	StartModuleID     *int                `json:"start_module_id,omitempty"`
+	FinishedAt        *time.Time          `json:"finished_at,omitempty"`
+	Item              *Exercise           `json:"item,omitempty"`
+	Result            *PronunciationScore `json:"result,omitempty"` // оценка последнего ответа на произношение
+}
+
+type PlacementAnswer struct {
+	UserID        uuid.UUID `json:"-"`
+	TestID        int       `json:"-"`
+	ExerciseID    *int      `json:"exercise_id"`
+	WordIndex     *int      `json:"word_index"`    // только для упражнений из одного слова
+	Status        string    `json:"status"`        // для guessWord: completed или failed
+	Transcription string    `json:"transcription"` // для упражнений на произношение
+	DurationMs    int       `json:"duration_ms"`
+}
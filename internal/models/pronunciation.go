@@ -0,0 +1,42 @@
+package models
+
+import (
+	"github.com/satori/uuid"
+)
+
+type PhonemeAlignment struct {
+	Expected   string  `json:"expected,omitempty"`
+	Recognized string  `json:"recognized,omitempty"`
+	Operation  string  `json:"operation"` // match, substitution, deletion, insertion
+	Cost       float64 `json:"cost"`
+}
+
+type PronunciationScore struct {
+	Score      int                `json:"score"`
+	Passed     bool               `json:"passed"`
+	Threshold  int                `json:"threshold"`
+	Expected   string             `json:"expected"`
+	Recognized string             `json:"recognized"`
+	Phonemes   []PhonemeAlignment `json:"phonemes"`
+}
+
+type PronunciationAttempt struct {
+	UserID        uuid.UUID
+	ExerciseType  string
+	Exercise      *Exercise
+	WordIndex     *int // nil - запись всего упражнения
+	Transcription string
+	Text          string
+	DurationMs    int
+	RecordingID   string
+}
+
+type PronunciationResult struct {
+	AttemptID    int                 `json:"attempt_id"`
+	ExerciseID   int                 `json:"exercise_id"`
+	ExerciseType string              `json:"exercise_type"`
+	Result       string              `json:"result"`
+	Status       string              `json:"status"`
+	Text         string              `json:"text,omitempty"`
+	Score        *PronunciationScore `json:"score"`
+}
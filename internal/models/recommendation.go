@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	StrategyAdaptive        = "adaptive"
+	StrategyFirstIncomplete = "first_incomplete"
+)
+
+// RecommendationCandidate - упражнение с признаками, по которым его ранжирует рекомендатель.
+type RecommendationCandidate struct {
+	Exercise       Exercise
+	Attempts       int
+	RecentFailures int
+	ReviewID       *int
+	DueAt          *time.Time
+}
+
+type RecommendedExercise struct {
+	Exercise Exercise `json:"exercise"`
+	Score    float64  `json:"score"`
+	Reasons  []string `json:"reasons,omitempty"` // review_due, recent_failures, weak_phonemes, new
+}
+
+type RecommendationList struct {
+	Strategy  string                `json:"strategy"`
+	Exercises []RecommendedExercise `json:"exercises"`
+}
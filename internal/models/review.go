@@ -0,0 +1,43 @@
+package models
+
+import (
+	"github.com/satori/uuid"
+	"time"
+)
+
+type ReviewItem struct {
+	ID             int
+	UserID         uuid.UUID
+	ExerciseID     int
+	ExerciseType   string
+	Ease           float64
+	IntervalDays   int
+	Repetitions    int
+	Lapses         int
+	DueAt          time.Time
+	LastReviewedAt *time.Time
+}
+
+type ReviewGrade struct {
+	UserID        uuid.UUID `json:"-"`
+	ReviewID      int       `json:"-"`
+	Grade         *int      `json:"grade"`
+	Status        string    `json:"status"`
+	Score         *int      `json:"score"`
+	DurationMs    int       `json:"duration_ms"`
+	Transcription string    `json:"transcription"`
+}
+
+type ReviewResult struct {
+	ReviewID     int       `json:"review_id"`
+	Grade        int       `json:"grade"`
+	Ease         float64   `json:"ease"`
+	IntervalDays int       `json:"interval_days"`
+	Repetitions  int       `json:"repetitions"`
+	DueAt        time.Time `json:"due_at"`
+}
+
+type ReviewSettings struct {
+	NewPerDay     *int `json:"new_per_day"`
+	ReviewsPerDay *int `json:"reviews_per_day"`
+}
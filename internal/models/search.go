@@ -0,0 +1,49 @@
+package models
+
+import (
+	"github.com/satori/uuid"
+)
+
+// Типы результатов поиска. Личные наборы (deck) и их слова видны только владельцу.
+const (
+	SearchWordModule   = "word_module"
+	SearchPhraseModule = "phrase_module"
+	SearchDeck         = "deck"
+	SearchWord         = "word"
+	SearchPhrase       = "phrase"
+	SearchTip          = "tip"
+)
+
+const (
+	SearchModeText = "text"
+	SearchModeIPA  = "ipa"
+)
+
+// SearchQuery - разобранный запрос поиска. В режиме ipa ищется последовательность Phonemes,
+// в режиме text - Text. Пустой Types означает все типы.
+type SearchQuery struct {
+	Text     string
+	Mode     string
+	Phonemes []string
+	Types    []string
+	UserID   uuid.UUID
+	Limit    int
+	Offset   int
+}
+
+type SearchResult struct {
+	Type          string  `json:"type"`
+	ID            int     `json:"id"`
+	ModuleID      *int    `json:"module_id,omitempty"`
+	Title         string  `json:"title"`
+	Subtitle      string  `json:"subtitle,omitempty"`
+	Transcription string  `json:"transcription,omitempty"`
+	Score         float64 `json:"score,omitempty"`
+}
+
+type SearchResults struct {
+	Query   string         `json:"query"`
+	Mode    string         `json:"mode"`
+	Total   int            `json:"total"`
+	Results []SearchResult `json:"results"`
+}
@@ -1,9 +1,33 @@
 package models
 
+const (
+	// DefaultTipLocale - язык объяснений, на котором есть советы для всех фонем.
+	DefaultTipLocale = "ru"
+
+	TipBeginner     = "beginner"
+	TipIntermediate = "intermediate"
+	TipAdvanced     = "advanced"
+)
+
 type TipData struct {
-	TipID        *int   `json:"id,omitempty"`
-	Phonema      string `json:"phonema"`
-	TipText      string `json:"text"`
-	TipMediaLink string `json:"media_link"`
-	TipAudioLink string `json:"audio_link"`
+	TipID        *int     `json:"id,omitempty"`
+	Phonema      string   `json:"phonema"`
+	TipText      string   `json:"text"`
+	TipMediaLink string   `json:"media_link"`
+	TipAudioLink string   `json:"audio_link"`
+	Locale       string   `json:"locale,omitempty"`
+	Position     int      `json:"position"`
+	Difficulty   string   `json:"difficulty,omitempty"`
+	ExampleWords []string `json:"example_words,omitempty"`
+}
+
+type TipList struct {
+	Tips []TipData `json:"tips"`
+}
+
+// TipFilter - выборка советов для ученика. Если на языке Locale советов нет,
+// отдаются советы на DefaultTipLocale.
+type TipFilter struct {
+	Phoneme string
+	Locale  string
 }
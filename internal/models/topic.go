@@ -1,12 +1,31 @@
 package models
 
+// Виды материалов, к которым привязывается тема. TopicWord - статья словаря.
+const (
+	TopicWordModule     = "word_module"
+	TopicPhraseModule   = "phrase_module"
+	TopicWordExercise   = "word_exercise"
+	TopicPhraseExercise = "phrase_exercise"
+	TopicWord           = "word"
+)
+
 type TopicsList struct {
 	Topics []OneTopic `json:"topics"`
 }
 
+// OneTopic - тема и её слова в общих модулях: AllWords - сколько всего, TrueWords - сколько
+// из них пользователь уже прошёл. Без авторизации TrueWords не заполняется.
 type OneTopic struct {
 	TopicId   *int   `json:"topic_id,omitempty"`
 	Topic     string `json:"topic"`
+	Title     string `json:"title,omitempty"`
 	AllWords  *int   `json:"all_words"`
 	TrueWords *int   `json:"true_words"`
 }
+
+// TopicLink - привязка темы к материалу вида Kind с идентификатором TargetID.
+type TopicLink struct {
+	TopicID  int
+	Kind     string
+	TargetID int
+}
@@ -9,6 +9,7 @@ type Transaction interface {
 	Commit() error
 	Rollback() error
 	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
 	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
 	Exec(query string, args ...any) (sql.Result, error)
 }
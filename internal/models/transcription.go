@@ -0,0 +1,47 @@
+package models
+
+// TranscriptionRequest - текст, для которого автор упражнения просит транскрипцию.
+// Если Transcription заполнена, она сверяется с предложенной.
+type TranscriptionRequest struct {
+	Text          string `json:"text"`
+	Transcription string `json:"transcription,omitempty"`
+}
+
+// WordTranscription - варианты произношения слова. Source - dictionary, если слово нашлось
+// в словаре, или rules, если транскрипция построена по правилам чтения и может быть неточной.
+type WordTranscription struct {
+	Word     string   `json:"word"`
+	Variants []string `json:"variants"`
+	Source   string   `json:"source"`
+}
+
+type TranscriptionSuggestion struct {
+	Text    string                `json:"text"`
+	IPA     string                `json:"ipa"`
+	Source  string                `json:"source"`
+	Words   []WordTranscription   `json:"words"`
+	Warning *TranscriptionWarning `json:"warning,omitempty"`
+}
+
+// TranscriptionWarning - введённая транскрипция заметно расходится со словарной.
+type TranscriptionWarning struct {
+	Text      string `json:"text"`
+	Submitted string `json:"submitted"`
+	Suggested string `json:"suggested"`
+	Score     int    `json:"score"`
+}
+
+// CreatedExercise - ответ на создание упражнения с предупреждениями о транскрипциях.
+// Предупреждения не мешают созданию: автор может исправить упражнение позже.
+type CreatedExercise struct {
+	Id       *int                   `json:"id"`
+	Warnings []TranscriptionWarning `json:"warnings,omitempty"`
+}
+
+// StoredTranscription - сохранённая транскрипция статьи словаря или фразы, для которой
+// ещё не построена последовательность фонем.
+type StoredTranscription struct {
+	ID            int      `json:"id"`
+	Transcription string   `json:"transcription"`
+	Phonemes      []string `json:"phonemes"`
+}
@@ -11,15 +11,22 @@ type CreateWordData struct {
 	Transcription string `json:"transcription"`
 	AudioLink     string `json:"audio_link"`
 	Translation   string `json:"translation"`
+	// Phonemes заполняется при разборе транскрипции
+	Phonemes []string `json:"-"`
+	// LocalizedTranslations - переводы на другие языки, кроме основного (Translation)
+	LocalizedTranslations map[string]string `json:"-"`
 }
 
 type CreateWordDataList struct {
-	Exercise      string   `json:"exercise"`
-	Word          []string `json:"word"`
-	ModuleId      *int     `json:"id"`
-	Transcription []string `json:"transcription"`
-	AudioLink     []string `json:"audio_link"`
-	Translation   []string `json:"translation"`
+	Exercise      string     `json:"exercise"`
+	Word          []string   `json:"word"`
+	ModuleId      *int       `json:"id"`
+	Transcription []string   `json:"transcription"`
+	AudioLink     []string   `json:"audio_link"`
+	Translation   []string   `json:"translation"`
+	Phonemes      [][]string `json:"-"`
+	// LocalizedTranslations - переводы слов на другие языки, по одному на слово
+	LocalizedTranslations map[string][]string `json:"-"`
 }
 
 type CreatePhraseData struct {
@@ -30,6 +37,9 @@ type CreatePhraseData struct {
 	AudioLink     string   `json:"audio"`
 	Translate     string   `json:"translate"`
 	Chain         []string `json:"chain"`
+	Phonemes      []string `json:"-"`
+	// LocalizedTranslations - переводы на другие языки, кроме основного (Translate)
+	LocalizedTranslations map[string]string `json:"-"`
 }
 
 type ExerciseProgress struct {
@@ -37,6 +47,12 @@ type ExerciseProgress struct {
 	ExerciseID   *int      `json:"exercise_id"`
 	ExerciseType string    `json:"exercise_type"`
 	Status       string    `json:"status"`
+
+	Score         *int   `json:"score,omitempty"`
+	DurationMs    int    `json:"duration_ms,omitempty"`
+	Transcription string `json:"transcription,omitempty"`
+	// запись произношения сохраняет только проверка произношения: ссылке из клиента
+	// верить нельзя, она попадает в очередь учителя
 }
 
 type IdStruct struct {
@@ -52,15 +68,19 @@ type WordData struct {
 }
 
 type Exercise struct {
-	ID             int      `json:"id"`
-	ExerciseType   string   `json:"exercise_type"`
-	Words          []string `json:"words"`          // Для фразовых упражнений содержит sentence
-	Translations   []string `json:"translations"`   // Для фразовых упражнений содержит translate
-	Transcriptions []string `json:"transcriptions"` // Для фразовых упражнений содержит transcription
-	Audio          []string `json:"audio"`
-	Chain          []string `json:"chain,omitempty"`
-	ModuleId       int      `json:"module_id"`
-	Status         string   `json:"status"`
+	ID             int        `json:"id"`
+	ExerciseType   string     `json:"exercise_type"`
+	Words          []string   `json:"words"`          // Для фразовых упражнений содержит sentence
+	Translations   []string   `json:"translations"`   // Для фразовых упражнений содержит translate
+	Transcriptions []string   `json:"transcriptions"` // Для фразовых упражнений содержит transcription
+	Audio          []string   `json:"audio"`
+	Phonemes       [][]string `json:"phonemes,omitempty"` // фонемы каждой транскрипции
+	Chain          []string   `json:"chain,omitempty"`
+	ModuleId       int        `json:"module_id"`
+	Status         string     `json:"status"`
+	ReviewID       *int       `json:"review_id,omitempty"`
+	Tips           []TipData  `json:"tips,omitempty"`
+	Examples       []Example  `json:"examples,omitempty"` // предложения со словами упражнения
 }
 type ExerciseList struct {
 	Exercises []Exercise `json:"exercises"`
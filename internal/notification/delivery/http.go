@@ -0,0 +1,112 @@
+package delivery
+
+import (
+	"errors"
+	"github.com/TeaStealers-backend-sem4/internal/notification"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	"github.com/TeaStealers-backend-sem4/pkg/middleware"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/gorilla/mux"
+	"github.com/satori/uuid"
+	"net/http"
+	"strconv"
+)
+
+const (
+	defaultNotificationsLimit = 20
+	maxNotificationsLimit     = 100
+)
+
+type NotificationHandler struct {
+	uc     notification.NotificationUsecase
+	logger logger.Logger
+}
+
+func NewNotificationHandler(uc notification.NotificationUsecase, logr logger.Logger) *NotificationHandler {
+	return &NotificationHandler{uc: uc, logger: logr}
+}
+
+func (h *NotificationHandler) GetNotificationsHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	limit := defaultNotificationsLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			utils.WriteError(w, http.StatusBadRequest, "limit must be positive int")
+			return
+		}
+		limit = min(parsed, maxNotificationsLimit)
+	}
+	unreadOnly := r.URL.Query().Get("unread") == "true"
+
+	list, err := h.uc.GetNotifications(r.Context(), UUID, unreadOnly, limit)
+	if err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetNotificationsHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "error get notifications")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusOK, list); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetNotificationsHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "GetNotificationsHandler")
+}
+
+func (h *NotificationHandler) MarkReadHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	notificationID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || notificationID <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "invalid notification ID format")
+		return
+	}
+
+	if err := h.uc.MarkRead(r.Context(), UUID, notificationID); err != nil {
+		if errors.Is(err, notification.ErrNotFound) {
+			h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "MarkReadHandler", err, http.StatusNotFound)
+			utils.WriteError(w, http.StatusNotFound, "notification not found")
+			return
+		}
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "MarkReadHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "error mark notification read")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "MarkReadHandler")
+}
+
+func (h *NotificationHandler) MarkAllReadHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	if err := h.uc.MarkAllRead(r.Context(), UUID); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "MarkAllReadHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "error mark notifications read")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "MarkAllReadHandler")
+}
@@ -0,0 +1,23 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/satori/uuid"
+)
+
+var ErrNotFound = errors.New("not found")
+
+// Notifier сохраняет уведомление в транзакции события, которое его вызвало.
+type Notifier interface {
+	Notify(ctx context.Context, tx models.Transaction, n *models.Notification) error
+}
+
+type NotificationUsecase interface {
+	Notifier
+
+	GetNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit int) (*models.NotificationList, error)
+	MarkRead(ctx context.Context, userID uuid.UUID, notificationID int) error
+	MarkAllRead(ctx context.Context, userID uuid.UUID) error
+}
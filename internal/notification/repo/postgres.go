@@ -0,0 +1,104 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+)
+
+type NotificationRepo struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+func NewRepository(db *sql.DB, logger logger.Logger) *NotificationRepo {
+	return &NotificationRepo{db: db, logger: logger}
+}
+
+func (r *NotificationRepo) InsertNotification(ctx context.Context, tx models.Transaction, n *models.Notification) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	payload := []byte(n.Payload)
+	if len(payload) == 0 {
+		payload = []byte("{}")
+	}
+
+	err := tx.QueryRowContext(ctx, InsertNotificationSql, n.UserID, n.Type, n.Title, n.Body, payload).Scan(&n.ID, &n.CreatedAt)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "InsertNotification", err)
+		return fmt.Errorf("failed to insert notification: %w", err)
+	}
+	return nil
+}
+
+func (r *NotificationRepo) GetNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit int) ([]models.Notification, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, SelectNotificationsSql, userID, unreadOnly, limit)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetNotifications", err)
+		return nil, fmt.Errorf("failed to query notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := make([]models.Notification, 0)
+	for rows.Next() {
+		var n models.Notification
+		var payload []byte
+		if err := rows.Scan(&n.ID, &n.Type, &n.Title, &n.Body, &payload, &n.ReadAt, &n.CreatedAt); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetNotifications", err)
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		n.UserID = userID
+		n.Payload = payload
+		notifications = append(notifications, n)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetNotifications", err)
+		return nil, fmt.Errorf("error after iterating notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+func (r *NotificationRepo) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, CountUnreadNotificationsSql, userID).Scan(&count); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "CountUnread", err)
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return count, nil
+}
+
+// MarkRead возвращает false, если у пользователя нет такого уведомления.
+func (r *NotificationRepo) MarkRead(ctx context.Context, userID uuid.UUID, notificationID int) (bool, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	res, err := r.db.ExecContext(ctx, MarkNotificationReadSql, notificationID, userID)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "MarkRead", err)
+		return false, fmt.Errorf("failed to mark notification read: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to mark notification read: %w", err)
+	}
+	return affected > 0, nil
+}
+
+func (r *NotificationRepo) MarkAllRead(ctx context.Context, userID uuid.UUID) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	if _, err := r.db.ExecContext(ctx, MarkAllNotificationsReadSql, userID); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "MarkAllRead", err)
+		return fmt.Errorf("failed to mark notifications read: %w", err)
+	}
+	return nil
+}
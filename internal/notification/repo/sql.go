@@ -0,0 +1,31 @@
+package repo
+
+const (
+	InsertNotificationSql = `
+        INSERT INTO notifications (user_id, type, title, body, payload)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, created_at
+    `
+
+	SelectNotificationsSql = `
+        SELECT id, type, title, body, payload, read_at, created_at
+        FROM notifications
+        WHERE user_id = $1 AND (NOT $2 OR read_at IS NULL)
+        ORDER BY created_at DESC, id DESC
+        LIMIT $3
+    `
+
+	CountUnreadNotificationsSql = `
+        SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL
+    `
+
+	MarkNotificationReadSql = `
+        UPDATE notifications SET read_at = COALESCE(read_at, CURRENT_TIMESTAMP)
+        WHERE id = $1 AND user_id = $2
+    `
+
+	MarkAllNotificationsReadSql = `
+        UPDATE notifications SET read_at = CURRENT_TIMESTAMP
+        WHERE user_id = $1 AND read_at IS NULL
+    `
+)
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/internal/notification"
+	"github.com/TeaStealers-backend-sem4/internal/notification/repo"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+)
+
+type NotificationUsecase struct {
+	repo   *repo.NotificationRepo
+	logger logger.Logger
+}
+
+func NewNotificationUsecase(repo *repo.NotificationRepo, logger logger.Logger) *NotificationUsecase {
+	return &NotificationUsecase{repo: repo, logger: logger}
+}
+
+func (uc *NotificationUsecase) Notify(ctx context.Context, tx models.Transaction, n *models.Notification) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	if err := uc.repo.InsertNotification(ctx, tx, n); err != nil {
+		return err
+	}
+	uc.logger.LogInfo(requestId, logger.UsecaseLayer, "Notify", fmt.Sprintf("notification %d (%s) created", n.ID, n.Type))
+	return nil
+}
+
+func (uc *NotificationUsecase) GetNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit int) (*models.NotificationList, error) {
+	notifications, err := uc.repo.GetNotifications(ctx, userID, unreadOnly, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+	unread, err := uc.repo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+	return &models.NotificationList{Unread: unread, Notifications: notifications}, nil
+}
+
+func (uc *NotificationUsecase) MarkRead(ctx context.Context, userID uuid.UUID, notificationID int) error {
+	marked, err := uc.repo.MarkRead(ctx, userID, notificationID)
+	if err != nil {
+		return err
+	}
+	if !marked {
+		return fmt.Errorf("%w: notification %d", notification.ErrNotFound, notificationID)
+	}
+	return nil
+}
+
+func (uc *NotificationUsecase) MarkAllRead(ctx context.Context, userID uuid.UUID) error {
+	return uc.repo.MarkAllRead(ctx, userID)
+}
@@ -0,0 +1,140 @@
+package delivery
+
+import (
+	"errors"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/internal/word"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+)
+
+// writeVariantError отвечает на ошибку usecase вариантов произношения подходящим статусом.
+func (h *WordHandler) writeVariantError(w http.ResponseWriter, requestId, method string, err error, message string) {
+	switch {
+	case errors.Is(err, word.ErrNotFound):
+		utils.WriteError(w, http.StatusNotFound, "exercise not found")
+	case errors.Is(err, word.ErrInvalidData):
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, method, err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, message)
+	}
+}
+
+// readVariantForm читает вариант произношения из multipart-формы: transcription и
+// необязательный файл audio. Акцент берётся из пути.
+func (h *WordHandler) readVariantForm(r *http.Request) (*models.AccentVariant, error) {
+	if err := r.ParseMultipartForm(5 << 20); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse form", errBadForm)
+	}
+
+	audio, err := h.uploadFormFile(r, "audio", []string{".wav", ".mp3"})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AccentVariant{
+		Accent:        mux.Vars(r)["accent"],
+		Transcription: r.FormValue("transcription"),
+		Audio:         audio,
+	}, nil
+}
+
+// readWordPosition читает id упражнения и позицию слова в нём из пути.
+func readWordPosition(r *http.Request) (int, int, error) {
+	exerciseID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		return 0, 0, err
+	}
+	position, err := strconv.Atoi(mux.Vars(r)["index"])
+	if err != nil {
+		return 0, 0, err
+	}
+	return exerciseID, position, nil
+}
+
+// SetWordVariantHandler сохраняет вариант произношения слова с позиции index упражнения.
+// Без файла audio остаётся прежняя озвучка варианта.
+func (h *WordHandler) SetWordVariantHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	exerciseID, position, err := readWordPosition(r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect exercise id or word index")
+		return
+	}
+
+	variant, err := h.readVariantForm(r)
+	if err != nil {
+		h.writeFormError(w, requestId, "SetWordVariantHandler", err)
+		return
+	}
+
+	if err := h.ucWord.SetWordVariant(r.Context(), exerciseID, position, variant); err != nil {
+		h.writeVariantError(w, requestId, "SetWordVariantHandler", err, "error save word variant")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "SetWordVariantHandler")
+}
+
+func (h *WordHandler) DeleteWordVariantHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	exerciseID, position, err := readWordPosition(r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect exercise id or word index")
+		return
+	}
+
+	if err := h.ucWord.DeleteWordVariant(r.Context(), exerciseID, position, mux.Vars(r)["accent"]); err != nil {
+		h.writeVariantError(w, requestId, "DeleteWordVariantHandler", err, "error delete word variant")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "DeleteWordVariantHandler")
+}
+
+func (h *WordHandler) SetPhraseVariantHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	exerciseID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect exercise id")
+		return
+	}
+
+	variant, err := h.readVariantForm(r)
+	if err != nil {
+		h.writeFormError(w, requestId, "SetPhraseVariantHandler", err)
+		return
+	}
+
+	if err := h.ucWord.SetPhraseVariant(r.Context(), exerciseID, variant); err != nil {
+		h.writeVariantError(w, requestId, "SetPhraseVariantHandler", err, "error save phrase variant")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "SetPhraseVariantHandler")
+}
+
+func (h *WordHandler) DeletePhraseVariantHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	exerciseID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect exercise id")
+		return
+	}
+
+	if err := h.ucWord.DeletePhraseVariant(r.Context(), exerciseID, mux.Vars(r)["accent"]); err != nil {
+		h.writeVariantError(w, requestId, "DeletePhraseVariantHandler", err, "error delete phrase variant")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "DeletePhraseVariantHandler")
+}
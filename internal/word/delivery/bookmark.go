@@ -0,0 +1,187 @@
+package delivery
+
+import (
+	"errors"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/internal/word"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	"github.com/TeaStealers-backend-sem4/pkg/middleware"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/gorilla/mux"
+	"github.com/satori/uuid"
+	"net/http"
+	"strconv"
+)
+
+// writeBookmarkError отвечает на ошибку usecase закладок подходящим статусом.
+func (h *WordHandler) writeBookmarkError(w http.ResponseWriter, requestId, method string, err error, message string) {
+	switch {
+	case errors.Is(err, word.ErrNotFound):
+		utils.WriteError(w, http.StatusNotFound, "not found")
+	case errors.Is(err, word.ErrInvalidData):
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, method, err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, message)
+	}
+}
+
+// parseBookmarkFilter читает из запроса фильтры type и module_id.
+func parseBookmarkFilter(r *http.Request) (*models.BookmarkFilter, bool) {
+	filter := &models.BookmarkFilter{Type: r.URL.Query().Get("type")}
+	if value := r.URL.Query().Get("module_id"); value != "" {
+		moduleID, err := strconv.Atoi(value)
+		if err != nil || moduleID <= 0 {
+			return nil, false
+		}
+		filter.ModuleID = &moduleID
+	}
+	return filter, true
+}
+
+func (h *WordHandler) GetBookmarksHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	filter, ok := parseBookmarkFilter(r)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "module_id must be positive int")
+		return
+	}
+
+	bookmarks, err := h.ucWord.GetBookmarks(r.Context(), UUID, filter)
+	if err != nil {
+		h.writeBookmarkError(w, requestId, "GetBookmarksHandler", err, "error get bookmarks")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusOK, bookmarks); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetBookmarksHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "GetBookmarksHandler")
+}
+
+func (h *WordHandler) AddBookmarkHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	data := models.BookmarkCreate{}
+	if err := utils.ReadRequestData(r, &data); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "AddBookmarkHandler", err, http.StatusBadRequest)
+		utils.WriteError(w, http.StatusBadRequest, "incorrect data format")
+		return
+	}
+
+	bookmark, err := h.ucWord.AddBookmark(r.Context(), UUID, &data)
+	if err != nil {
+		h.writeBookmarkError(w, requestId, "AddBookmarkHandler", err, "error save bookmark")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusCreated, bookmark); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "AddBookmarkHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "AddBookmarkHandler")
+}
+
+func (h *WordHandler) UpdateBookmarkNoteHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	bookmarkID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || bookmarkID <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "invalid bookmark ID format")
+		return
+	}
+
+	data := models.BookmarkNote{}
+	if err := utils.ReadRequestData(r, &data); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "UpdateBookmarkNoteHandler", err, http.StatusBadRequest)
+		utils.WriteError(w, http.StatusBadRequest, "incorrect data format")
+		return
+	}
+
+	if err := h.ucWord.UpdateBookmarkNote(r.Context(), UUID, bookmarkID, &data); err != nil {
+		h.writeBookmarkError(w, requestId, "UpdateBookmarkNoteHandler", err, "error update bookmark")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "UpdateBookmarkNoteHandler")
+}
+
+func (h *WordHandler) DeleteBookmarkHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	bookmarkID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || bookmarkID <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "invalid bookmark ID format")
+		return
+	}
+
+	if err := h.ucWord.DeleteBookmark(r.Context(), UUID, bookmarkID); err != nil {
+		h.writeBookmarkError(w, requestId, "DeleteBookmarkHandler", err, "error delete bookmark")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "DeleteBookmarkHandler")
+}
+
+// GetBookmarkExercisesHandler отдаёт упражнения из закладок для тренировки.
+func (h *WordHandler) GetBookmarkExercisesHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	filter, ok := parseBookmarkFilter(r)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "module_id must be positive int")
+		return
+	}
+
+	exercises, err := h.ucWord.GetBookmarkExercises(r.Context(), UUID, filter, utils.ParseAcceptLanguage(r.Header.Get("Accept-Language")))
+	if err != nil {
+		h.writeBookmarkError(w, requestId, "GetBookmarkExercisesHandler", err, "error get bookmarked exercises")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusOK, exercises); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetBookmarkExercisesHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "GetBookmarkExercisesHandler")
+}
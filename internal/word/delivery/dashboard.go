@@ -0,0 +1,34 @@
+package delivery
+
+import (
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	"github.com/TeaStealers-backend-sem4/pkg/middleware"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+	"net/http"
+)
+
+func (h *WordHandler) GetProgressDashboardHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	dashboard, err := h.ucWord.GetProgressDashboard(r.Context(), UUID)
+	if err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetProgressDashboardHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "error get progress")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusOK, dashboard); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetProgressDashboardHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "GetProgressDashboardHandler")
+}
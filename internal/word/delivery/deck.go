@@ -0,0 +1,309 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/internal/word"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	"github.com/TeaStealers-backend-sem4/pkg/middleware"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/gorilla/mux"
+	"github.com/satori/uuid"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// writeDeckError отвечает на ошибку usecase личных наборов подходящим статусом.
+func (h *WordHandler) writeDeckError(w http.ResponseWriter, requestId, method string, err error, message string) {
+	switch {
+	case errors.Is(err, word.ErrNotFound):
+		utils.WriteError(w, http.StatusNotFound, "deck not found")
+	case errors.Is(err, word.ErrInvalidData):
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, method, err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, message)
+	}
+}
+
+func (h *WordHandler) GetDecksHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	decks, err := h.ucWord.GetDecks(r.Context(), UUID)
+	if err != nil {
+		h.writeDeckError(w, requestId, "GetDecksHandler", err, "error get decks")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusOK, decks); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetDecksHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "GetDecksHandler")
+}
+
+func (h *WordHandler) CreateDeckHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	data := models.DeckCreate{}
+	if err := utils.ReadRequestData(r, &data); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "CreateDeckHandler", err, http.StatusBadRequest)
+		utils.WriteError(w, http.StatusBadRequest, "incorrect data format")
+		return
+	}
+
+	deck, err := h.ucWord.CreateDeck(r.Context(), UUID, &data)
+	if err != nil {
+		h.writeDeckError(w, requestId, "CreateDeckHandler", err, "error create deck")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusCreated, deck); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "CreateDeckHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "CreateDeckHandler")
+}
+
+func (h *WordHandler) GetDeckHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	deckID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || deckID <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "invalid deck ID format")
+		return
+	}
+
+	deck, err := h.ucWord.GetDeck(r.Context(), UUID, deckID, utils.ParseAcceptLanguage(r.Header.Get("Accept-Language")))
+	if err != nil {
+		h.writeDeckError(w, requestId, "GetDeckHandler", err, "error get deck")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusOK, deck); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetDeckHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "GetDeckHandler")
+}
+
+func (h *WordHandler) RenameDeckHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	deckID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || deckID <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "invalid deck ID format")
+		return
+	}
+
+	data := models.DeckCreate{}
+	if err := utils.ReadRequestData(r, &data); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "RenameDeckHandler", err, http.StatusBadRequest)
+		utils.WriteError(w, http.StatusBadRequest, "incorrect data format")
+		return
+	}
+
+	if err := h.ucWord.RenameDeck(r.Context(), UUID, deckID, &data); err != nil {
+		h.writeDeckError(w, requestId, "RenameDeckHandler", err, "error rename deck")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "RenameDeckHandler")
+}
+
+func (h *WordHandler) DeleteDeckHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	deckID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || deckID <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "invalid deck ID format")
+		return
+	}
+
+	if err := h.ucWord.DeleteDeck(r.Context(), UUID, deckID); err != nil {
+		h.writeDeckError(w, requestId, "DeleteDeckHandler", err, "error delete deck")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "DeleteDeckHandler")
+}
+
+// AddDeckWordHandler принимает multipart-форму: word, translation, transcription и
+// необязательные audio (файл) или generate_audio=true для синтезированной озвучки,
+// exercises - виды упражнений списком, например [pronounce, guessWord].
+func (h *WordHandler) AddDeckWordHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	deckID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || deckID <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "invalid deck ID format")
+		return
+	}
+
+	if err := r.ParseMultipartForm(5 << 20); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "max size file 5 mb")
+		return
+	}
+
+	data := models.DeckWordCreate{
+		DeckID:        deckID,
+		Word:          r.FormValue("word"),
+		Translation:   r.FormValue("translation"),
+		Transcription: r.FormValue("transcription"),
+	}
+	if value := r.FormValue("generate_audio"); value != "" {
+		if data.GenerateAudio, err = strconv.ParseBool(value); err != nil {
+			utils.WriteError(w, http.StatusBadRequest, "generate_audio must be bool")
+			return
+		}
+	}
+	if value := r.FormValue("exercises"); value != "" {
+		data.Exercises = utils.ParseStringArray(value)
+	}
+
+	audioFile, audioHead, err := r.FormFile("audio")
+	switch {
+	case err == nil:
+		defer audioFile.Close()
+		allowedExtensions := []string{".wav", ".mp3"}
+		fileType := strings.ToLower(filepath.Ext(audioHead.Filename))
+		if !slices.Contains(allowedExtensions, fileType) {
+			utils.WriteError(w, http.StatusBadRequest, "wav and mp3 only")
+			return
+		}
+		if data.AudioLink, err = h.minClient.UploadFile(audioFile, audioHead.Filename); err != nil {
+			h.logger.LogError(requestId, logger.DeliveryLayer, "AddDeckWordHandler", err)
+			utils.WriteError(w, http.StatusInternalServerError, "failed to upload file")
+			return
+		}
+	case !errors.Is(err, http.ErrMissingFile):
+		utils.WriteError(w, http.StatusBadRequest, "bad data request")
+		return
+	case data.GenerateAudio:
+		if h.cfg.MlServer.SpeechEndpoint == "" {
+			utils.WriteError(w, http.StatusBadRequest, "audio generation is not available")
+			return
+		}
+		if strings.TrimSpace(data.Word) == "" {
+			utils.WriteError(w, http.StatusBadRequest, "word is required")
+			return
+		}
+		if data.AudioLink, err = h.generateWordAudio(strings.TrimSpace(data.Word)); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				h.logger.LogError(requestId, logger.DeliveryLayer, "AddDeckWordHandler", errors.New("ML service timeout"))
+				utils.WriteError(w, http.StatusGatewayTimeout, "ML service timeout")
+				return
+			}
+			h.logger.LogError(requestId, logger.DeliveryLayer, "AddDeckWordHandler", err)
+			utils.WriteError(w, http.StatusInternalServerError, "failed to generate audio")
+			return
+		}
+	}
+
+	exerciseIDs, err := h.ucWord.AddDeckWord(r.Context(), UUID, &data)
+	if err != nil {
+		h.writeDeckError(w, requestId, "AddDeckWordHandler", err, "error add deck word")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusCreated, models.DeckWordAdded{Id: &exerciseIDs[0], ExerciseIDs: exerciseIDs}); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "AddDeckWordHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "AddDeckWordHandler")
+}
+
+// generateWordAudio озвучивает слово через ML-сервис и сохраняет результат в файловое хранилище.
+func (h *WordHandler) generateWordAudio(text string) (string, error) {
+	response, err := utils.SynthesizeMLService(h.cfg.MlServer.SpeechEndpoint, text, h.cfg.MlServer.Timeout)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("speech service returned status %d", response.StatusCode)
+	}
+
+	return h.minClient.UploadFile(response.Body, uuid.NewV4().String()+".wav")
+}
+
+func (h *WordHandler) DeleteDeckWordHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	deckID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || deckID <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "invalid deck ID format")
+		return
+	}
+	exerciseID, err := strconv.Atoi(mux.Vars(r)["exercise_id"])
+	if err != nil || exerciseID <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "invalid exercise ID format")
+		return
+	}
+
+	if err := h.ucWord.DeleteDeckWord(r.Context(), UUID, deckID, exerciseID); err != nil {
+		h.writeDeckError(w, requestId, "DeleteDeckWordHandler", err, "error delete deck word")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "DeleteDeckWordHandler")
+}
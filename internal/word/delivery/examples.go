@@ -0,0 +1,159 @@
+package delivery
+
+import (
+	"errors"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/internal/word"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+)
+
+const (
+	defaultExamplesLimit = 20
+	maxExamplesLimit     = 100
+)
+
+// writeExampleError отвечает на ошибку usecase примеров подходящим статусом.
+func (h *WordHandler) writeExampleError(w http.ResponseWriter, requestId, method string, err error, message string) {
+	switch {
+	case errors.Is(err, word.ErrNotFound):
+		utils.WriteError(w, http.StatusNotFound, "not found")
+	case errors.Is(err, word.ErrInvalidData):
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, method, err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, message)
+	}
+}
+
+// readExampleForm читает пример из multipart-формы: sentence, translation и необязательный
+// файл audio.
+func (h *WordHandler) readExampleForm(r *http.Request) (*models.ExampleData, error) {
+	if err := r.ParseMultipartForm(5 << 20); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse form", errBadForm)
+	}
+
+	audio, err := h.uploadFormFile(r, "audio", []string{".wav", ".mp3"})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ExampleData{
+		Sentence:    r.FormValue("sentence"),
+		Translation: r.FormValue("translation"),
+		Audio:       audio,
+	}, nil
+}
+
+// GetLexiconExamplesHandler - GET /lexicon/{id}/examples?limit=&offset=: фразовые упражнения
+// и отдельные примеры, где встречается слово статьи словаря.
+func (h *WordHandler) GetLexiconExamplesHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	entryID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect lexicon entry id")
+		return
+	}
+
+	limit, offset := defaultExamplesLimit, 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			utils.WriteError(w, http.StatusBadRequest, "limit must be positive int")
+			return
+		}
+		limit = min(parsed, maxExamplesLimit)
+	}
+	if value := r.URL.Query().Get("offset"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			utils.WriteError(w, http.StatusBadRequest, "offset must be non-negative int")
+			return
+		}
+		offset = parsed
+	}
+
+	examples, err := h.ucWord.GetLexiconExamples(r.Context(), entryID, limit, offset)
+	if err != nil {
+		h.writeExampleError(w, requestId, "GetLexiconExamplesHandler", err, "error get examples")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusOK, examples); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetLexiconExamplesHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "GetLexiconExamplesHandler")
+}
+
+func (h *WordHandler) CreateExampleHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+
+	example, err := h.readExampleForm(r)
+	if err != nil {
+		h.writeFormError(w, requestId, "CreateExampleHandler", err)
+		return
+	}
+
+	exampleID, err := h.ucWord.CreateExample(r.Context(), example)
+	if err != nil {
+		h.writeExampleError(w, requestId, "CreateExampleHandler", err, "error create example")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusCreated, models.IdStruct{Id: &exampleID}); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "CreateExampleHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "CreateExampleHandler")
+}
+
+// UpdateExampleHandler заменяет текст примера. Без файла audio озвучка остаётся прежней.
+func (h *WordHandler) UpdateExampleHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	exampleID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect example id")
+		return
+	}
+
+	example, err := h.readExampleForm(r)
+	if err != nil {
+		h.writeFormError(w, requestId, "UpdateExampleHandler", err)
+		return
+	}
+	example.ID = exampleID
+
+	if err := h.ucWord.UpdateExample(r.Context(), example); err != nil {
+		h.writeExampleError(w, requestId, "UpdateExampleHandler", err, "error update example")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "UpdateExampleHandler")
+}
+
+func (h *WordHandler) DeleteExampleHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	exampleID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect example id")
+		return
+	}
+
+	if err := h.ucWord.DeleteExample(r.Context(), exampleID); err != nil {
+		h.writeExampleError(w, requestId, "DeleteExampleHandler", err, "error delete example")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "DeleteExampleHandler")
+}
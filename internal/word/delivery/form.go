@@ -0,0 +1,43 @@
+package delivery
+
+import (
+	"errors"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// errBadForm - ошибка в полях multipart-формы, отдаётся клиенту как 400.
+var errBadForm = errors.New("bad form")
+
+// uploadFormFile загружает необязательный файл формы и возвращает его идентификатор.
+// Если файла в форме нет, возвращается пустая строка.
+func (h *WordHandler) uploadFormFile(r *http.Request, field string, extensions []string) (string, error) {
+	file, head, err := r.FormFile(field)
+	if errors.Is(err, http.ErrMissingFile) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("%w: %s: %s", errBadForm, field, err.Error())
+	}
+	defer file.Close()
+
+	if extensions != nil && !slices.Contains(extensions, strings.ToLower(filepath.Ext(head.Filename))) {
+		return "", fmt.Errorf("%w: %s must be one of %s", errBadForm, field, strings.Join(extensions, ", "))
+	}
+	return h.minClient.UploadFile(file, head.Filename)
+}
+
+// writeFormError отвечает на ошибку чтения формы: 400 для неверных полей, 500 для хранилища файлов.
+func (h *WordHandler) writeFormError(w http.ResponseWriter, requestId, method string, err error) {
+	h.logger.LogError(requestId, logger.DeliveryLayer, method, err)
+	if errors.Is(err, errBadForm) {
+		utils.WriteError(w, http.StatusBadRequest, strings.TrimPrefix(err.Error(), errBadForm.Error()+": "))
+		return
+	}
+	utils.WriteError(w, http.StatusInternalServerError, "failed to upload file")
+}
@@ -84,9 +84,13 @@ func (h *WordHandler) CreateWordExerciseHandler(w http.ResponseWriter, r *http.R
 		utils.WriteError(w, http.StatusBadRequest, "bad data request")
 		return
 	}
-
-	translationsList := utils.ParseStringArray(translations)
-	gotId := models.IdStruct{}
+	translationsList, localizedTranslations, err := readWordTranslations(translations)
+	if err != nil {
+		h.logger.LogError(requestId, logger.DeliveryLayer, "CreateWordExercise", err)
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	gotId := models.CreatedExercise{}
 
 	switch exercise {
 	case "pronounce":
@@ -112,14 +116,27 @@ func (h *WordHandler) CreateWordExerciseHandler(w http.ResponseWriter, r *http.R
 		}
 
 		wordData := models.CreateWordData{Exercise: exercise, ModuleId: &moduleId, Word: wordsList[0], Transcription: transcriptionsList[0], Translation: translationsList[0], AudioLink: audioLink}
+		for locale, list := range localizedTranslations {
+			if wordData.LocalizedTranslations == nil {
+				wordData.LocalizedTranslations = make(map[string]string)
+			}
+			if len(list) > 0 {
+				wordData.LocalizedTranslations[locale] = list[0]
+			}
+		}
 
 		id, err := h.ucWord.CreateWordExercise(r.Context(), &wordData)
 		if err != nil {
+			if errors.Is(err, word.ErrInvalidData) {
+				utils.WriteError(w, http.StatusBadRequest, err.Error())
+				return
+			}
 			h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "CreateWordExercise", err, http.StatusInternalServerError)
 			utils.WriteError(w, http.StatusInternalServerError, "error create word")
 			return
 		}
 		gotId.Id = &id
+		gotId.Warnings = h.ucWord.CheckTranscriptions(r.Context(), wordsList[:1], transcriptionsList[:1])
 	case "pronounceFiew":
 		fallthrough
 	case "guessWord":
@@ -158,15 +175,21 @@ func (h *WordHandler) CreateWordExerciseHandler(w http.ResponseWriter, r *http.R
 		}
 
 		wordData := models.CreateWordDataList{Exercise: exercise, ModuleId: &moduleId, Word: wordsList,
-			Transcription: transcriptionsList, Translation: translationsList, AudioLink: audioLinks}
+			Transcription: transcriptionsList, Translation: translationsList, AudioLink: audioLinks,
+			LocalizedTranslations: localizedTranslations}
 
 		id, err := h.ucWord.CreateWordExerciseList(r.Context(), &wordData)
 		if err != nil {
+			if errors.Is(err, word.ErrInvalidData) {
+				utils.WriteError(w, http.StatusBadRequest, err.Error())
+				return
+			}
 			h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "CreateWordExercise", err, http.StatusInternalServerError)
 			utils.WriteError(w, http.StatusInternalServerError, "error create word")
 			return
 		}
 		gotId.Id = &id
+		gotId.Warnings = h.ucWord.CheckTranscriptions(r.Context(), wordsList, transcriptionsList)
 	default:
 		utils.WriteError(w, http.StatusBadRequest, "no such exercise")
 		return
@@ -231,6 +254,12 @@ func (h *WordHandler) CreatePhraseExerciseHandler(w http.ResponseWriter, r *http
 		utils.WriteError(w, http.StatusBadRequest, "bad data request")
 		return
 	}
+	translate, localizedTranslations, err := readPhraseTranslation(translate)
+	if err != nil {
+		h.logger.LogError(requestId, logger.DeliveryLayer, "CreatePhraseExerciseHandler", err)
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	chain := r.FormValue("chain")
 	if chain == "" {
@@ -260,19 +289,25 @@ func (h *WordHandler) CreatePhraseExerciseHandler(w http.ResponseWriter, r *http
 		utils.WriteError(w, http.StatusInternalServerError, "failed to upload file")
 		return
 	}
-	gotId := models.IdStruct{}
+	gotId := models.CreatedExercise{}
 
 	phraseData := models.CreatePhraseData{Exercise: exercise, Sentence: sentence, Transcription: transcription,
 		ModuleId:  &moduleId,
-		AudioLink: audioLink, Translate: translate, Chain: chainList}
+		AudioLink: audioLink, Translate: translate, Chain: chainList,
+		LocalizedTranslations: localizedTranslations}
 
 	id, err := h.ucWord.CreatePhraseExercise(r.Context(), &phraseData)
 	if err != nil {
+		if errors.Is(err, word.ErrInvalidData) {
+			utils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "CreatePhraseExerciseHandler", err, http.StatusInternalServerError)
 		utils.WriteError(w, http.StatusInternalServerError, "error create word")
 		return
 	}
 	gotId.Id = &id
+	gotId.Warnings = h.ucWord.CheckTranscriptions(r.Context(), []string{sentence}, []string{transcription})
 
 	if err := utils.WriteResponse(w, http.StatusCreated, gotId); err != nil {
 		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "CreatePhraseExerciseHandler", err, http.StatusInternalServerError)
@@ -303,6 +338,11 @@ func (h *WordHandler) UpdateProgressHandler(w http.ResponseWriter, r *http.Reque
 
 	_, err := h.ucWord.CreateUpdateProgress(r.Context(), &progressData)
 	if err != nil {
+		if errors.Is(err, word.ErrNotFound) {
+			h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "UpdateProgressHandler", err, http.StatusNotFound)
+			utils.WriteError(w, http.StatusNotFound, "exercise not found")
+			return
+		}
 		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "UpdateProgressHandler", err, http.StatusInternalServerError)
 		utils.WriteError(w, http.StatusInternalServerError, "error create word")
 		return
@@ -321,8 +361,13 @@ func (h *WordHandler) UpdateProgressHandler(w http.ResponseWriter, r *http.Reque
 func (h *WordHandler) WordModulesHandler(w http.ResponseWriter, r *http.Request) {
 	requestId := utils.GetRequestIDFromCtx(r.Context())
 
-	gotModules, err := h.ucWord.GetWordModules(r.Context())
+	// topic оставляет только модули этой темы
+	gotModules, err := h.ucWord.GetWordModules(r.Context(), r.URL.Query().Get("topic"))
 	if err != nil {
+		if errors.Is(err, word.ErrInvalidData) {
+			utils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "WordModulesHandler", err, http.StatusInternalServerError)
 		utils.WriteError(w, http.StatusInternalServerError, "error create word")
 		return
@@ -341,8 +386,12 @@ func (h *WordHandler) WordModulesHandler(w http.ResponseWriter, r *http.Request)
 func (h *WordHandler) PhraseModulesHandler(w http.ResponseWriter, r *http.Request) {
 	requestId := utils.GetRequestIDFromCtx(r.Context())
 
-	gotModules, err := h.ucWord.GetPhraseModules(r.Context())
+	gotModules, err := h.ucWord.GetPhraseModules(r.Context(), r.URL.Query().Get("topic"))
 	if err != nil {
+		if errors.Is(err, word.ErrInvalidData) {
+			utils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "WordModulesHandler", err, http.StatusInternalServerError)
 		utils.WriteError(w, http.StatusInternalServerError, "error create word")
 		return
@@ -375,20 +424,37 @@ func (h *WordHandler) GetWordModuleExercisesHandler(w http.ResponseWriter, r *ht
 		utils.WriteError(w, http.StatusBadRequest, "module ID must be positive")
 		return
 	}
-	id := r.Context().Value(middleware.CookieName)
-	userId, ok := id.(string)
-	if !ok {
-		userId = ""
+	// без авторизации доступны только общие модули, личный набор видит лишь владелец
+	userId := ""
+	if UUID, ok := r.Context().Value(middleware.CookieName).(uuid.UUID); ok {
+		userId = UUID.String()
 	}
 
-	gotModules, err := h.ucWord.GetWordModuleExercises(r.Context(), userId, moduleID)
+	languages := utils.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	// examples=true добавляет к словам предложения, где они встречаются
+	withExamples := r.URL.Query().Get("examples") == "true"
+	// topic оставляет только упражнения этой темы
+	topic := r.URL.Query().Get("topic")
+	gotModules, err := h.ucWord.GetWordModuleExercises(r.Context(), userId, moduleID, languages, withExamples, topic)
 
 	if err != nil {
+		if errors.Is(err, word.ErrInvalidData) {
+			utils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetWordModuleExercisesHandler", err, http.StatusInternalServerError)
 		utils.WriteError(w, http.StatusInternalServerError, "error create word")
 		return
 	}
 
+	var tips []*models.TipData
+	for i := range gotModules.Exercises {
+		for j := range gotModules.Exercises[i].Tips {
+			tips = append(tips, &gotModules.Exercises[i].Tips[j])
+		}
+	}
+	h.resolveTipLinks(requestId, "GetWordModuleExercisesHandler", tips)
+
 	if err := utils.WriteResponse(w, http.StatusCreated, gotModules); err != nil {
 		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetWordModuleExercisesHandler", err, http.StatusInternalServerError)
 		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
@@ -417,14 +483,18 @@ func (h *WordHandler) GetPhraseModuleExercisesHandler(w http.ResponseWriter, r *
 		return
 	}
 
-	id := r.Context().Value(middleware.CookieName)
-	userId, ok := id.(string)
-	if !ok {
-		userId = ""
+	userId := ""
+	if UUID, ok := r.Context().Value(middleware.CookieName).(uuid.UUID); ok {
+		userId = UUID.String()
 	}
 
-	gotModules, err := h.ucWord.GetPhraseModuleExercises(r.Context(), userId, moduleID)
+	languages := utils.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	gotModules, err := h.ucWord.GetPhraseModuleExercises(r.Context(), userId, moduleID, languages, r.URL.Query().Get("topic"))
 	if err != nil {
+		if errors.Is(err, word.ErrInvalidData) {
+			utils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetPhraseModuleExercisesHandler", err, http.StatusInternalServerError)
 		utils.WriteError(w, http.StatusInternalServerError, "error create word")
 		return
@@ -504,6 +574,10 @@ func (h *WordHandler) UploadTipHandler(w http.ResponseWriter, r *http.Request) {
 
 	if err := h.ucWord.UploadTip(r.Context(), &data); err != nil {
 		h.logger.LogError(requestId, logger.DeliveryLayer, "UploadTip", err)
+		if errors.Is(err, word.ErrInvalidData) {
+			utils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		utils.WriteError(w, http.StatusInternalServerError, "failed to upload tip")
 		return
 	}
@@ -559,7 +633,7 @@ func (h *WordHandler) GetTipHandler(w http.ResponseWriter, r *http.Request) {
 func (h *WordHandler) GetCurrentModuleWordHandler(w http.ResponseWriter, r *http.Request) {
 	requestId := utils.GetRequestIDFromCtx(r.Context())
 	id := r.Context().Value(middleware.CookieName)
-	userId, ok := id.(string)
+	UUID, ok := id.(uuid.UUID)
 	if !ok {
 		mod1 := models.ModuleCreate{ID: 1}
 		if err := utils.WriteResponse(w, http.StatusOK, mod1); err != nil {
@@ -570,7 +644,7 @@ func (h *WordHandler) GetCurrentModuleWordHandler(w http.ResponseWriter, r *http
 		return
 	}
 
-	gotTopic, err := h.ucWord.GetNextWordModule(r.Context(), userId)
+	gotTopic, err := h.ucWord.GetNextWordModule(r.Context(), UUID.String())
 	if err != nil {
 		utils.WriteError(w, http.StatusInternalServerError, "error get topic progress")
 		return
@@ -616,3 +690,44 @@ func (h *WordHandler) GetCurrentModulePhraseHandler(w http.ResponseWriter, r *ht
 	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "GetTopicProgressHandler")
 
 }
+
+func (h *WordHandler) GetExerciseAttemptsHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	exerciseID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || exerciseID <= 0 {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetExerciseAttemptsHandler", errors.New("bad exercise id"), http.StatusBadRequest)
+		utils.WriteError(w, http.StatusBadRequest, "invalid exercise ID format")
+		return
+	}
+
+	exerciseType := r.URL.Query().Get("type")
+	if exerciseType == "" {
+		exerciseType = "word"
+	}
+	if exerciseType != "word" && exerciseType != "phrase" {
+		utils.WriteError(w, http.StatusBadRequest, "type must be word or phrase")
+		return
+	}
+
+	attempts, err := h.ucWord.GetExerciseAttempts(r.Context(), UUID, exerciseID, exerciseType)
+	if err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetExerciseAttemptsHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "error get attempts")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusOK, attempts); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetExerciseAttemptsHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "GetExerciseAttemptsHandler")
+}
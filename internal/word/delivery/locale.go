@@ -0,0 +1,135 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/internal/word"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	"github.com/TeaStealers-backend-sem4/pkg/middleware"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+	"net/http"
+	"strings"
+)
+
+// isLocaleMap - поле формы передано объектом по языкам, а не прежним значением.
+func isLocaleMap(value string) bool {
+	return strings.HasPrefix(strings.TrimSpace(value), "{")
+}
+
+// isMainLocale - ключ объекта по языкам относится к основному языку.
+func isMainLocale(locale string) bool {
+	return strings.EqualFold(strings.TrimSpace(locale), models.DefaultContentLocale)
+}
+
+// readWordTranslations разбирает переводы слов из формы автора: прежний список на основном
+// языке ([кот, собака]) или объект по языкам ({"ru": ["кот", "собака"], "kk": ["мысық", "ит"]}),
+// в котором перевод на основном языке обязателен.
+func readWordTranslations(value string) ([]string, map[string][]string, error) {
+	if !isLocaleMap(value) {
+		return utils.ParseStringArray(value), nil, nil
+	}
+
+	var byLocale map[string][]string
+	if err := json.Unmarshal([]byte(value), &byLocale); err != nil {
+		return nil, nil, errors.New("translations must be a list or an object of lists by locale")
+	}
+	var main []string
+	localized := make(map[string][]string, len(byLocale))
+	for locale, translations := range byLocale {
+		if isMainLocale(locale) {
+			main = translations
+		} else {
+			localized[locale] = translations
+		}
+	}
+	if len(main) == 0 {
+		return nil, nil, fmt.Errorf("translations must include %q", models.DefaultContentLocale)
+	}
+	return main, localized, nil
+}
+
+// readPhraseTranslation - то же для перевода фразы: строка или {"ru": "...", "uz": "..."}.
+func readPhraseTranslation(value string) (string, map[string]string, error) {
+	if !isLocaleMap(value) {
+		return value, nil, nil
+	}
+
+	var byLocale map[string]string
+	if err := json.Unmarshal([]byte(value), &byLocale); err != nil {
+		return "", nil, errors.New("translate must be a string or an object of strings by locale")
+	}
+	var main string
+	localized := make(map[string]string, len(byLocale))
+	for locale, translation := range byLocale {
+		if isMainLocale(locale) {
+			main = translation
+		} else {
+			localized[locale] = translation
+		}
+	}
+	if strings.TrimSpace(main) == "" {
+		return "", nil, fmt.Errorf("translate must include %q", models.DefaultContentLocale)
+	}
+	return main, localized, nil
+}
+
+func (h *WordHandler) GetContentSettingsHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	UUID, ok := r.Context().Value(middleware.CookieName).(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	settings, err := h.ucWord.GetContentSettings(r.Context(), UUID)
+	if err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetContentSettingsHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "error get content settings")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusOK, settings); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetContentSettingsHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "GetContentSettingsHandler")
+}
+
+func (h *WordHandler) UpdateContentSettingsHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	UUID, ok := r.Context().Value(middleware.CookieName).(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	var data models.ContentSettings
+	if err := utils.ReadRequestData(r, &data); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect data format")
+		return
+	}
+
+	settings, err := h.ucWord.UpdateContentSettings(r.Context(), UUID, &data)
+	if err != nil {
+		if errors.Is(err, word.ErrInvalidData) {
+			utils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "UpdateContentSettingsHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "error update content settings")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusOK, settings); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "UpdateContentSettingsHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "UpdateContentSettingsHandler")
+}
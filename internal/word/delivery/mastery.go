@@ -0,0 +1,43 @@
+package delivery
+
+import (
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	"github.com/TeaStealers-backend-sem4/pkg/middleware"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+	"net/http"
+)
+
+func (h *WordHandler) GetUserPhonemesHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	phonemes, err := h.ucWord.GetUserPhonemes(r.Context(), UUID, utils.ParseAcceptLanguage(r.Header.Get("Accept-Language")))
+	if err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetUserPhonemesHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "error get phonemes")
+		return
+	}
+
+	tips := make([]*models.TipData, 0, len(phonemes.Phonemes))
+	for _, m := range phonemes.Phonemes {
+		if m.Tip != nil {
+			tips = append(tips, m.Tip)
+		}
+	}
+	h.resolveTipLinks(requestId, "GetUserPhonemesHandler", tips)
+
+	if err := utils.WriteResponse(w, http.StatusOK, phonemes); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetUserPhonemesHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "GetUserPhonemesHandler")
+}
@@ -0,0 +1,125 @@
+package delivery
+
+import (
+	"errors"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/internal/word"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	"github.com/TeaStealers-backend-sem4/pkg/middleware"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/gorilla/mux"
+	"github.com/satori/uuid"
+	"net/http"
+	"strconv"
+)
+
+func (h *WordHandler) StartPlacementHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	test, err := h.ucWord.StartPlacement(r.Context(), UUID, utils.ParseAcceptLanguage(r.Header.Get("Accept-Language")))
+	if err != nil {
+		if errors.Is(err, word.ErrNotFound) {
+			utils.WriteError(w, http.StatusNotFound, "no word modules for placement")
+			return
+		}
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "StartPlacementHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "error start placement test")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusCreated, test); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "StartPlacementHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "StartPlacementHandler")
+}
+
+func (h *WordHandler) GetPlacementHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	testID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || testID <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "invalid placement test ID format")
+		return
+	}
+
+	test, err := h.ucWord.GetPlacement(r.Context(), UUID, testID, utils.ParseAcceptLanguage(r.Header.Get("Accept-Language")))
+	if err != nil {
+		if errors.Is(err, word.ErrNotFound) {
+			utils.WriteError(w, http.StatusNotFound, "placement test not found")
+			return
+		}
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetPlacementHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "error get placement test")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusOK, test); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetPlacementHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "GetPlacementHandler")
+}
+
+func (h *WordHandler) AnswerPlacementHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	testID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || testID <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "invalid placement test ID format")
+		return
+	}
+
+	answer := models.PlacementAnswer{}
+	if err := utils.ReadRequestData(r, &answer); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "AnswerPlacementHandler", err, http.StatusBadRequest)
+		utils.WriteError(w, http.StatusBadRequest, "incorrect data format")
+		return
+	}
+	answer.UserID = UUID
+	answer.TestID = testID
+
+	test, err := h.ucWord.AnswerPlacement(r.Context(), &answer, utils.ParseAcceptLanguage(r.Header.Get("Accept-Language")))
+	if err != nil {
+		switch {
+		case errors.Is(err, word.ErrNotFound):
+			utils.WriteError(w, http.StatusNotFound, "placement test not found")
+		case errors.Is(err, word.ErrInvalidData):
+			utils.WriteError(w, http.StatusBadRequest, err.Error())
+		default:
+			h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "AnswerPlacementHandler", err, http.StatusInternalServerError)
+			utils.WriteError(w, http.StatusInternalServerError, "error save placement answer")
+		}
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusOK, test); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "AnswerPlacementHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "AnswerPlacementHandler")
+}
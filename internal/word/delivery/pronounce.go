@@ -0,0 +1,153 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/internal/word"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	"github.com/TeaStealers-backend-sem4/pkg/middleware"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/gorilla/mux"
+	"github.com/satori/uuid"
+	"io"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+func (h *WordHandler) PronounceWordExerciseHandler(w http.ResponseWriter, r *http.Request) {
+	h.pronounceExercise(w, r, "word", h.cfg.MlServer.TranscribeWordEndpoint)
+}
+
+func (h *WordHandler) PronouncePhraseExerciseHandler(w http.ResponseWriter, r *http.Request) {
+	h.pronounceExercise(w, r, "phrase", h.cfg.MlServer.TranscribePhraseEndpoint)
+}
+
+func (h *WordHandler) pronounceExercise(w http.ResponseWriter, r *http.Request, exerciseType string, mlServiceURL string) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	exerciseID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || exerciseID <= 0 {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "PronounceExercise", errors.New("bad exercise id"), http.StatusBadRequest)
+		utils.WriteError(w, http.StatusBadRequest, "invalid exercise ID format")
+		return
+	}
+
+	if err := r.ParseMultipartForm(5 << 20); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "max size file 5 mb")
+		return
+	}
+
+	file, head, err := r.FormFile("audio")
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "bad data request")
+		return
+	}
+	defer file.Close()
+
+	allowedExtensions := []string{".wav", ".mp3"}
+	fileType := strings.ToLower(filepath.Ext(head.Filename))
+	if !slices.Contains(allowedExtensions, fileType) {
+		utils.WriteError(w, http.StatusBadRequest, "wav and mp3 only")
+		return
+	}
+
+	// без word_index оценивается запись всего упражнения
+	var wordIndex *int
+	if value := r.FormValue("word_index"); value != "" {
+		index, err := strconv.Atoi(value)
+		if err != nil {
+			utils.WriteError(w, http.StatusBadRequest, "word_index must be int")
+			return
+		}
+		wordIndex = &index
+	}
+
+	durationMs := 0
+	if value := r.FormValue("duration_ms"); value != "" {
+		if durationMs, err = strconv.Atoi(value); err != nil || durationMs < 0 {
+			utils.WriteError(w, http.StatusBadRequest, "duration_ms must be positive int")
+			return
+		}
+	}
+
+	exercise, err := h.ucWord.GetExercise(r.Context(), UUID, exerciseType, exerciseID)
+	if err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "PronounceExercise", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "error get exercise")
+		return
+	}
+	if exercise == nil {
+		utils.WriteError(w, http.StatusNotFound, "exercise not found")
+		return
+	}
+
+	response, err := utils.TranscribeMLService(mlServiceURL, file, head.Filename, h.cfg.MlServer.Timeout)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			h.logger.LogError(requestId, logger.DeliveryLayer, "PronounceExercise", errors.New("ML service timeout"))
+			utils.WriteError(w, http.StatusGatewayTimeout, "ML service timeout")
+			return
+		}
+		h.logger.LogError(requestId, logger.DeliveryLayer, "PronounceExercise", errors.New("ML service unavailable"))
+		utils.WriteError(w, http.StatusInternalServerError, "ML service unavailable")
+		return
+	}
+
+	mlAns := models.MlAnswer{}
+	if err := utils.ReadResponseData(response, &mlAns); err != nil {
+		h.logger.LogError(requestId, logger.DeliveryLayer, "PronounceExercise", errors.New("fail to read ml response"))
+		utils.WriteError(w, http.StatusInternalServerError, "fail to read ml response")
+		return
+	}
+	if mlAns.MlError != "" {
+		h.logger.LogError(requestId, logger.DeliveryLayer, "PronounceExercise", errors.New(mlAns.MlError))
+		utils.WriteError(w, http.StatusInternalServerError, mlAns.MlError)
+		return
+	}
+
+	// запись сохраняется для проверки учителем; без неё попытка всё равно засчитывается
+	recordingID := ""
+	if _, err := file.Seek(0, io.SeekStart); err == nil {
+		if recordingID, err = h.minClient.UploadFile(file, head.Filename); err != nil {
+			h.logger.LogError(requestId, logger.DeliveryLayer, "PronounceExercise", err)
+		}
+	}
+
+	result, err := h.ucWord.SubmitPronunciation(r.Context(), &models.PronunciationAttempt{
+		UserID:        UUID,
+		ExerciseType:  exerciseType,
+		Exercise:      exercise,
+		WordIndex:     wordIndex,
+		Transcription: mlAns.Transcription,
+		Text:          mlAns.Text,
+		DurationMs:    durationMs,
+		RecordingID:   recordingID,
+	})
+	if errors.Is(err, word.ErrInvalidData) {
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "PronounceExercise", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "error save attempt")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusOK, result); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "PronounceExercise", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "PronounceExercise")
+}
@@ -0,0 +1,64 @@
+package delivery
+
+import (
+	"errors"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/internal/word"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	"github.com/TeaStealers-backend-sem4/pkg/middleware"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+	"net/http"
+	"strconv"
+)
+
+const (
+	defaultNextExercises = 10
+	maxNextExercises     = 50
+)
+
+func (h *WordHandler) GetNextExercisesHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	count := defaultNextExercises
+	if value := r.URL.Query().Get("count"); value != "" {
+		var err error
+		if count, err = strconv.Atoi(value); err != nil || count <= 0 || count > maxNextExercises {
+			utils.WriteError(w, http.StatusBadRequest, "count must be between 1 and 50")
+			return
+		}
+	}
+
+	recommendations, err := h.ucWord.GetNextExercises(r.Context(), UUID, count, r.URL.Query().Get("strategy"), utils.ParseAcceptLanguage(r.Header.Get("Accept-Language")))
+	if err != nil {
+		if errors.Is(err, word.ErrInvalidData) {
+			utils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetNextExercisesHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "error get next exercises")
+		return
+	}
+
+	var tips []*models.TipData
+	for i := range recommendations.Exercises {
+		for j := range recommendations.Exercises[i].Exercise.Tips {
+			tips = append(tips, &recommendations.Exercises[i].Exercise.Tips[j])
+		}
+	}
+	h.resolveTipLinks(requestId, "GetNextExercisesHandler", tips)
+
+	if err := utils.WriteResponse(w, http.StatusOK, recommendations); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetNextExercisesHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "GetNextExercisesHandler")
+}
@@ -0,0 +1,146 @@
+package delivery
+
+import (
+	"errors"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/internal/word"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	"github.com/TeaStealers-backend-sem4/pkg/middleware"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/gorilla/mux"
+	"github.com/satori/uuid"
+	"net/http"
+	"strconv"
+)
+
+func (h *WordHandler) GetReviewQueueHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	queue, err := h.ucWord.GetReviewQueue(r.Context(), UUID, utils.ParseAcceptLanguage(r.Header.Get("Accept-Language")))
+	if err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetReviewQueueHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "error get review queue")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusOK, queue); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetReviewQueueHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "GetReviewQueueHandler")
+}
+
+func (h *WordHandler) GradeReviewHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	reviewID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || reviewID <= 0 {
+		utils.WriteError(w, http.StatusBadRequest, "invalid review ID format")
+		return
+	}
+
+	grade := models.ReviewGrade{}
+	if err := utils.ReadRequestData(r, &grade); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GradeReviewHandler", err, http.StatusBadRequest)
+		utils.WriteError(w, http.StatusBadRequest, "incorrect data format")
+		return
+	}
+	grade.UserID = UUID
+	grade.ReviewID = reviewID
+
+	result, err := h.ucWord.GradeReview(r.Context(), &grade)
+	if err != nil {
+		switch {
+		case errors.Is(err, word.ErrNotFound):
+			utils.WriteError(w, http.StatusNotFound, "review not found")
+		case errors.Is(err, word.ErrInvalidData):
+			utils.WriteError(w, http.StatusBadRequest, err.Error())
+		default:
+			h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GradeReviewHandler", err, http.StatusInternalServerError)
+			utils.WriteError(w, http.StatusInternalServerError, "error grade review")
+		}
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusOK, result); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GradeReviewHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "GradeReviewHandler")
+}
+
+func (h *WordHandler) GetReviewSettingsHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	settings, err := h.ucWord.GetReviewSettings(r.Context(), UUID)
+	if err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetReviewSettingsHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "error get review settings")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusOK, settings); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetReviewSettingsHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "GetReviewSettingsHandler")
+}
+
+func (h *WordHandler) UpdateReviewSettingsHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	id := r.Context().Value(middleware.CookieName)
+	UUID, ok := id.(uuid.UUID)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect id")
+		return
+	}
+
+	settings := models.ReviewSettings{}
+	if err := utils.ReadRequestData(r, &settings); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "UpdateReviewSettingsHandler", err, http.StatusBadRequest)
+		utils.WriteError(w, http.StatusBadRequest, "incorrect data format")
+		return
+	}
+
+	if err := h.ucWord.UpdateReviewSettings(r.Context(), UUID, &settings); err != nil {
+		if errors.Is(err, word.ErrInvalidData) {
+			utils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "UpdateReviewSettingsHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "error save review settings")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusOK, settings); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "UpdateReviewSettingsHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "UpdateReviewSettingsHandler")
+}
@@ -0,0 +1,71 @@
+package delivery
+
+import (
+	"errors"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/internal/word"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	"github.com/TeaStealers-backend-sem4/pkg/middleware"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+	"net/http"
+	"strconv"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 50
+)
+
+// SearchHandler - GET /search?q=&type=&limit=&offset=. Без авторизации личные наборы
+// и их слова не ищутся.
+func (h *WordHandler) SearchHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	values := r.URL.Query()
+
+	query := models.SearchQuery{
+		Text:  values.Get("q"),
+		Limit: defaultSearchLimit,
+	}
+	if UUID, ok := r.Context().Value(middleware.CookieName).(uuid.UUID); ok {
+		query.UserID = UUID
+	}
+	if value := values.Get("type"); value != "" {
+		query.Types = utils.ParseStringArray(value)
+	}
+	if value := values.Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			utils.WriteError(w, http.StatusBadRequest, "limit must be positive int")
+			return
+		}
+		query.Limit = min(parsed, maxSearchLimit)
+	}
+	if value := values.Get("offset"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			utils.WriteError(w, http.StatusBadRequest, "offset must be non-negative int")
+			return
+		}
+		query.Offset = parsed
+	}
+
+	results, err := h.ucWord.Search(r.Context(), &query)
+	if err != nil {
+		if errors.Is(err, word.ErrInvalidData) {
+			utils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "SearchHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "error search")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusOK, results); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "SearchHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "SearchHandler")
+}
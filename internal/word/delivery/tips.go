@@ -0,0 +1,209 @@
+package delivery
+
+import (
+	"errors"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/internal/word"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+)
+
+// writeTipError отвечает на ошибку usecase советов подходящим статусом.
+func (h *WordHandler) writeTipError(w http.ResponseWriter, requestId, method string, err error, message string) {
+	switch {
+	case errors.Is(err, word.ErrNotFound):
+		utils.WriteError(w, http.StatusNotFound, "tip not found")
+	case errors.Is(err, word.ErrInvalidData):
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, method, err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, message)
+	}
+}
+
+// readTipForm читает совет из multipart-формы редактора: phoneme, text, locale, difficulty,
+// position, example_words и необязательные файлы tip_audio и tip_media.
+func (h *WordHandler) readTipForm(r *http.Request) (*models.TipData, error) {
+	if err := r.ParseMultipartForm(5 << 20); err != nil {
+		return nil, fmt.Errorf("%w: max size file 5 mb", errBadForm)
+	}
+
+	tip := &models.TipData{
+		Phonema:      r.FormValue("phoneme"),
+		TipText:      r.FormValue("text"),
+		Locale:       r.FormValue("locale"),
+		Difficulty:   r.FormValue("difficulty"),
+		Position:     -1,
+		ExampleWords: utils.ParseStringArray(r.FormValue("example_words")),
+	}
+	if value := r.FormValue("position"); value != "" {
+		position, err := strconv.Atoi(value)
+		if err != nil || position < 0 {
+			return nil, fmt.Errorf("%w: incorrect position", errBadForm)
+		}
+		tip.Position = position
+	}
+
+	var err error
+	if tip.TipAudioLink, err = h.uploadFormFile(r, "tip_audio", []string{".wav", ".mp3"}); err != nil {
+		return nil, err
+	}
+	if tip.TipMediaLink, err = h.uploadFormFile(r, "tip_media", nil); err != nil {
+		return nil, err
+	}
+	return tip, nil
+}
+
+func (h *WordHandler) CreateTipHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+
+	tip, err := h.readTipForm(r)
+	if err != nil {
+		h.writeFormError(w, requestId, "CreateTipHandler", err)
+		return
+	}
+
+	tipID, err := h.ucWord.CreateTip(r.Context(), tip)
+	if err != nil {
+		h.writeTipError(w, requestId, "CreateTipHandler", err, "error create tip")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusCreated, models.IdStruct{Id: &tipID}); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "CreateTipHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "CreateTipHandler")
+}
+
+func (h *WordHandler) GetTipsHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+
+	filter := models.TipFilter{
+		Phoneme: r.URL.Query().Get("phoneme"),
+		Locale:  r.URL.Query().Get("locale"),
+	}
+	if filter.Phoneme == "" {
+		utils.WriteError(w, http.StatusBadRequest, "phoneme is required")
+		return
+	}
+
+	list, err := h.ucWord.GetTips(r.Context(), &filter)
+	if err != nil {
+		h.writeTipError(w, requestId, "GetTipsHandler", err, "error get tips")
+		return
+	}
+
+	tips := make([]*models.TipData, 0, len(list.Tips))
+	for i := range list.Tips {
+		tips = append(tips, &list.Tips[i])
+	}
+	h.resolveTipLinks(requestId, "GetTipsHandler", tips)
+
+	if err := utils.WriteResponse(w, http.StatusOK, list); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetTipsHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "GetTipsHandler")
+}
+
+func (h *WordHandler) GetTipByIDHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	tipID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect tip id")
+		return
+	}
+
+	tip, err := h.ucWord.GetTipByID(r.Context(), tipID)
+	if err != nil {
+		h.writeTipError(w, requestId, "GetTipByIDHandler", err, "error get tip")
+		return
+	}
+	h.resolveTipLinks(requestId, "GetTipByIDHandler", []*models.TipData{tip})
+
+	if err := utils.WriteResponse(w, http.StatusOK, tip); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetTipByIDHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "GetTipByIDHandler")
+}
+
+// UpdateTipHandler заменяет поля совета. Файлы, не приложенные к форме, остаются прежними,
+// без position совет остаётся на своём месте.
+func (h *WordHandler) UpdateTipHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	tipID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect tip id")
+		return
+	}
+
+	tip, err := h.readTipForm(r)
+	if err != nil {
+		h.writeFormError(w, requestId, "UpdateTipHandler", err)
+		return
+	}
+	tip.TipID = &tipID
+
+	if err := h.ucWord.UpdateTip(r.Context(), tip); err != nil {
+		h.writeTipError(w, requestId, "UpdateTipHandler", err, "error update tip")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "UpdateTipHandler")
+}
+
+func (h *WordHandler) DeleteTipHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	tipID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect tip id")
+		return
+	}
+
+	if err := h.ucWord.DeleteTip(r.Context(), tipID); err != nil {
+		h.writeTipError(w, requestId, "DeleteTipHandler", err, "error delete tip")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "DeleteTipHandler")
+}
+
+// resolveTipLinks заменяет идентификаторы аудио и картинок советов ссылками на файлы.
+// Ссылки запрашиваются одной пачкой, одинаковые файлы - один раз.
+func (h *WordHandler) resolveTipLinks(requestId, handler string, tips []*models.TipData) {
+	files := make([]string, 0, 2*len(tips))
+	for _, tip := range tips {
+		files = append(files, tip.TipAudioLink, tip.TipMediaLink)
+	}
+	if len(files) == 0 {
+		return
+	}
+
+	links, err := h.minClient.GetFileLinks(files)
+	if err != nil {
+		h.logger.LogError(requestId, logger.DeliveryLayer, handler, err)
+	}
+
+	for _, tip := range tips {
+		if link, ok := links[tip.TipAudioLink]; ok {
+			tip.TipAudioLink = link
+		}
+		if link, ok := links[tip.TipMediaLink]; ok {
+			tip.TipMediaLink = link
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package delivery
+
+import (
+	"errors"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/internal/word"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	"github.com/TeaStealers-backend-sem4/pkg/middleware"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/gorilla/mux"
+	"github.com/satori/uuid"
+	"net/http"
+	"strconv"
+)
+
+// topicTargets - сегменты пути /topics/{id}/{target}/{target_id} и виды материалов.
+var topicTargets = map[string]string{
+	"word-modules":     models.TopicWordModule,
+	"phrase-modules":   models.TopicPhraseModule,
+	"word-exercises":   models.TopicWordExercise,
+	"phrase-exercises": models.TopicPhraseExercise,
+	"lexicon":          models.TopicWord,
+}
+
+// writeTopicError отвечает на ошибку usecase тем подходящим статусом.
+func (h *WordHandler) writeTopicError(w http.ResponseWriter, requestId, method string, err error, message string) {
+	switch {
+	case errors.Is(err, word.ErrNotFound):
+		utils.WriteError(w, http.StatusNotFound, "not found")
+	case errors.Is(err, word.ErrInvalidData):
+		utils.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, method, err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, message)
+	}
+}
+
+// GetTopicsHandler - GET /topics: темы с числом слов, для авторизованного пользователя
+// ещё и с числом пройденных слов.
+func (h *WordHandler) GetTopicsHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	userID := uuid.Nil
+	if UUID, ok := r.Context().Value(middleware.CookieName).(uuid.UUID); ok {
+		userID = UUID
+	}
+
+	topics, err := h.ucWord.GetTopics(r.Context(), userID)
+	if err != nil {
+		h.writeTopicError(w, requestId, "GetTopicsHandler", err, "error get topics")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusOK, topics); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "GetTopicsHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "GetTopicsHandler")
+}
+
+// CreateTopicHandler - POST /topics {"topic": "th-sounds", "title": "..."}.
+func (h *WordHandler) CreateTopicHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+
+	data := models.OneTopic{}
+	if err := utils.ReadRequestData(r, &data); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "CreateTopicHandler", err, http.StatusBadRequest)
+		utils.WriteError(w, http.StatusBadRequest, "incorrect data format")
+		return
+	}
+
+	topicID, err := h.ucWord.CreateTopic(r.Context(), &data)
+	if err != nil {
+		h.writeTopicError(w, requestId, "CreateTopicHandler", err, "error create topic")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusCreated, models.IdStruct{Id: &topicID}); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "CreateTopicHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "CreateTopicHandler")
+}
+
+func (h *WordHandler) DeleteTopicHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	topicID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect topic id")
+		return
+	}
+
+	if err := h.ucWord.DeleteTopic(r.Context(), topicID); err != nil {
+		h.writeTopicError(w, requestId, "DeleteTopicHandler", err, "error delete topic")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "DeleteTopicHandler")
+}
+
+// readTopicLink читает привязку темы из пути /topics/{id}/{target}/{target_id}.
+func readTopicLink(r *http.Request) (*models.TopicLink, bool) {
+	vars := mux.Vars(r)
+	topicID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		return nil, false
+	}
+	targetID, err := strconv.Atoi(vars["target_id"])
+	if err != nil {
+		return nil, false
+	}
+	kind, ok := topicTargets[vars["target"]]
+	if !ok {
+		return nil, false
+	}
+	return &models.TopicLink{TopicID: topicID, Kind: kind, TargetID: targetID}, true
+}
+
+// AddTopicLinkHandler - PUT /topics/{id}/{target}/{target_id}: привязывает тему к модулю,
+// упражнению или статье словаря.
+func (h *WordHandler) AddTopicLinkHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	link, ok := readTopicLink(r)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect topic link")
+		return
+	}
+
+	if err := h.ucWord.AddTopicLink(r.Context(), link); err != nil {
+		h.writeTopicError(w, requestId, "AddTopicLinkHandler", err, "error add topic link")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "AddTopicLinkHandler")
+}
+
+func (h *WordHandler) DeleteTopicLinkHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+	link, ok := readTopicLink(r)
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, "incorrect topic link")
+		return
+	}
+
+	if err := h.ucWord.DeleteTopicLink(r.Context(), link); err != nil {
+		h.writeTopicError(w, requestId, "DeleteTopicLinkHandler", err, "error delete topic link")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "DeleteTopicLinkHandler")
+}
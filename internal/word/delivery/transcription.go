@@ -0,0 +1,41 @@
+package delivery
+
+import (
+	"errors"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/internal/word"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"net/http"
+)
+
+func (h *WordHandler) TranscribeHandler(w http.ResponseWriter, r *http.Request) {
+	requestId := utils.GetRequestIDFromCtx(r.Context())
+
+	data := models.TranscriptionRequest{}
+	if err := utils.ReadRequestData(r, &data); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "TranscribeHandler", err, http.StatusBadRequest)
+		utils.WriteError(w, http.StatusBadRequest, "incorrect data format")
+		return
+	}
+
+	suggestion, err := h.ucWord.SuggestTranscription(r.Context(), &data)
+	if err != nil {
+		if errors.Is(err, word.ErrInvalidData) {
+			h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "TranscribeHandler", err, http.StatusBadRequest)
+			utils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "TranscribeHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "error transcribe")
+		return
+	}
+
+	if err := utils.WriteResponse(w, http.StatusOK, suggestion); err != nil {
+		h.logger.LogErrorResponse(requestId, logger.DeliveryLayer, "TranscribeHandler", err, http.StatusInternalServerError)
+		utils.WriteError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	h.logger.LogSuccessResponse(requestId, logger.DeliveryLayer, "TranscribeHandler")
+}
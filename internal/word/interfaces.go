@@ -2,25 +2,95 @@ package word
 
 import (
 	"context"
+	"errors"
 	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/satori/uuid"
 )
 
+var (
+	ErrNotFound    = errors.New("not found")
+	ErrInvalidData = errors.New("invalid data")
+)
+
+// AttemptListener получает каждую сохранённую попытку упражнения внутри её транзакции;
+// status - итоговый статус упражнения после попытки. Ошибка отменяет всю попытку.
+type AttemptListener interface {
+	OnAttempt(ctx context.Context, tx models.Transaction, attempt *models.ExerciseAttempt, status string) error
+}
+
 type WordUsecase interface {
 	CreateWordExercise(ctx context.Context, wordCreateData *models.CreateWordData) (int, error)
 	CreateWordExerciseList(ctx context.Context, wordCreateData *models.CreateWordDataList) (int, error)
 	CreatePhraseExercise(ctx context.Context, phraseCreateData *models.CreatePhraseData) (int, error)
 
+	SuggestTranscription(ctx context.Context, data *models.TranscriptionRequest) (*models.TranscriptionSuggestion, error)
+	CheckTranscriptions(ctx context.Context, texts, transcriptions []string) []models.TranscriptionWarning
+
 	CreateUpdateProgress(ctx context.Context, progress *models.ExerciseProgress) (int, error)
+	GetExerciseAttempts(ctx context.Context, userID uuid.UUID, exerciseID int, exerciseType string) (*models.AttemptList, error)
+
+	GetExercise(ctx context.Context, userID uuid.UUID, exerciseType string, exerciseID int) (*models.Exercise, error)
+	SubmitPronunciation(ctx context.Context, data *models.PronunciationAttempt) (*models.PronunciationResult, error)
+	GetUserPhonemes(ctx context.Context, userID uuid.UUID, languages []string) (*models.PhonemeMasteryList, error)
+
+	GetReviewQueue(ctx context.Context, userID uuid.UUID, languages []string) (*models.ExerciseList, error)
+	GradeReview(ctx context.Context, data *models.ReviewGrade) (*models.ReviewResult, error)
+	GetReviewSettings(ctx context.Context, userID uuid.UUID) (*models.ReviewSettings, error)
+	UpdateReviewSettings(ctx context.Context, userID uuid.UUID, settings *models.ReviewSettings) error
 
-	GetWordModuleExercises(ctx context.Context, userID string, moduleId int) (*models.ExerciseList, error)
-	GetPhraseModuleExercises(ctx context.Context, userID string, moduleId int) (*models.ExerciseList, error)
+	GetProgressDashboard(ctx context.Context, userID uuid.UUID) (*models.ProgressDashboard, error)
+	GetNextExercises(ctx context.Context, userID uuid.UUID, count int, strategy string, languages []string) (*models.RecommendationList, error)
 
-	GetWordModules(ctx context.Context) (*models.ModuleList, error)
-	GetPhraseModules(ctx context.Context) (*models.ModuleList, error)
+	StartPlacement(ctx context.Context, userID uuid.UUID, languages []string) (*models.PlacementTest, error)
+	GetPlacement(ctx context.Context, userID uuid.UUID, testID int, languages []string) (*models.PlacementTest, error)
+	AnswerPlacement(ctx context.Context, answer *models.PlacementAnswer, languages []string) (*models.PlacementTest, error)
+
+	GetWordModuleExercises(ctx context.Context, userID string, moduleId int, languages []string, withExamples bool, topic string) (*models.ExerciseList, error)
+	GetPhraseModuleExercises(ctx context.Context, userID string, moduleId int, languages []string, topic string) (*models.ExerciseList, error)
+
+	GetWordModules(ctx context.Context, topic string) (*models.ModuleList, error)
+	GetPhraseModules(ctx context.Context, topic string) (*models.ModuleList, error)
 
 	GetNextPhraseModule(ctx context.Context, userID string) (*models.ModuleCreate, error)
 	GetNextWordModule(ctx context.Context, userID string) (*models.ModuleCreate, error)
 
+	CreateDeck(ctx context.Context, userID uuid.UUID, data *models.DeckCreate) (*models.Deck, error)
+	GetDecks(ctx context.Context, userID uuid.UUID) (*models.DeckList, error)
+	GetDeck(ctx context.Context, userID uuid.UUID, deckID int, languages []string) (*models.Deck, error)
+	RenameDeck(ctx context.Context, userID uuid.UUID, deckID int, data *models.DeckCreate) error
+	DeleteDeck(ctx context.Context, userID uuid.UUID, deckID int) error
+	AddDeckWord(ctx context.Context, userID uuid.UUID, data *models.DeckWordCreate) ([]int, error)
+	DeleteDeckWord(ctx context.Context, userID uuid.UUID, deckID, exerciseID int) error
+
+	AddBookmark(ctx context.Context, userID uuid.UUID, data *models.BookmarkCreate) (*models.Bookmark, error)
+	GetBookmarks(ctx context.Context, userID uuid.UUID, filter *models.BookmarkFilter) (*models.BookmarkList, error)
+	UpdateBookmarkNote(ctx context.Context, userID uuid.UUID, bookmarkID int, data *models.BookmarkNote) error
+	DeleteBookmark(ctx context.Context, userID uuid.UUID, bookmarkID int) error
+	GetBookmarkExercises(ctx context.Context, userID uuid.UUID, filter *models.BookmarkFilter, languages []string) (*models.ExerciseList, error)
+
 	UploadTip(ctx context.Context, data *models.TipData) error
 	GetTip(ctx context.Context, data *models.TipData) (*models.TipData, error)
+	CreateTip(ctx context.Context, tip *models.TipData) (int, error)
+	GetTipByID(ctx context.Context, tipID int) (*models.TipData, error)
+	GetTips(ctx context.Context, filter *models.TipFilter) (*models.TipList, error)
+	UpdateTip(ctx context.Context, tip *models.TipData) error
+	DeleteTip(ctx context.Context, tipID int) error
+	Search(ctx context.Context, query *models.SearchQuery) (*models.SearchResults, error)
+	GetContentSettings(ctx context.Context, userID uuid.UUID) (*models.ContentSettings, error)
+	UpdateContentSettings(ctx context.Context, userID uuid.UUID, settings *models.ContentSettings) (*models.ContentSettings, error)
+	SetWordVariant(ctx context.Context, exerciseID, position int, variant *models.AccentVariant) error
+	DeleteWordVariant(ctx context.Context, exerciseID, position int, accent string) error
+	SetPhraseVariant(ctx context.Context, exerciseID int, variant *models.AccentVariant) error
+	DeletePhraseVariant(ctx context.Context, exerciseID int, accent string) error
+
+	GetLexiconExamples(ctx context.Context, entryID, limit, offset int) (*models.ExampleList, error)
+	CreateExample(ctx context.Context, example *models.ExampleData) (int, error)
+	UpdateExample(ctx context.Context, example *models.ExampleData) error
+	DeleteExample(ctx context.Context, exampleID int) error
+
+	GetTopics(ctx context.Context, userID uuid.UUID) (*models.TopicsList, error)
+	CreateTopic(ctx context.Context, topic *models.OneTopic) (int, error)
+	DeleteTopic(ctx context.Context, topicID int) error
+	AddTopicLink(ctx context.Context, link *models.TopicLink) error
+	DeleteTopicLink(ctx context.Context, link *models.TopicLink) error
 }
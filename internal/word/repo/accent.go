@@ -0,0 +1,159 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/lib/pq"
+)
+
+// SetWordVariant сохраняет вариант произношения слова на позиции position упражнения. Вариант
+// относится к статье словаря, поэтому действует во всех упражнениях с этим словом.
+// Возвращает false, если слова нет или упражнение из личного набора.
+func (r *WordRepo) SetWordVariant(ctx context.Context, exerciseID, position int, variant *models.AccentVariant) (bool, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	res, err := r.db.ExecContext(ctx, UpsertWordVariantSql,
+		exerciseID,
+		position,
+		variant.Accent,
+		variant.Transcription,
+		pq.Array(variant.Phonemes),
+		variant.Audio,
+	)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "SetWordVariant", err)
+		return false, fmt.Errorf("failed to save word variant: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
+
+func (r *WordRepo) DeleteWordVariant(ctx context.Context, exerciseID, position int, accent string) (bool, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	res, err := r.db.ExecContext(ctx, DeleteWordVariantSql, exerciseID, position, accent)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "DeleteWordVariant", err)
+		return false, fmt.Errorf("failed to delete word variant: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// SetPhraseVariant возвращает false, если фразового упражнения нет.
+func (r *WordRepo) SetPhraseVariant(ctx context.Context, exerciseID int, variant *models.AccentVariant) (bool, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	res, err := r.db.ExecContext(ctx, UpsertPhraseVariantSql,
+		exerciseID,
+		variant.Accent,
+		variant.Transcription,
+		pq.Array(variant.Phonemes),
+		variant.Audio,
+	)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "SetPhraseVariant", err)
+		return false, fmt.Errorf("failed to save phrase variant: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
+
+func (r *WordRepo) DeletePhraseVariant(ctx context.Context, exerciseID int, accent string) (bool, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	res, err := r.db.ExecContext(ctx, DeletePhraseVariantSql, exerciseID, accent)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "DeletePhraseVariant", err)
+		return false, fmt.Errorf("failed to delete phrase variant: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// GetWordVariants возвращает варианты слов упражнений с акцентом accent:
+// id упражнения -> позиция слова -> вариант.
+func (r *WordRepo) GetWordVariants(ctx context.Context, exerciseIDs []int, accent string) (map[int]map[int]models.AccentVariant, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, SelectWordVariantsSql, pq.Array(exerciseIDs), accent)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetWordVariants", err)
+		return nil, fmt.Errorf("failed to query word variants: %w", err)
+	}
+	defer rows.Close()
+
+	variants := make(map[int]map[int]models.AccentVariant)
+	for rows.Next() {
+		var exerciseID, position int
+		var phonemes pq.StringArray
+		variant := models.AccentVariant{Accent: accent}
+		if err := rows.Scan(&exerciseID, &position, &variant.Transcription, &phonemes, &variant.Audio); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetWordVariants", err)
+			return nil, fmt.Errorf("failed to scan word variant: %w", err)
+		}
+		variant.Phonemes = phonemes
+		if variants[exerciseID] == nil {
+			variants[exerciseID] = make(map[int]models.AccentVariant)
+		}
+		variants[exerciseID][position] = variant
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetWordVariants", err)
+		return nil, fmt.Errorf("error after iterating word variants: %w", err)
+	}
+
+	return variants, nil
+}
+
+// GetPhraseVariants - то же для фразовых упражнений: id упражнения -> вариант.
+func (r *WordRepo) GetPhraseVariants(ctx context.Context, exerciseIDs []int, accent string) (map[int]models.AccentVariant, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, SelectPhraseVariantsSql, pq.Array(exerciseIDs), accent)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetPhraseVariants", err)
+		return nil, fmt.Errorf("failed to query phrase variants: %w", err)
+	}
+	defer rows.Close()
+
+	variants := make(map[int]models.AccentVariant)
+	for rows.Next() {
+		var exerciseID int
+		var phonemes pq.StringArray
+		variant := models.AccentVariant{Accent: accent}
+		if err := rows.Scan(&exerciseID, &variant.Transcription, &phonemes, &variant.Audio); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetPhraseVariants", err)
+			return nil, fmt.Errorf("failed to scan phrase variant: %w", err)
+		}
+		variant.Phonemes = phonemes
+		variants[exerciseID] = variant
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetPhraseVariants", err)
+		return nil, fmt.Errorf("error after iterating phrase variants: %w", err)
+	}
+
+	return variants, nil
+}
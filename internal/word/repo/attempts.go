@@ -0,0 +1,119 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+)
+
+func (r *WordRepo) InsertExerciseAttempt(ctx context.Context, tx models.Transaction, attempt *models.ExerciseAttempt) (int, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	validTypes := map[string]bool{
+		"word":   true,
+		"phrase": true,
+	}
+	if !validTypes[attempt.ExerciseType] {
+		return 0, fmt.Errorf("invalid exercise type: %s", attempt.ExerciseType)
+	}
+
+	validResults := map[string]bool{
+		"completed": true,
+		"failed":    true,
+	}
+	if !validResults[attempt.Result] {
+		return 0, fmt.Errorf("invalid attempt result: %s", attempt.Result)
+	}
+
+	var score sql.NullInt64
+	if attempt.Score != nil {
+		score = sql.NullInt64{Int64: int64(*attempt.Score), Valid: true}
+	}
+
+	err := tx.QueryRowContext(ctx, InsertExerciseAttemptSql,
+		attempt.UserID,
+		attempt.ExerciseID,
+		attempt.ExerciseType,
+		attempt.Result,
+		score,
+		attempt.DurationMs,
+		attempt.Transcription,
+		attempt.RecordingID,
+	).Scan(&attempt.ID, &attempt.CreatedAt)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "InsertExerciseAttempt", err)
+		return 0, fmt.Errorf("failed to insert exercise attempt: %w", err)
+	}
+
+	r.logger.LogInfo(requestId, logger.RepositoryLayer, "InsertExerciseAttempt", "exercise attempt inserted")
+	return attempt.ID, nil
+}
+
+// RefreshExerciseProgress пересчитывает сводку exercise_progress по всем попыткам пользователя.
+// Возвращает id строки сводки и итоговый статус упражнения.
+func (r *WordRepo) RefreshExerciseProgress(ctx context.Context, tx models.Transaction, userID uuid.UUID, exerciseID int, exerciseType string) (int, string, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var progressID int
+	var status string
+	err := tx.QueryRowContext(ctx, RefreshExerciseProgressSql, userID, exerciseID, exerciseType).Scan(&progressID, &status)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "RefreshExerciseProgress", err)
+		return 0, "", fmt.Errorf("failed to refresh exercise progress: %w", err)
+	}
+
+	r.logger.LogInfo(requestId, logger.RepositoryLayer, "RefreshExerciseProgress", "exercise progress refreshed")
+	return progressID, status, nil
+}
+
+func (r *WordRepo) GetExerciseAttempts(ctx context.Context, userID uuid.UUID, exerciseID int, exerciseType string) (*models.AttemptList, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, SelectExerciseAttemptsSql, userID, exerciseID, exerciseType)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetExerciseAttempts", err)
+		return nil, fmt.Errorf("failed to query exercise attempts: %w", err)
+	}
+	defer rows.Close()
+
+	attempts := make([]models.ExerciseAttempt, 0)
+	for rows.Next() {
+		var attempt models.ExerciseAttempt
+		var score sql.NullInt64
+
+		if err := rows.Scan(
+			&attempt.ID,
+			&attempt.ExerciseID,
+			&attempt.ExerciseType,
+			&attempt.Result,
+			&score,
+			&attempt.DurationMs,
+			&attempt.Transcription,
+			&attempt.RecordingID,
+			&attempt.CreatedAt,
+		); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetExerciseAttempts", err)
+			return nil, fmt.Errorf("failed to scan exercise attempt: %w", err)
+		}
+		if score.Valid {
+			value := int(score.Int64)
+			attempt.Score = &value
+		}
+
+		attempts = append(attempts, attempt)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetExerciseAttempts", err)
+		return nil, fmt.Errorf("error after iterating exercise attempts: %w", err)
+	}
+
+	r.logger.LogInfo(requestId, logger.RepositoryLayer, "GetExerciseAttempts",
+		fmt.Sprintf("retrieved %d attempts for %s exercise %d", len(attempts), exerciseType, exerciseID))
+
+	return &models.AttemptList{Attempts: attempts}, nil
+}
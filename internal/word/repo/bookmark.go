@@ -0,0 +1,199 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/lib/pq"
+	"github.com/satori/uuid"
+)
+
+// UpsertBookmark ставит закладку или обновляет заметку существующей. Возвращает 0,
+// если упражнение не найдено, недоступно пользователю или в нём нет слова с таким индексом.
+func (r *WordRepo) UpsertBookmark(ctx context.Context, userID uuid.UUID, data *models.BookmarkCreate) (int, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var row *sql.Row
+	if data.Type == models.BookmarkWord {
+		row = r.db.QueryRowContext(ctx, UpsertWordBookmarkSql, userID, *data.ExerciseID, data.WordIndex, data.Note)
+	} else {
+		row = r.db.QueryRowContext(ctx, UpsertPhraseBookmarkSql, userID, *data.ExerciseID, data.Note)
+	}
+
+	var bookmarkID int
+	if err := row.Scan(&bookmarkID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		r.logger.LogError(requestId, logger.RepositoryLayer, "UpsertBookmark", err)
+		return 0, fmt.Errorf("failed to save bookmark: %w", err)
+	}
+
+	return bookmarkID, nil
+}
+
+// GetBookmarks возвращает закладки пользователя; bookmarkID ограничивает выборку одной закладкой.
+func (r *WordRepo) GetBookmarks(ctx context.Context, userID uuid.UUID, filter *models.BookmarkFilter, bookmarkID *int) ([]models.Bookmark, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, SelectBookmarksSql, userID, filter.Type, filter.ModuleID, bookmarkID)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetBookmarks", err)
+		return nil, fmt.Errorf("failed to query bookmarks: %w", err)
+	}
+	defer rows.Close()
+
+	bookmarks := make([]models.Bookmark, 0)
+	for rows.Next() {
+		var b models.Bookmark
+		if err := rows.Scan(
+			&b.ID,
+			&b.Type,
+			&b.ExerciseID,
+			&b.WordIndex,
+			&b.ModuleID,
+			&b.Text,
+			&b.Translation,
+			&b.Transcription,
+			&b.Audio,
+			&b.Note,
+			&b.CreatedAt,
+		); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetBookmarks", err)
+			return nil, fmt.Errorf("failed to scan bookmark: %w", err)
+		}
+		bookmarks = append(bookmarks, b)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetBookmarks", err)
+		return nil, fmt.Errorf("error after iterating bookmarks: %w", err)
+	}
+
+	return bookmarks, nil
+}
+
+// UpdateBookmarkNote возвращает false, если закладка не найдена среди закладок пользователя.
+func (r *WordRepo) UpdateBookmarkNote(ctx context.Context, userID uuid.UUID, bookmarkID int, note string) (bool, error) {
+	return r.execBookmark(ctx, "UpdateBookmarkNote", UpdateBookmarkNoteSql, bookmarkID, userID, note)
+}
+
+// DeleteBookmark возвращает false, если закладка не найдена среди закладок пользователя.
+func (r *WordRepo) DeleteBookmark(ctx context.Context, userID uuid.UUID, bookmarkID int) (bool, error) {
+	return r.execBookmark(ctx, "DeleteBookmark", DeleteBookmarkSql, bookmarkID, userID)
+}
+
+func (r *WordRepo) execBookmark(ctx context.Context, method, query string, args ...any) (bool, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, method, err)
+		return false, fmt.Errorf("failed to update bookmark: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, method, err)
+		return false, fmt.Errorf("failed to update bookmark: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// GetBookmarkedWordExercises возвращает упражнения со словами из закладок вместе со статусом прогресса.
+func (r *WordRepo) GetBookmarkedWordExercises(ctx context.Context, userID uuid.UUID, moduleID *int) ([]models.Exercise, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, SelectBookmarkedWordExercisesSql, userID, moduleID)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetBookmarkedWordExercises", err)
+		return nil, fmt.Errorf("failed to query bookmarked word exercises: %w", err)
+	}
+	defer rows.Close()
+
+	exercises := make([]models.Exercise, 0)
+	for rows.Next() {
+		var exercise models.Exercise
+		var words, transcriptions, audio, translations pq.StringArray
+
+		if err := rows.Scan(
+			&exercise.ID,
+			&exercise.ExerciseType,
+			&words,
+			&transcriptions,
+			&audio,
+			&translations,
+			&exercise.ModuleId,
+			&exercise.Status,
+		); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetBookmarkedWordExercises", err)
+			return nil, fmt.Errorf("failed to scan word exercise: %w", err)
+		}
+
+		exercise.Words = words
+		exercise.Transcriptions = transcriptions
+		exercise.Audio = audio
+		exercise.Translations = translations
+
+		exercises = append(exercises, exercise)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetBookmarkedWordExercises", err)
+		return nil, fmt.Errorf("error after iterating word exercises: %w", err)
+	}
+
+	return exercises, nil
+}
+
+// GetBookmarkedPhraseExercises возвращает фразы из закладок вместе со статусом прогресса.
+func (r *WordRepo) GetBookmarkedPhraseExercises(ctx context.Context, userID uuid.UUID, moduleID *int) ([]models.Exercise, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, SelectBookmarkedPhraseExercisesSql, userID, moduleID)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetBookmarkedPhraseExercises", err)
+		return nil, fmt.Errorf("failed to query bookmarked phrase exercises: %w", err)
+	}
+	defer rows.Close()
+
+	exercises := make([]models.Exercise, 0)
+	for rows.Next() {
+		var exercise models.Exercise
+		var sentence, translate, transcription, audio string
+		var chain pq.StringArray
+
+		if err := rows.Scan(
+			&exercise.ID,
+			&exercise.ExerciseType,
+			&sentence,
+			&translate,
+			&transcription,
+			&audio,
+			&chain,
+			&exercise.ModuleId,
+			&exercise.Status,
+		); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetBookmarkedPhraseExercises", err)
+			return nil, fmt.Errorf("failed to scan phrase exercise: %w", err)
+		}
+
+		exercise.Words = []string{sentence}
+		exercise.Translations = []string{translate}
+		exercise.Transcriptions = []string{transcription}
+		exercise.Audio = []string{audio}
+		exercise.Chain = chain
+
+		exercises = append(exercises, exercise)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetBookmarkedPhraseExercises", err)
+		return nil, fmt.Errorf("error after iterating phrase exercises: %w", err)
+	}
+
+	return exercises, nil
+}
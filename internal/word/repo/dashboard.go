@@ -0,0 +1,99 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/lib/pq"
+	"github.com/satori/uuid"
+)
+
+// GetModuleProgress одним запросом считает прогресс пользователя по всем модулям курса.
+func (r *WordRepo) GetModuleProgress(ctx context.Context, userID uuid.UUID, track string) ([]models.ModuleProgress, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var query string
+	switch track {
+	case "word":
+		query = SelectWordModuleProgressSql
+	case "phrase":
+		query = SelectPhraseModuleProgressSql
+	default:
+		return nil, fmt.Errorf("unknown track %q", track)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetModuleProgress", err)
+		return nil, fmt.Errorf("failed to query module progress: %w", err)
+	}
+	defer rows.Close()
+
+	modules := make([]models.ModuleProgress, 0)
+	for rows.Next() {
+		var m models.ModuleProgress
+		if err := rows.Scan(
+			&m.ModuleID,
+			&m.Title,
+			&m.Deck,
+			&m.Total,
+			&m.Attempted,
+			&m.Completed,
+			&m.Failed,
+			&m.Skipped,
+			&m.Attempts,
+			&m.TimeSpentMs,
+		); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetModuleProgress", err)
+			return nil, fmt.Errorf("failed to scan module progress: %w", err)
+		}
+		modules = append(modules, m)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetModuleProgress", err)
+		return nil, fmt.Errorf("error after iterating module progress: %w", err)
+	}
+
+	return modules, nil
+}
+
+// GetRecentWords возвращает последние отработанные слова с результатом последней попытки.
+func (r *WordRepo) GetRecentWords(ctx context.Context, userID uuid.UUID, limit int) ([]models.RecentWord, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, SelectRecentWordsSql, userID, limit)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetRecentWords", err)
+		return nil, fmt.Errorf("failed to query recent words: %w", err)
+	}
+	defer rows.Close()
+
+	words := make([]models.RecentWord, 0)
+	for rows.Next() {
+		var w models.RecentWord
+		var list pq.StringArray
+		var score sql.NullInt64
+
+		if err := rows.Scan(&w.ExerciseID, &list, &w.Result, &score, &w.PractisedAt); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetRecentWords", err)
+			return nil, fmt.Errorf("failed to scan recent word: %w", err)
+		}
+		w.Words = list
+		if score.Valid {
+			value := int(score.Int64)
+			w.Score = &value
+		}
+		words = append(words, w)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetRecentWords", err)
+		return nil, fmt.Errorf("error after iterating recent words: %w", err)
+	}
+
+	return words, nil
+}
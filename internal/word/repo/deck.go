@@ -0,0 +1,145 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/lib/pq"
+	"github.com/satori/uuid"
+)
+
+// Все запросы к личным наборам фильтруют по владельцу: чужой набор неотличим от несуществующего.
+
+func (r *WordRepo) CreateDeck(ctx context.Context, userID uuid.UUID, deck *models.Deck) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	if err := r.db.QueryRowContext(ctx, InsertDeckSql, deck.Title, userID).Scan(&deck.ID, &deck.CreatedAt); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "CreateDeck", err)
+		return fmt.Errorf("failed to create deck: %w", err)
+	}
+
+	r.logger.LogInfo(requestId, logger.RepositoryLayer, "CreateDeck", "deck created")
+	return nil
+}
+
+func (r *WordRepo) GetDecks(ctx context.Context, userID uuid.UUID) ([]models.Deck, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, SelectDecksSql, userID)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetDecks", err)
+		return nil, fmt.Errorf("failed to query decks: %w", err)
+	}
+	defer rows.Close()
+
+	decks := make([]models.Deck, 0)
+	for rows.Next() {
+		var deck models.Deck
+		if err := rows.Scan(&deck.ID, &deck.Title, &deck.Words, &deck.CreatedAt); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetDecks", err)
+			return nil, fmt.Errorf("failed to scan deck: %w", err)
+		}
+		decks = append(decks, deck)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetDecks", err)
+		return nil, fmt.Errorf("error after iterating decks: %w", err)
+	}
+
+	return decks, nil
+}
+
+// GetDeck возвращает nil, если набора нет или он принадлежит другому пользователю.
+func (r *WordRepo) GetDeck(ctx context.Context, userID uuid.UUID, deckID int) (*models.Deck, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var deck models.Deck
+	err := r.db.QueryRowContext(ctx, SelectDeckSql, deckID, userID).Scan(&deck.ID, &deck.Title, &deck.Words, &deck.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetDeck", err)
+		return nil, fmt.Errorf("failed to get deck: %w", err)
+	}
+
+	return &deck, nil
+}
+
+// RenameDeck возвращает false, если набор не найден среди наборов пользователя.
+func (r *WordRepo) RenameDeck(ctx context.Context, userID uuid.UUID, deckID int, title string) (bool, error) {
+	return r.execDeck(ctx, "RenameDeck", UpdateDeckTitleSql, deckID, userID, title)
+}
+
+// DeleteDeck удаляет набор вместе с его словами; false - набор не найден.
+func (r *WordRepo) DeleteDeck(ctx context.Context, userID uuid.UUID, deckID int) (bool, error) {
+	return r.execDeck(ctx, "DeleteDeck", DeleteDeckSql, deckID, userID)
+}
+
+// DeleteDeckWord возвращает false, если слова нет в наборе пользователя.
+func (r *WordRepo) DeleteDeckWord(ctx context.Context, userID uuid.UUID, deckID, exerciseID int) (bool, error) {
+	return r.execDeck(ctx, "DeleteDeckWord", DeleteDeckWordSql, deckID, userID, exerciseID)
+}
+
+func (r *WordRepo) execDeck(ctx context.Context, method, query string, args ...any) (bool, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, method, err)
+		return false, fmt.Errorf("failed to update deck: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, method, err)
+		return false, fmt.Errorf("failed to update deck: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// AddDeckWord создаёт в наборе упражнение вида exerciseType со словами entries и возвращает
+// его id или 0, если набор не принадлежит пользователю. Слова попадают в личные статьи
+// словаря владельца, общие статьи не меняются.
+func (r *WordRepo) AddDeckWord(ctx context.Context, tx models.Transaction, userID uuid.UUID, deckID int, exerciseType string, entries []models.LexiconEntry) (int, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var exerciseID int
+	err := tx.QueryRowContext(ctx, InsertDeckWordSql, deckID, userID, exerciseType).Scan(&exerciseID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		r.logger.LogError(requestId, logger.RepositoryLayer, "AddDeckWord", err)
+		return 0, fmt.Errorf("failed to add deck word: %w", err)
+	}
+
+	if err := r.AttachExerciseEntries(ctx, tx, exerciseID, userID, entries); err != nil {
+		return 0, err
+	}
+
+	r.logger.LogInfo(requestId, logger.RepositoryLayer, "AddDeckWord", "deck word added")
+	return exerciseID, nil
+}
+
+// GetDeckGuessPartner возвращает слово набора с озвучкой, отличное от word, или nil,
+// если такого нет.
+func (r *WordRepo) GetDeckGuessPartner(ctx context.Context, tx models.Transaction, userID uuid.UUID, deckID int, word string) (*models.LexiconEntry, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	entry := &models.LexiconEntry{Translations: []string{""}}
+	err := tx.QueryRowContext(ctx, SelectDeckGuessPartnerSql, deckID, userID, word).Scan(
+		&entry.Word, &entry.IPA, &entry.Translations[0], &entry.Audio, pq.Array(&entry.Phonemes))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetDeckGuessPartner", err)
+		return nil, fmt.Errorf("failed to get deck word: %w", err)
+	}
+	return entry, nil
+}
@@ -0,0 +1,203 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/lib/pq"
+)
+
+// SetSentenceWords заменяет слова предложения фразового упражнения (phrases = true) или
+// отдельного примера.
+func (r *WordRepo) SetSentenceWords(ctx context.Context, tx models.Transaction, phrases bool, id int, words []models.SentenceWord) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	deleteQuery, insertQuery := DeleteExampleWordsSql, InsertExampleWordsSql
+	if phrases {
+		deleteQuery, insertQuery = DeletePhraseWordsSql, InsertPhraseWordsSql
+	}
+
+	if _, err := tx.ExecContext(ctx, deleteQuery, id); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "SetSentenceWords", err)
+		return fmt.Errorf("failed to delete sentence words: %w", err)
+	}
+	if len(words) == 0 {
+		return nil
+	}
+
+	positions := make([]int64, 0, len(words))
+	forms := make([]string, 0, len(words))
+	keys := make([]string, 0, len(words))
+	for _, w := range words {
+		positions = append(positions, int64(w.Position))
+		forms = append(forms, w.Form)
+		keys = append(keys, w.Key)
+	}
+
+	if _, err := tx.ExecContext(ctx, insertQuery, id, pq.Array(positions), pq.Array(forms), pq.Array(keys)); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "SetSentenceWords", err)
+		return fmt.Errorf("failed to insert sentence words: %w", err)
+	}
+	return nil
+}
+
+// GetPhrasesWithoutWords возвращает пачку предложений фразовых упражнений, ещё не
+// разобранных на слова.
+func (r *WordRepo) GetPhrasesWithoutWords(ctx context.Context, afterID, limit int) ([]models.StoredSentence, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, SelectPhrasesWithoutWordsSql, afterID, limit)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetPhrasesWithoutWords", err)
+		return nil, fmt.Errorf("failed to query phrase sentences: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]models.StoredSentence, 0, limit)
+	for rows.Next() {
+		var s models.StoredSentence
+		if err := rows.Scan(&s.ID, &s.Sentence); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetPhrasesWithoutWords", err)
+			return nil, fmt.Errorf("failed to scan phrase sentence: %w", err)
+		}
+		result = append(result, s)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetPhrasesWithoutWords", err)
+		return nil, fmt.Errorf("error after iterating phrase sentences: %w", err)
+	}
+
+	return result, nil
+}
+
+func scanExample(row interface{ Scan(dest ...any) error }, dest ...any) (models.Example, error) {
+	var example models.Example
+	var moduleID sql.NullInt64
+	dest = append(dest, &example.Kind, &example.ID, &moduleID, &example.Sentence, &example.Translation, &example.Audio, &example.Form)
+	if err := row.Scan(dest...); err != nil {
+		return example, err
+	}
+	if moduleID.Valid {
+		id := int(moduleID.Int64)
+		example.ModuleID = &id
+	}
+	return example, nil
+}
+
+// GetExerciseExamples возвращает до limit примеров к каждому слову упражнений:
+// id упражнения -> примеры в порядке слов.
+func (r *WordRepo) GetExerciseExamples(ctx context.Context, exerciseIDs []int, limit int) (map[int][]models.Example, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, SelectExerciseExamplesSql, pq.Array(exerciseIDs), limit)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetExerciseExamples", err)
+		return nil, fmt.Errorf("failed to query exercise examples: %w", err)
+	}
+	defer rows.Close()
+
+	examples := make(map[int][]models.Example)
+	for rows.Next() {
+		var exerciseID int
+		example, err := scanExample(rows, &exerciseID)
+		if err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetExerciseExamples", err)
+			return nil, fmt.Errorf("failed to scan exercise example: %w", err)
+		}
+		examples[exerciseID] = append(examples[exerciseID], example)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetExerciseExamples", err)
+		return nil, fmt.Errorf("error after iterating exercise examples: %w", err)
+	}
+
+	return examples, nil
+}
+
+// GetLexiconExamples возвращает примеры к общей статье словаря. Если статьи нет или она
+// из личного набора, возвращает false.
+func (r *WordRepo) GetLexiconExamples(ctx context.Context, entryID, limit, offset int) ([]models.Example, bool, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, SelectSharedLexiconEntryExistsSql, entryID).Scan(&exists); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetLexiconExamples", err)
+		return nil, false, fmt.Errorf("failed to check lexicon entry: %w", err)
+	}
+	if !exists {
+		return nil, false, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, SelectLexiconExamplesSql, entryID, limit, offset)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetLexiconExamples", err)
+		return nil, false, fmt.Errorf("failed to query lexicon examples: %w", err)
+	}
+	defer rows.Close()
+
+	examples := make([]models.Example, 0, limit)
+	for rows.Next() {
+		example, err := scanExample(rows)
+		if err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetLexiconExamples", err)
+			return nil, false, fmt.Errorf("failed to scan lexicon example: %w", err)
+		}
+		examples = append(examples, example)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetLexiconExamples", err)
+		return nil, false, fmt.Errorf("error after iterating lexicon examples: %w", err)
+	}
+
+	return examples, true, nil
+}
+
+func (r *WordRepo) CreateExample(ctx context.Context, tx models.Transaction, example *models.ExampleData) (int, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var id int
+	if err := tx.QueryRowContext(ctx, InsertExampleSql, example.Sentence, example.Translation, example.Audio).Scan(&id); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "CreateExample", err)
+		return 0, fmt.Errorf("failed to create example: %w", err)
+	}
+	return id, nil
+}
+
+// UpdateExample возвращает false, если примера нет.
+func (r *WordRepo) UpdateExample(ctx context.Context, tx models.Transaction, example *models.ExampleData) (bool, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	res, err := tx.ExecContext(ctx, UpdateExampleSql, example.ID, example.Sentence, example.Translation, example.Audio)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "UpdateExample", err)
+		return false, fmt.Errorf("failed to update example: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
+
+func (r *WordRepo) DeleteExample(ctx context.Context, exampleID int) (bool, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	res, err := r.db.ExecContext(ctx, DeleteExampleSql, exampleID)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "DeleteExample", err)
+		return false, fmt.Errorf("failed to delete example: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
@@ -0,0 +1,182 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/lib/pq"
+	"github.com/satori/uuid"
+	"strings"
+)
+
+// UpsertLexiconEntry находит статью с тем же написанием и транскрипцией или создаёт новую.
+// ownerID = uuid.Nil - общая статья, иначе статья из личного набора пользователя.
+// Возвращает сохранённую статью: её id, озвучку и основной перевод.
+func (r *WordRepo) UpsertLexiconEntry(ctx context.Context, tx models.Transaction, ownerID uuid.UUID, entry *models.LexiconEntry) (*models.LexiconEntry, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	translation := ""
+	if len(entry.Translations) > 0 {
+		translation = strings.TrimSpace(entry.Translations[0])
+	}
+	word := strings.TrimSpace(entry.Word)
+	ipa := strings.TrimSpace(entry.IPA)
+
+	var row *sql.Row
+	if uuid.Equal(ownerID, uuid.Nil) {
+		row = tx.QueryRowContext(ctx, UpsertSharedLexiconEntrySql, word, ipa, translation, entry.Audio, pq.Array(entry.Phonemes))
+	} else {
+		row = tx.QueryRowContext(ctx, UpsertOwnedLexiconEntrySql, word, ipa, translation, entry.Audio, ownerID, pq.Array(entry.Phonemes))
+	}
+
+	stored := &models.LexiconEntry{Word: word, IPA: ipa, Translations: []string{""}}
+	if err := row.Scan(&stored.ID, &stored.Audio, &stored.Translations[0]); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "UpsertLexiconEntry", err)
+		return nil, fmt.Errorf("failed to upsert lexicon entry: %w", err)
+	}
+	entryID := stored.ID
+
+	for locale, translation := range entry.LocalizedTranslations {
+		if translation = strings.TrimSpace(translation); translation == "" {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, UpsertLexiconTranslationSql, entryID, locale, translation); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "UpsertLexiconEntry", err)
+			return nil, fmt.Errorf("failed to upsert lexicon translation: %w", err)
+		}
+	}
+
+	return stored, nil
+}
+
+// AttachExerciseEntries связывает упражнение со статьями словаря в порядке следования слов.
+// Если у статьи уже были другие озвучка или основной перевод, переданные значения
+// сохраняются у упражнения и показываются в нём вместо словарных.
+func (r *WordRepo) AttachExerciseEntries(ctx context.Context, tx models.Transaction, exerciseID int, ownerID uuid.UUID, entries []models.LexiconEntry) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	for position := range entries {
+		entry := &entries[position]
+		stored, err := r.UpsertLexiconEntry(ctx, tx, ownerID, entry)
+		if err != nil {
+			return err
+		}
+		entry.ID = stored.ID
+
+		audio := ""
+		if entry.Audio != "" && entry.Audio != stored.Audio {
+			audio = entry.Audio
+		}
+		translation := ""
+		if len(entry.Translations) > 0 {
+			translation = strings.TrimSpace(entry.Translations[0])
+		}
+		if translation == stored.Translations[0] {
+			translation = ""
+		}
+
+		if _, err := tx.ExecContext(ctx, InsertExerciseEntrySql, exerciseID, position, entry.ID, audio, translation); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "AttachExerciseEntries", err)
+			return fmt.Errorf("failed to attach lexicon entry: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetTranscriptionsWithoutPhonemes возвращает пачку транскрипций без разобранных фонем:
+// статей словаря (phrases = false) или фразовых упражнений.
+func (r *WordRepo) GetTranscriptionsWithoutPhonemes(ctx context.Context, phrases bool, afterID, limit int) ([]models.StoredTranscription, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	query := SelectLexiconWithoutPhonemesSql
+	if phrases {
+		query = SelectPhrasesWithoutPhonemesSql
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetTranscriptionsWithoutPhonemes", err)
+		return nil, fmt.Errorf("failed to query transcriptions: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]models.StoredTranscription, 0, limit)
+	for rows.Next() {
+		var t models.StoredTranscription
+		if err := rows.Scan(&t.ID, &t.Transcription); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetTranscriptionsWithoutPhonemes", err)
+			return nil, fmt.Errorf("failed to scan transcription: %w", err)
+		}
+		result = append(result, t)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetTranscriptionsWithoutPhonemes", err)
+		return nil, fmt.Errorf("error after iterating transcriptions: %w", err)
+	}
+
+	return result, nil
+}
+
+// UpdateTranscription сохраняет нормализованную транскрипцию и её фонемы.
+func (r *WordRepo) UpdateTranscription(ctx context.Context, tx models.Transaction, phrases bool, t *models.StoredTranscription) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	query := UpdateLexiconPhonemesSql
+	if phrases {
+		query = UpdatePhrasePhonemesSql
+	}
+
+	if _, err := tx.ExecContext(ctx, query, t.ID, t.Transcription, pq.Array(t.Phonemes)); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "UpdateTranscription", err)
+		return fmt.Errorf("failed to update transcription: %w", err)
+	}
+	return nil
+}
+
+// GetLexiconDuplicate возвращает id другой статьи того же владельца с тем же словом
+// и транскрипцией ipa, 0 - если такой нет.
+func (r *WordRepo) GetLexiconDuplicate(ctx context.Context, tx models.Transaction, entryID int, ipa string) (int, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var duplicateID int
+	err := tx.QueryRowContext(ctx, SelectLexiconDuplicateSql, entryID, ipa).Scan(&duplicateID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetLexiconDuplicate", err)
+		return 0, fmt.Errorf("failed to get lexicon duplicate: %w", err)
+	}
+	return duplicateID, nil
+}
+
+// MergeLexiconEntries переносит статью fromID в intoID: упражнения, переводы, варианты
+// произношения и темы переходят к intoID, после чего fromID удаляется.
+func (r *WordRepo) MergeLexiconEntries(ctx context.Context, tx models.Transaction, fromID, intoID int) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	for _, query := range []string{
+		MergeLexiconFieldsSql,
+		MergeLexiconExerciseEntriesSql,
+		MergeLexiconTranslationsSql,
+		MergeLexiconVariantsSql,
+		MergeLexiconTopicsSql,
+	} {
+		if _, err := tx.ExecContext(ctx, query, fromID, intoID); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "MergeLexiconEntries", err)
+			return fmt.Errorf("failed to merge lexicon entries: %w", err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, DeleteLexiconEntrySql, fromID); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "MergeLexiconEntries", err)
+		return fmt.Errorf("failed to delete merged lexicon entry: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/lib/pq"
+	"github.com/satori/uuid"
+)
+
+// GetPhonemeMasteryForUpdate блокирует строки освоения фонем пользователя до конца транзакции.
+func (r *WordRepo) GetPhonemeMasteryForUpdate(ctx context.Context, tx models.Transaction, userID uuid.UUID, phonemes []string) (map[string]*models.PhonemeMastery, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	rows, err := tx.QueryContext(ctx, SelectPhonemeMasteryForUpdateSql, userID, pq.Array(phonemes))
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetPhonemeMasteryForUpdate", err)
+		return nil, fmt.Errorf("failed to query phoneme mastery: %w", err)
+	}
+	defer rows.Close()
+
+	mastery := make(map[string]*models.PhonemeMastery, len(phonemes))
+	for rows.Next() {
+		var m models.PhonemeMastery
+		if err := rows.Scan(&m.Phoneme, &m.Accuracy, &m.Weight, &m.TrendValue, &m.Attempts, &m.Correct, &m.UpdatedAt); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetPhonemeMasteryForUpdate", err)
+			return nil, fmt.Errorf("failed to scan phoneme mastery: %w", err)
+		}
+		mastery[m.Phoneme] = &m
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetPhonemeMasteryForUpdate", err)
+		return nil, fmt.Errorf("error after iterating phoneme mastery: %w", err)
+	}
+
+	return mastery, nil
+}
+
+func (r *WordRepo) UpsertPhonemeMastery(ctx context.Context, tx models.Transaction, userID uuid.UUID, m *models.PhonemeMastery) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	_, err := tx.ExecContext(ctx, UpsertPhonemeMasterySql,
+		userID,
+		m.Phoneme,
+		m.Accuracy,
+		m.Weight,
+		m.TrendValue,
+		m.Attempts,
+		m.Correct,
+		m.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "UpsertPhonemeMastery", err)
+		return fmt.Errorf("failed to upsert phoneme mastery: %w", err)
+	}
+
+	return nil
+}
+
+// GetUserPhonemes возвращает освоение фонем пользователем с первым советом на языке locale,
+// а если на нём советов нет - на языке по умолчанию.
+func (r *WordRepo) GetUserPhonemes(ctx context.Context, userID uuid.UUID, locale string) ([]models.PhonemeMastery, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, SelectUserPhonemesSql, userID, locale, models.DefaultTipLocale)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetUserPhonemes", err)
+		return nil, fmt.Errorf("failed to query user phonemes: %w", err)
+	}
+	defer rows.Close()
+
+	phonemes := make([]models.PhonemeMastery, 0)
+	for rows.Next() {
+		var m models.PhonemeMastery
+		var tipPhonema, tipText, tipAudio, tipMedia sql.NullString
+
+		if err := rows.Scan(
+			&m.Phoneme,
+			&m.Accuracy,
+			&m.Weight,
+			&m.TrendValue,
+			&m.Attempts,
+			&m.Correct,
+			&m.UpdatedAt,
+			&tipPhonema,
+			&tipText,
+			&tipAudio,
+			&tipMedia,
+		); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetUserPhonemes", err)
+			return nil, fmt.Errorf("failed to scan user phoneme: %w", err)
+		}
+		if tipPhonema.Valid {
+			m.Tip = &models.TipData{
+				Phonema:      tipPhonema.String,
+				TipText:      tipText.String,
+				TipAudioLink: tipAudio.String,
+				TipMediaLink: tipMedia.String,
+			}
+		}
+
+		phonemes = append(phonemes, m)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetUserPhonemes", err)
+		return nil, fmt.Errorf("error after iterating user phonemes: %w", err)
+	}
+
+	r.logger.LogInfo(requestId, logger.RepositoryLayer, "GetUserPhonemes",
+		fmt.Sprintf("retrieved %d phonemes", len(phonemes)))
+
+	return phonemes, nil
+}
@@ -0,0 +1,220 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/lib/pq"
+	"github.com/satori/uuid"
+)
+
+// GetPlacementModuleIDs возвращает общие модули по порядку курса, в которых есть хотя бы
+// minItems заданий на произношение или угадывание слова.
+func (r *WordRepo) GetPlacementModuleIDs(ctx context.Context, tx models.Transaction, minItems int) ([]int, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	rows, err := tx.QueryContext(ctx, SelectPlacementModuleIDsSql, minItems)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetPlacementModuleIDs", err)
+		return nil, fmt.Errorf("failed to query word modules: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make([]int, 0)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetPlacementModuleIDs", err)
+			return nil, fmt.Errorf("failed to scan word module: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetPlacementModuleIDs", err)
+		return nil, fmt.Errorf("error after iterating word modules: %w", err)
+	}
+
+	return ids, nil
+}
+
+// AbandonPlacementTests закрывает незаконченные тесты пользователя перед стартом нового.
+func (r *WordRepo) AbandonPlacementTests(ctx context.Context, tx models.Transaction, userID uuid.UUID) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	if _, err := tx.ExecContext(ctx, AbandonPlacementTestsSql, userID); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "AbandonPlacementTests", err)
+		return fmt.Errorf("failed to abandon placement tests: %w", err)
+	}
+	return nil
+}
+
+func (r *WordRepo) CreatePlacementTest(ctx context.Context, tx models.Transaction, test *models.PlacementTest) (int, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var id int
+	err := tx.QueryRowContext(ctx, InsertPlacementTestSql, test.UserID, pq.Array(test.ModuleIDs), test.Low, test.High).Scan(&id)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "CreatePlacementTest", err)
+		return 0, fmt.Errorf("failed to create placement test: %w", err)
+	}
+
+	r.logger.LogInfo(requestId, logger.RepositoryLayer, "CreatePlacementTest", fmt.Sprintf("created placement test %d", id))
+	return id, nil
+}
+
+// GetPlacementTest возвращает nil, если теста нет или он принадлежит другому пользователю.
+// С forUpdate строка блокируется до конца транзакции.
+func (r *WordRepo) GetPlacementTest(ctx context.Context, tx models.Transaction, testID int, userID uuid.UUID, forUpdate bool) (*models.PlacementTest, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	query := SelectPlacementTestSql
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
+
+	var test models.PlacementTest
+	var moduleIDs pq.Int64Array
+	var currentExercise, startModule sql.NullInt64
+	var finishedAt sql.NullTime
+
+	err := tx.QueryRowContext(ctx, query, testID, userID).Scan(
+		&test.ID,
+		&test.UserID,
+		&test.Status,
+		&moduleIDs,
+		&test.Low,
+		&test.High,
+		&test.ProbeItems,
+		&test.ProbePassed,
+		&test.Answered,
+		&test.Correct,
+		&currentExercise,
+		&startModule,
+		&finishedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetPlacementTest", err)
+		return nil, fmt.Errorf("failed to get placement test: %w", err)
+	}
+
+	test.ModuleIDs = make([]int, len(moduleIDs))
+	for i, id := range moduleIDs {
+		test.ModuleIDs[i] = int(id)
+	}
+	if currentExercise.Valid {
+		id := int(currentExercise.Int64)
+		test.CurrentExerciseID = &id
+	}
+	if startModule.Valid {
+		id := int(startModule.Int64)
+		test.StartModuleID = &id
+	}
+	if finishedAt.Valid {
+		test.FinishedAt = &finishedAt.Time
+	}
+
+	return &test, nil
+}
+
+func (r *WordRepo) UpdatePlacementTest(ctx context.Context, tx models.Transaction, test *models.PlacementTest) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	_, err := tx.ExecContext(ctx, UpdatePlacementTestSql,
+		test.ID,
+		test.Status,
+		test.Low,
+		test.High,
+		test.ProbeItems,
+		test.ProbePassed,
+		test.Answered,
+		test.Correct,
+		test.CurrentExerciseID,
+		test.StartModuleID,
+		test.FinishedAt,
+	)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "UpdatePlacementTest", err)
+		return fmt.Errorf("failed to update placement test: %w", err)
+	}
+	return nil
+}
+
+// GetPlacementItem выбирает ещё не показанное в тесте упражнение модуля, предпочитая тип preferType.
+// Возвращает nil, если подходящих упражнений не осталось.
+func (r *WordRepo) GetPlacementItem(ctx context.Context, tx models.Transaction, testID, moduleID int, preferType string) (*models.Exercise, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var exercise models.Exercise
+	var words, transcriptions, audio, translations pq.StringArray
+
+	err := tx.QueryRowContext(ctx, SelectPlacementItemSql, moduleID, testID, preferType).Scan(
+		&exercise.ID,
+		&exercise.ExerciseType,
+		&words,
+		&transcriptions,
+		&audio,
+		&translations,
+		&exercise.ModuleId,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetPlacementItem", err)
+		return nil, fmt.Errorf("failed to get placement item: %w", err)
+	}
+
+	exercise.Words = words
+	exercise.Transcriptions = transcriptions
+	exercise.Audio = audio
+	exercise.Translations = translations
+	exercise.Status = "none"
+
+	return &exercise, nil
+}
+
+func (r *WordRepo) InsertPlacementAnswer(ctx context.Context, tx models.Transaction, testID int, exercise *models.Exercise, passed bool, score *int) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	if _, err := tx.ExecContext(ctx, InsertPlacementAnswerSql, testID, exercise.ID, exercise.ModuleId, passed, score); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "InsertPlacementAnswer", err)
+		return fmt.Errorf("failed to insert placement answer: %w", err)
+	}
+	return nil
+}
+
+// SkipWordModules помечает упражнения модулей пропущенными, не трогая уже пройденные.
+func (r *WordRepo) SkipWordModules(ctx context.Context, tx models.Transaction, userID uuid.UUID, moduleIDs []int) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	if len(moduleIDs) == 0 {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, SkipWordModulesProgressSql, userID, pq.Array(moduleIDs)); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "SkipWordModules", err)
+		return fmt.Errorf("failed to skip word modules: %w", err)
+	}
+	return nil
+}
+
+func (r *WordRepo) UpdateUserStartModule(ctx context.Context, tx models.Transaction, userID uuid.UUID, moduleID int) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	if _, err := tx.ExecContext(ctx, UpdateUserStartModuleSql, userID, moduleID); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "UpdateUserStartModule", err)
+		return fmt.Errorf("failed to update user start module: %w", err)
+	}
+
+	r.logger.LogInfo(requestId, logger.RepositoryLayer, "UpdateUserStartModule",
+		fmt.Sprintf("start word module set to %d", moduleID))
+	return nil
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/TeaStealers-backend-sem4/pkg/logger"
 	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
 	"github.com/lib/pq"
+	"github.com/satori/uuid"
+	"strings"
 )
 
 type WordRepo struct {
@@ -29,28 +31,36 @@ func (r *WordRepo) BeginTx(ctx context.Context) (models.Transaction, error) {
 }
 
 func (r *WordRepo) CreateWordExercise(ctx context.Context, tx models.Transaction, wordCreate *models.CreateWordData) (int, error) {
-	requestId := utils.GetRequestIDFromCtx(ctx)
+	return r.createWordExercise(ctx, tx, wordCreate.Exercise, wordCreate.ModuleId, []models.LexiconEntry{{
+		Word:         wordCreate.Word,
+		IPA:          wordCreate.Transcription,
+		Translations: []string{wordCreate.Translation},
+		Audio:        wordCreate.AudioLink,
+		Phonemes:     wordCreate.Phonemes,
+
+		LocalizedTranslations: wordCreate.LocalizedTranslations,
+	}})
+}
 
-	wordsArray := pq.Array([]string{wordCreate.Word})
-	transcriptionsArray := pq.Array([]string{wordCreate.Transcription})
-	translationsArray := pq.Array([]string{wordCreate.Translation})
-	audioArray := pq.Array([]string{wordCreate.AudioLink})
+// createWordExercise создаёт упражнение общего модуля и привязывает к нему статьи словаря.
+// Возвращает 0, если общего модуля moduleID нет: личные наборы так не пополняются.
+func (r *WordRepo) createWordExercise(ctx context.Context, tx models.Transaction, exercise string, moduleID *int, entries []models.LexiconEntry) (int, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
 
 	var lastInsertID int
-	err := tx.QueryRowContext(ctx, CreateWordExerciseSql,
-		wordCreate.Exercise,
-		wordsArray,
-		transcriptionsArray,
-		audioArray,
-		translationsArray,
-		wordCreate.ModuleId,
-	).Scan(&lastInsertID)
-
+	err := tx.QueryRowContext(ctx, CreateWordExerciseSql, exercise, moduleID).Scan(&lastInsertID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
 	if err != nil {
 		r.logger.LogError(requestId, logger.RepositoryLayer, "CreateWordExercise", err)
 		return 0, fmt.Errorf("failed to create word exercise: %w", err)
 	}
 
+	if err := r.AttachExerciseEntries(ctx, tx, lastInsertID, uuid.Nil, entries); err != nil {
+		return 0, err
+	}
+
 	r.logger.LogInfo(requestId, logger.RepositoryLayer, "CreateWordExercise", "word exercise created")
 	return lastInsertID, nil
 }
@@ -80,6 +90,7 @@ func (r *WordRepo) CreatePhraseExercise(ctx context.Context, tx models.Transacti
 		phraseCreate.AudioLink,
 		pq.Array(phraseCreate.Chain),
 		phraseCreate.ModuleId,
+		pq.Array(phraseCreate.Phonemes),
 	).Scan(&lastInsertID)
 
 	if err != nil {
@@ -87,6 +98,16 @@ func (r *WordRepo) CreatePhraseExercise(ctx context.Context, tx models.Transacti
 		return 0, fmt.Errorf("failed to create phrase exercise: %w", err)
 	}
 
+	for locale, translation := range phraseCreate.LocalizedTranslations {
+		if translation = strings.TrimSpace(translation); translation == "" {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, UpsertPhraseTranslationSql, lastInsertID, locale, translation); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "CreatePhraseExercise", err)
+			return 0, fmt.Errorf("failed to save phrase translation: %w", err)
+		}
+	}
+
 	r.logger.LogInfo(requestId, logger.RepositoryLayer, "CreatePhraseExercise", "phrase exercise created")
 	return lastInsertID, nil
 }
@@ -145,37 +166,36 @@ func (r *WordRepo) CreateOrUpdateExerciseProgress(
 	return lastInsertID, nil
 }
 
+// CreateWordExerciseList ожидает списки одинаковой длины: i-е элементы описывают одно слово.
 func (r *WordRepo) CreateWordExerciseList(ctx context.Context, tx models.Transaction, wordCreate *models.CreateWordDataList) (int, error) {
-	requestId := utils.GetRequestIDFromCtx(ctx)
-
-	wordsArray := pq.Array(wordCreate.Word)
-	transcriptionsArray := pq.Array(wordCreate.Transcription)
-	translationsArray := pq.Array(wordCreate.Translation)
-	audioArray := pq.Array(wordCreate.AudioLink)
-
-	var lastInsertID int
-	err := tx.QueryRowContext(ctx, CreateWordExerciseSql,
-		wordCreate.Exercise,
-		wordsArray,
-		transcriptionsArray,
-		audioArray,
-		translationsArray,
-		wordCreate.ModuleId,
-	).Scan(&lastInsertID)
-
-	if err != nil {
-		r.logger.LogError(requestId, logger.RepositoryLayer, "CreateWordExercise", err)
-		return 0, fmt.Errorf("failed to create word exercise: %w", err)
+	entries := make([]models.LexiconEntry, len(wordCreate.Word))
+	for i := range wordCreate.Word {
+		entries[i] = models.LexiconEntry{
+			Word:         wordCreate.Word[i],
+			IPA:          wordCreate.Transcription[i],
+			Translations: []string{wordCreate.Translation[i]},
+			Audio:        wordCreate.AudioLink[i],
+		}
+		if i < len(wordCreate.Phonemes) {
+			entries[i].Phonemes = wordCreate.Phonemes[i]
+		}
+		for locale, translations := range wordCreate.LocalizedTranslations {
+			if i < len(translations) {
+				if entries[i].LocalizedTranslations == nil {
+					entries[i].LocalizedTranslations = make(map[string]string)
+				}
+				entries[i].LocalizedTranslations[locale] = translations[i]
+			}
+		}
 	}
 
-	r.logger.LogInfo(requestId, logger.RepositoryLayer, "CreateWordExercise", "word exercise created")
-	return lastInsertID, nil
+	return r.createWordExercise(ctx, tx, wordCreate.Exercise, wordCreate.ModuleId, entries)
 }
 
-func (r *WordRepo) GetPhraseModules(ctx context.Context) (*models.ModuleList, error) {
+func (r *WordRepo) GetPhraseModules(ctx context.Context, topic string) (*models.ModuleList, error) {
 	requestId := utils.GetRequestIDFromCtx(ctx)
 
-	rows, err := r.db.QueryContext(ctx, SelectPhraseModulesSql)
+	rows, err := r.db.QueryContext(ctx, SelectPhraseModulesSql, topic)
 	if err != nil {
 		r.logger.LogError(requestId, logger.RepositoryLayer, "GetPhraseModules", err)
 		return nil, fmt.Errorf("failed to get phrase modules: %w", err)
@@ -203,10 +223,10 @@ func (r *WordRepo) GetPhraseModules(ctx context.Context) (*models.ModuleList, er
 	return &models.ModuleList{Modules: modules}, nil
 }
 
-func (r *WordRepo) GetWordModules(ctx context.Context) (*models.ModuleList, error) {
+func (r *WordRepo) GetWordModules(ctx context.Context, topic string) (*models.ModuleList, error) {
 	requestId := utils.GetRequestIDFromCtx(ctx)
 
-	rows, err := r.db.QueryContext(ctx, SelectWordModulesSql)
+	rows, err := r.db.QueryContext(ctx, SelectWordModulesSql, topic)
 	if err != nil {
 		r.logger.LogError(requestId, logger.RepositoryLayer, "GetWordModules", err)
 		return nil, fmt.Errorf("failed to get word modules: %w", err)
@@ -234,23 +254,10 @@ func (r *WordRepo) GetWordModules(ctx context.Context) (*models.ModuleList, erro
 	return &models.ModuleList{Modules: modules}, nil
 }
 
-func (r *WordRepo) UploadTip(ctx context.Context, tx models.Transaction, data *models.TipData) error {
+func (r *WordRepo) GetTip(ctx context.Context, data *models.TipData) (*models.TipData, error) {
 	requestId := utils.GetRequestIDFromCtx(ctx)
 
-	_, err := r.db.ExecContext(ctx, InsertWordTip, data.Phonema, data.TipText, data.TipAudioLink, data.TipMediaLink)
-	if err != nil {
-		r.logger.LogError(requestId, logger.RepositoryLayer, "UploadTip", err)
-		return err
-	}
-
-	r.logger.LogInfo(requestId, logger.RepositoryLayer, "UploadTip", "tip uploaded successfully")
-	return nil
-}
-
-func (r *WordRepo) GetTip(ctx context.Context, tx models.Transaction, data *models.TipData) (*models.TipData, error) {
-	requestId := utils.GetRequestIDFromCtx(ctx)
-
-	res := r.db.QueryRow(SelectWordTip, data.Phonema)
+	res := r.db.QueryRow(SelectWordTip, data.Phonema, models.DefaultTipLocale)
 
 	gotTip := &models.TipData{}
 
@@ -284,7 +291,7 @@ func (r *WordRepo) GetWordModuleExercises(ctx context.Context, userID string, mo
 	var exercises []models.Exercise
 	for rows.Next() {
 		var exercise models.Exercise
-		var words, transcriptions, audio, translations pq.StringArray
+		var words, transcriptions, audio, translations, phonemes pq.StringArray
 
 		if err := rows.Scan(
 			&exercise.ID,
@@ -295,6 +302,7 @@ func (r *WordRepo) GetWordModuleExercises(ctx context.Context, userID string, mo
 			&translations,
 			&exercise.ModuleId,
 			&exercise.Status,
+			&phonemes,
 		); err != nil {
 			r.logger.LogError(requestId, logger.RepositoryLayer, "GetWordModuleExercises", err)
 			return nil, fmt.Errorf("failed to scan word exercise: %w", err)
@@ -304,6 +312,10 @@ func (r *WordRepo) GetWordModuleExercises(ctx context.Context, userID string, mo
 		exercise.Transcriptions = transcriptions
 		exercise.Audio = audio
 		exercise.Translations = translations
+		exercise.Phonemes = make([][]string, len(phonemes))
+		for i, wordPhonemes := range phonemes {
+			exercise.Phonemes[i] = strings.Fields(wordPhonemes)
+		}
 
 		exercises = append(exercises, exercise)
 	}
@@ -421,3 +433,78 @@ func (r *WordRepo) GetIncompleteWordModule(ctx context.Context, userID string) (
 
 	return &module, nil
 }
+
+func (r *WordRepo) GetWordExercise(ctx context.Context, userID uuid.UUID, exerciseID int) (*models.Exercise, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var exercise models.Exercise
+	var words, transcriptions, audio, translations, phonemes pq.StringArray
+
+	err := r.db.QueryRowContext(ctx, GetWordExerciseSql, exerciseID, userID).Scan(
+		&exercise.ID,
+		&exercise.ExerciseType,
+		&words,
+		&transcriptions,
+		&audio,
+		&translations,
+		&exercise.ModuleId,
+		&phonemes,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			r.logger.LogInfo(requestId, logger.RepositoryLayer, "GetWordExercise", "word exercise not found")
+			return nil, nil
+		}
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetWordExercise", err)
+		return nil, fmt.Errorf("failed to get word exercise: %w", err)
+	}
+
+	exercise.Words = words
+	exercise.Transcriptions = transcriptions
+	exercise.Audio = audio
+	exercise.Translations = translations
+	exercise.Phonemes = make([][]string, len(phonemes))
+	for i, wordPhonemes := range phonemes {
+		exercise.Phonemes[i] = strings.Fields(wordPhonemes)
+	}
+
+	return &exercise, nil
+}
+
+func (r *WordRepo) GetPhraseExercise(ctx context.Context, exerciseID int) (*models.Exercise, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var exercise models.Exercise
+	var sentence, translate, transcription sql.NullString
+	var audio string
+	var chain, phonemes pq.StringArray
+
+	err := r.db.QueryRowContext(ctx, GetPhraseExerciseSql, exerciseID).Scan(
+		&exercise.ID,
+		&exercise.ExerciseType,
+		&sentence,
+		&translate,
+		&transcription,
+		&audio,
+		&chain,
+		&exercise.ModuleId,
+		&phonemes,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			r.logger.LogInfo(requestId, logger.RepositoryLayer, "GetPhraseExercise", "phrase exercise not found")
+			return nil, nil
+		}
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetPhraseExercise", err)
+		return nil, fmt.Errorf("failed to get phrase exercise: %w", err)
+	}
+
+	exercise.Words = []string{sentence.String}
+	exercise.Translations = []string{translate.String}
+	exercise.Transcriptions = []string{transcription.String}
+	exercise.Audio = []string{audio}
+	exercise.Chain = chain
+	exercise.Phonemes = [][]string{phonemes}
+
+	return &exercise, nil
+}
@@ -0,0 +1,72 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/lib/pq"
+	"github.com/satori/uuid"
+)
+
+// GetRecommendationCandidates возвращает незавершённые упражнения и упражнения, которые пора
+// повторить. Повторения и недавно проваленные идут первыми, чтобы не потеряться за лимитом.
+func (r *WordRepo) GetRecommendationCandidates(ctx context.Context, userID uuid.UUID, limit int) ([]models.RecommendationCandidate, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, SelectRecommendationCandidatesSql, userID, limit)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetRecommendationCandidates", err)
+		return nil, fmt.Errorf("failed to query recommendation candidates: %w", err)
+	}
+	defer rows.Close()
+
+	candidates := make([]models.RecommendationCandidate, 0)
+	for rows.Next() {
+		var c models.RecommendationCandidate
+		var words, transcriptions, audio, translations pq.StringArray
+		var reviewID sql.NullInt64
+		var dueAt sql.NullTime
+
+		if err := rows.Scan(
+			&c.Exercise.ID,
+			&c.Exercise.ExerciseType,
+			&words,
+			&transcriptions,
+			&audio,
+			&translations,
+			&c.Exercise.ModuleId,
+			&c.Exercise.Status,
+			&c.Attempts,
+			&c.RecentFailures,
+			&reviewID,
+			&dueAt,
+		); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetRecommendationCandidates", err)
+			return nil, fmt.Errorf("failed to scan recommendation candidate: %w", err)
+		}
+
+		c.Exercise.Words = words
+		c.Exercise.Transcriptions = transcriptions
+		c.Exercise.Audio = audio
+		c.Exercise.Translations = translations
+		if reviewID.Valid {
+			id := int(reviewID.Int64)
+			c.ReviewID = &id
+		}
+		if dueAt.Valid {
+			c.DueAt = &dueAt.Time
+		}
+
+		candidates = append(candidates, c)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetRecommendationCandidates", err)
+		return nil, fmt.Errorf("error after iterating recommendation candidates: %w", err)
+	}
+
+	return candidates, nil
+}
@@ -0,0 +1,181 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/lib/pq"
+	"github.com/satori/uuid"
+)
+
+// AddReviewItem ставит упражнение в очередь повторения; повторный вызов ничего не меняет.
+func (r *WordRepo) AddReviewItem(ctx context.Context, tx models.Transaction, userID uuid.UUID, exerciseID int, exerciseType string) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	if _, err := tx.ExecContext(ctx, InsertReviewItemSql, userID, exerciseID, exerciseType); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "AddReviewItem", err)
+		return fmt.Errorf("failed to add review item: %w", err)
+	}
+	return nil
+}
+
+// GetReviewSettings возвращает nil, если пользователь не менял лимиты.
+func (r *WordRepo) GetReviewSettings(ctx context.Context, userID uuid.UUID) (*models.ReviewSettings, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var newPerDay, reviewsPerDay int
+	err := r.db.QueryRowContext(ctx, SelectReviewSettingsSql, userID).Scan(&newPerDay, &reviewsPerDay)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetReviewSettings", err)
+		return nil, fmt.Errorf("failed to get review settings: %w", err)
+	}
+
+	return &models.ReviewSettings{NewPerDay: &newPerDay, ReviewsPerDay: &reviewsPerDay}, nil
+}
+
+func (r *WordRepo) UpsertReviewSettings(ctx context.Context, userID uuid.UUID, settings *models.ReviewSettings) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	if _, err := r.db.ExecContext(ctx, UpsertReviewSettingsSql, userID, *settings.NewPerDay, *settings.ReviewsPerDay); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "UpsertReviewSettings", err)
+		return fmt.Errorf("failed to save review settings: %w", err)
+	}
+
+	r.logger.LogInfo(requestId, logger.RepositoryLayer, "UpsertReviewSettings", "review settings saved")
+	return nil
+}
+
+// CountReviewsToday возвращает количество новых карточек и повторений, пройденных сегодня.
+func (r *WordRepo) CountReviewsToday(ctx context.Context, userID uuid.UUID) (int, int, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var newCount, reviewCount int
+	if err := r.db.QueryRowContext(ctx, CountReviewsTodaySql, userID).Scan(&newCount, &reviewCount); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "CountReviewsToday", err)
+		return 0, 0, fmt.Errorf("failed to count today reviews: %w", err)
+	}
+	return newCount, reviewCount, nil
+}
+
+// GetDueReviewExercises возвращает упражнения, которые пора повторить: только новые
+// карточки при onlyNew = true, иначе только уже повторявшиеся.
+func (r *WordRepo) GetDueReviewExercises(ctx context.Context, userID uuid.UUID, onlyNew bool, limit int) ([]models.Exercise, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	exercises := make([]models.Exercise, 0)
+	if limit <= 0 {
+		return exercises, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, SelectDueReviewExercisesSql, userID, onlyNew, limit)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetDueReviewExercises", err)
+		return nil, fmt.Errorf("failed to query due reviews: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var exercise models.Exercise
+		var reviewID int
+		var words, transcriptions, audio, translations pq.StringArray
+
+		if err := rows.Scan(
+			&reviewID,
+			&exercise.ID,
+			&exercise.ExerciseType,
+			&words,
+			&transcriptions,
+			&audio,
+			&translations,
+			&exercise.ModuleId,
+			&exercise.Status,
+		); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetDueReviewExercises", err)
+			return nil, fmt.Errorf("failed to scan due review: %w", err)
+		}
+
+		exercise.ReviewID = &reviewID
+		exercise.Words = words
+		exercise.Transcriptions = transcriptions
+		exercise.Audio = audio
+		exercise.Translations = translations
+
+		exercises = append(exercises, exercise)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetDueReviewExercises", err)
+		return nil, fmt.Errorf("error after iterating due reviews: %w", err)
+	}
+
+	return exercises, nil
+}
+
+// GetReviewItemForUpdate возвращает nil, если карточки нет или она принадлежит другому пользователю.
+func (r *WordRepo) GetReviewItemForUpdate(ctx context.Context, tx models.Transaction, reviewID int, userID uuid.UUID) (*models.ReviewItem, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var item models.ReviewItem
+	var lastReviewed sql.NullTime
+	err := tx.QueryRowContext(ctx, SelectReviewItemForUpdateSql, reviewID, userID).Scan(
+		&item.ID,
+		&item.UserID,
+		&item.ExerciseID,
+		&item.ExerciseType,
+		&item.Ease,
+		&item.IntervalDays,
+		&item.Repetitions,
+		&item.Lapses,
+		&item.DueAt,
+		&lastReviewed,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetReviewItemForUpdate", err)
+		return nil, fmt.Errorf("failed to get review item: %w", err)
+	}
+	if lastReviewed.Valid {
+		item.LastReviewedAt = &lastReviewed.Time
+	}
+
+	return &item, nil
+}
+
+func (r *WordRepo) UpdateReviewItem(ctx context.Context, tx models.Transaction, item *models.ReviewItem) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	_, err := tx.ExecContext(ctx, UpdateReviewItemSql,
+		item.ID,
+		item.Ease,
+		item.IntervalDays,
+		item.Repetitions,
+		item.Lapses,
+		item.DueAt,
+		item.LastReviewedAt,
+	)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "UpdateReviewItem", err)
+		return fmt.Errorf("failed to update review item: %w", err)
+	}
+	return nil
+}
+
+func (r *WordRepo) InsertReviewLog(ctx context.Context, tx models.Transaction, item *models.ReviewItem, grade int, wasNew bool) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	_, err := tx.ExecContext(ctx, InsertReviewLogSql, item.ID, item.UserID, grade, wasNew, item.IntervalDays, item.LastReviewedAt)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "InsertReviewLog", err)
+		return fmt.Errorf("failed to insert review log: %w", err)
+	}
+	return nil
+}
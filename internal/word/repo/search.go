@@ -0,0 +1,72 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/lib/pq"
+	"strings"
+)
+
+// Search ищет материалы, видимые пользователю query.UserID, и возвращает страницу результатов
+// вместе с общим числом найденного.
+func (r *WordRepo) Search(ctx context.Context, query *models.SearchQuery) ([]models.SearchResult, int, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	types := query.Types
+	if types == nil {
+		types = []string{}
+	}
+
+	statement, pattern := SearchTextSql, query.Text
+	if query.Mode == models.SearchModeIPA {
+		statement, pattern = SearchPhonemesSql, " "+strings.Join(query.Phonemes, " ")+" "
+	}
+
+	rows, err := r.db.QueryContext(ctx, statement, pattern, query.UserID, pq.Array(types), query.Limit, query.Offset)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "Search", err)
+		return nil, 0, fmt.Errorf("failed to search: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]models.SearchResult, 0)
+	total := 0
+	for rows.Next() {
+		var result models.SearchResult
+		var kind sql.NullString
+		var moduleID sql.NullInt64
+		if err := rows.Scan(
+			&kind,
+			&result.ID,
+			&moduleID,
+			&result.Title,
+			&result.Subtitle,
+			&result.Transcription,
+			&result.Score,
+			&total,
+		); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "Search", err)
+			return nil, 0, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		if !kind.Valid {
+			continue
+		}
+		result.Type = kind.String
+		if moduleID.Valid {
+			id := int(moduleID.Int64)
+			result.ModuleID = &id
+		}
+		results = append(results, result)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "Search", err)
+		return nil, 0, fmt.Errorf("error after iterating search results: %w", err)
+	}
+
+	return results, total, nil
+}
@@ -19,27 +19,31 @@ const (
         JOIN word_exercises e ON e.module_id = m.id
         LEFT JOIN exercise_progress p 
             ON p.exercise_id = e.id AND p.exercise_type = 'word' AND p.user_id = $1
+        WHERE m.owner_id IS NULL
         GROUP BY m.id
-        HAVING COUNT(*) FILTER (WHERE p.status = 'completed') < COUNT(*)
+        HAVING COUNT(*) FILTER (WHERE p.status IN ('completed', 'skipped')) < COUNT(*)
         ORDER BY m.id
         LIMIT 1
     `
 
 	GetWordModuleExercisesWithProgressSql = `
         SELECT e.id, e.exercise_type, e.words, e.transcriptions, e.audio, e.translations, e.module_id,
-               COALESCE(p.status, 'none') AS status
-        FROM word_exercises e
+               COALESCE(p.status, 'none') AS status, e.phonemes
+        FROM word_exercise_cards e
+        JOIN word_modules m ON m.id = e.module_id
         LEFT JOIN exercise_progress p 
             ON p.exercise_id = e.id AND p.exercise_type = 'word' AND p.user_id = $1
-        WHERE e.module_id = $2
+        WHERE e.module_id = $2 AND (m.owner_id IS NULL OR m.owner_id = $1)
         ORDER BY e.id
     `
 
 	GetWordModuleExercisesSql = `
-        SELECT id, exercise_type, words, transcriptions, audio, translations, module_id, 'none' AS status
-        FROM word_exercises
-        WHERE module_id = $1
-        ORDER BY id
+        SELECT e.id, e.exercise_type, e.words, e.transcriptions, e.audio, e.translations, e.module_id, 'none' AS status,
+               e.phonemes
+        FROM word_exercise_cards e
+        JOIN word_modules m ON m.id = e.module_id
+        WHERE e.module_id = $1 AND m.owner_id IS NULL
+        ORDER BY e.id
     `
 
 	GetPhraseModuleExercisesWithProgressSql = `
@@ -59,30 +63,339 @@ const (
         ORDER BY id
     `
 
+	// упражнения из личных наборов видны только владельцу; $2 = uuid.Nil - только общие
+	GetWordExerciseSql = `
+        SELECT e.id, e.exercise_type, e.words, e.transcriptions, e.audio, e.translations, e.module_id, e.phonemes
+        FROM word_exercise_cards e
+        LEFT JOIN word_modules m ON m.id = e.module_id
+        WHERE e.id = $1 AND (m.owner_id IS NULL OR m.owner_id = $2)
+    `
+
+	GetPhraseExerciseSql = `
+        SELECT id, exercise_type, sentence, translate, transcription, audio, chain, module_id, phonemes
+        FROM phrase_exercises
+        WHERE id = $1
+    `
+
+	SelectPhonemeMasteryForUpdateSql = `
+        SELECT phoneme, accuracy, weight, trend, attempts, correct, updated_at
+        FROM user_phoneme_mastery
+        WHERE user_id = $1 AND phoneme = ANY($2)
+        FOR UPDATE
+    `
+
+	UpsertPhonemeMasterySql = `
+        INSERT INTO user_phoneme_mastery (user_id, phoneme, accuracy, weight, trend, attempts, correct, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        ON CONFLICT (user_id, phoneme)
+        DO UPDATE SET accuracy = EXCLUDED.accuracy,
+                      weight = EXCLUDED.weight,
+                      trend = EXCLUDED.trend,
+                      attempts = EXCLUDED.attempts,
+                      correct = EXCLUDED.correct,
+                      updated_at = EXCLUDED.updated_at
+    `
+
+	SelectUserPhonemesSql = `
+        SELECT m.phoneme, m.accuracy, m.weight, m.trend, m.attempts, m.correct, m.updated_at,
+               t.phonema, t.tip_text, t.tip_audio_link, t.tip_video_link
+        FROM user_phoneme_mastery m
+        LEFT JOIN LATERAL (
+            SELECT phonema, tip_text, tip_audio_link, tip_video_link
+            FROM word_tip
+            WHERE phonema = m.phoneme AND locale IN ($2, $3)
+            ORDER BY locale = $2 DESC, position, id
+            LIMIT 1
+        ) t ON true
+        WHERE m.user_id = $1
+        ORDER BY m.accuracy, m.phoneme
+    `
+
+	InsertReviewItemSql = `
+        INSERT INTO review_items (user_id, exercise_id, exercise_type, due_at)
+        VALUES ($1, $2, $3, CURRENT_TIMESTAMP + INTERVAL '1 day')
+        ON CONFLICT (user_id, exercise_id, exercise_type) DO NOTHING
+    `
+
+	SelectReviewSettingsSql = `
+        SELECT new_per_day, reviews_per_day
+        FROM user_review_settings
+        WHERE user_id = $1
+    `
+
+	UpsertReviewSettingsSql = `
+        INSERT INTO user_review_settings (user_id, new_per_day, reviews_per_day)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (user_id)
+        DO UPDATE SET new_per_day = EXCLUDED.new_per_day, reviews_per_day = EXCLUDED.reviews_per_day
+    `
+
+	CountReviewsTodaySql = `
+        SELECT COUNT(*) FILTER (WHERE was_new), COUNT(*) FILTER (WHERE NOT was_new)
+        FROM review_log
+        WHERE user_id = $1 AND reviewed_at >= date_trunc('day', CURRENT_TIMESTAMP)
+    `
+
+	SelectDueReviewExercisesSql = `
+        SELECT r.id, e.id, e.exercise_type, e.words, e.transcriptions, e.audio, e.translations, e.module_id,
+               COALESCE(p.status, 'none') AS status
+        FROM review_items r
+        JOIN word_exercise_cards e ON e.id = r.exercise_id
+        LEFT JOIN word_modules m ON m.id = e.module_id
+        LEFT JOIN exercise_progress p
+            ON p.exercise_id = e.id AND p.exercise_type = 'word' AND p.user_id = r.user_id
+        WHERE r.user_id = $1 AND r.exercise_type = 'word'
+          AND (m.owner_id IS NULL OR m.owner_id = $1)
+          AND r.due_at <= CURRENT_TIMESTAMP
+          AND (r.last_reviewed_at IS NULL) = $2
+        ORDER BY r.due_at, r.id
+        LIMIT $3
+    `
+
+	SelectReviewItemForUpdateSql = `
+        SELECT id, user_id, exercise_id, exercise_type, ease, interval_days, repetitions, lapses, due_at, last_reviewed_at
+        FROM review_items
+        WHERE id = $1 AND user_id = $2
+        FOR UPDATE
+    `
+
+	UpdateReviewItemSql = `
+        UPDATE review_items
+        SET ease = $2, interval_days = $3, repetitions = $4, lapses = $5, due_at = $6, last_reviewed_at = $7
+        WHERE id = $1
+    `
+
+	InsertReviewLogSql = `
+        INSERT INTO review_log (review_id, user_id, grade, was_new, interval_days, reviewed_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+    `
+
+	SelectRecommendationCandidatesSql = `
+        WITH failures AS (
+            SELECT exercise_id, COUNT(*) AS failed
+            FROM exercise_attempts
+            WHERE user_id = $1 AND exercise_type = 'word' AND result = 'failed'
+              AND created_at >= CURRENT_TIMESTAMP - INTERVAL '7 days'
+            GROUP BY exercise_id
+        )
+        SELECT e.id, e.exercise_type, e.words, e.transcriptions, e.audio, e.translations, e.module_id,
+               COALESCE(p.status, 'none') AS status, COALESCE(p.attempts, 0), COALESCE(f.failed, 0),
+               r.id, r.due_at
+        FROM word_exercise_cards e
+        LEFT JOIN word_modules m ON m.id = e.module_id
+        LEFT JOIN exercise_progress p
+            ON p.exercise_id = e.id AND p.exercise_type = 'word' AND p.user_id = $1
+        LEFT JOIN review_items r
+            ON r.exercise_id = e.id AND r.exercise_type = 'word' AND r.user_id = $1
+        LEFT JOIN failures f ON f.exercise_id = e.id
+        WHERE (m.owner_id IS NULL OR m.owner_id = $1)
+          AND (COALESCE(p.status, 'none') NOT IN ('completed', 'skipped') OR r.due_at <= CURRENT_TIMESTAMP)
+        ORDER BY (f.failed IS NOT NULL OR r.due_at <= CURRENT_TIMESTAMP) DESC, e.module_id, e.id
+        LIMIT $2
+    `
+
+	SelectPlacementModuleIDsSql = `
+        SELECT m.id
+        FROM word_modules m
+        WHERE m.owner_id IS NULL
+          AND (SELECT COUNT(*) FROM word_exercise_cards e
+               WHERE e.module_id = m.id AND e.exercise_type IN ('pronounce', 'guessWord')) >= $1
+        ORDER BY m.id
+    `
+
+	AbandonPlacementTestsSql = `
+        UPDATE placement_tests
+        SET status = 'abandoned', finished_at = CURRENT_TIMESTAMP
+        WHERE user_id = $1 AND status = 'in_progress'
+    `
+
+	InsertPlacementTestSql = `
+        INSERT INTO placement_tests (user_id, module_ids, low, high)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id
+    `
+
+	SelectPlacementTestSql = `
+        SELECT id, user_id, status, module_ids, low, high, probe_items, probe_passed, answered, correct,
+               current_exercise_id, start_module_id, finished_at
+        FROM placement_tests
+        WHERE id = $1 AND user_id = $2
+    `
+
+	UpdatePlacementTestSql = `
+        UPDATE placement_tests
+        SET status = $2, low = $3, high = $4, probe_items = $5, probe_passed = $6, answered = $7, correct = $8,
+            current_exercise_id = $9, start_module_id = $10, finished_at = $11
+        WHERE id = $1
+    `
+
+	SelectPlacementItemSql = `
+        SELECT e.id, e.exercise_type, e.words, e.transcriptions, e.audio, e.translations, e.module_id
+        FROM word_exercise_cards e
+        JOIN word_modules m ON m.id = e.module_id
+        WHERE e.module_id = $1 AND m.owner_id IS NULL AND e.exercise_type IN ('pronounce', 'guessWord')
+          AND NOT EXISTS (SELECT 1 FROM placement_answers a WHERE a.test_id = $2 AND a.exercise_id = e.id)
+        ORDER BY (e.exercise_type::text = $3) DESC, e.id
+        LIMIT 1
+    `
+
+	InsertPlacementAnswerSql = `
+        INSERT INTO placement_answers (test_id, exercise_id, module_id, passed, score)
+        VALUES ($1, $2, $3, $4, $5)
+    `
+
+	SkipWordModulesProgressSql = `
+        INSERT INTO exercise_progress (user_id, exercise_id, exercise_type, status)
+        SELECT $1::uuid, e.id, 'word', 'skipped'
+        FROM word_exercises e
+        JOIN word_modules m ON m.id = e.module_id
+        WHERE e.module_id = ANY($2) AND m.owner_id IS NULL
+        ON CONFLICT (user_id, exercise_id, exercise_type)
+        DO UPDATE SET status = 'skipped', updated_at = CURRENT_TIMESTAMP
+        WHERE exercise_progress.status <> 'completed'
+    `
+
+	UpdateUserStartModuleSql = `
+        UPDATE users SET start_word_module = $2, placement_completed_at = CURRENT_TIMESTAMP WHERE id = $1
+    `
+
+	SelectWordModuleProgressSql = `
+        WITH spent AS (
+            SELECT exercise_id, SUM(duration_ms) AS ms
+            FROM exercise_attempts
+            WHERE user_id = $1 AND exercise_type = 'word'
+            GROUP BY exercise_id
+        )
+        SELECT m.id, m.title, m.owner_id IS NOT NULL,
+               COUNT(e.id),
+               COUNT(p.id) FILTER (WHERE p.attempts > 0),
+               COUNT(p.id) FILTER (WHERE p.status = 'completed'),
+               COUNT(p.id) FILTER (WHERE p.status = 'failed'),
+               COUNT(p.id) FILTER (WHERE p.status = 'skipped'),
+               COALESCE(SUM(p.attempts), 0),
+               COALESCE(SUM(s.ms), 0)
+        FROM word_modules m
+        LEFT JOIN word_exercises e ON e.module_id = m.id
+        LEFT JOIN exercise_progress p
+            ON p.exercise_id = e.id AND p.exercise_type = 'word' AND p.user_id = $1
+        LEFT JOIN spent s ON s.exercise_id = e.id
+        WHERE m.owner_id IS NULL OR m.owner_id = $1
+        GROUP BY m.id, m.title
+        ORDER BY m.owner_id IS NOT NULL, m.id
+    `
+
+	SelectPhraseModuleProgressSql = `
+        WITH spent AS (
+            SELECT exercise_id, SUM(duration_ms) AS ms
+            FROM exercise_attempts
+            WHERE user_id = $1 AND exercise_type = 'phrase'
+            GROUP BY exercise_id
+        )
+        SELECT m.id, m.title, FALSE,
+               COUNT(e.id),
+               COUNT(p.id) FILTER (WHERE p.attempts > 0),
+               COUNT(p.id) FILTER (WHERE p.status = 'completed'),
+               COUNT(p.id) FILTER (WHERE p.status = 'failed'),
+               COUNT(p.id) FILTER (WHERE p.status = 'skipped'),
+               COALESCE(SUM(p.attempts), 0),
+               COALESCE(SUM(s.ms), 0)
+        FROM phrase_modules m
+        LEFT JOIN phrase_exercises e ON e.module_id = m.id
+        LEFT JOIN exercise_progress p
+            ON p.exercise_id = e.id AND p.exercise_type = 'phrase' AND p.user_id = $1
+        LEFT JOIN spent s ON s.exercise_id = e.id
+        GROUP BY m.id, m.title
+        ORDER BY m.id
+    `
+
+	SelectRecentWordsSql = `
+        SELECT a.exercise_id, e.words, a.result, a.score, a.created_at
+        FROM (
+            SELECT DISTINCT ON (exercise_id) exercise_id, result, score, created_at
+            FROM exercise_attempts
+            WHERE user_id = $1 AND exercise_type = 'word'
+            ORDER BY exercise_id, created_at DESC
+        ) a
+        JOIN word_exercise_cards e ON e.id = a.exercise_id
+        LEFT JOIN word_modules m ON m.id = e.module_id
+        WHERE m.owner_id IS NULL OR m.owner_id = $1
+        ORDER BY a.created_at DESC
+        LIMIT $2
+    `
+
 	// node sql
+	// $1 - тема; пустая строка - все модули. Модуль относится к теме, если она привязана
+	// к нему самому или к одному из его упражнений или слов
 	SelectPhraseModulesSql = `
         SELECT id, title 
-        FROM phrase_modules 
+        FROM phrase_modules m
+        WHERE $1 = '' OR EXISTS (
+            SELECT 1 FROM topics t
+            WHERE t.slug = $1 AND (
+                EXISTS (SELECT 1 FROM topic_links l WHERE l.topic_id = t.id AND l.phrase_module_id = m.id)
+                OR EXISTS (SELECT 1 FROM phrase_exercises e
+                           JOIN exercise_topics et ON et.exercise_type = 'phrase' AND et.exercise_id = e.id
+                           WHERE e.module_id = m.id AND et.topic_id = t.id)))
         ORDER BY id
     `
 
 	SelectWordModulesSql = `
         SELECT id, title 
-        FROM word_modules 
+        FROM word_modules m
+        WHERE owner_id IS NULL AND ($1 = '' OR EXISTS (
+            SELECT 1 FROM topics t
+            WHERE t.slug = $1 AND (
+                EXISTS (SELECT 1 FROM topic_links l WHERE l.topic_id = t.id AND l.word_module_id = m.id)
+                OR EXISTS (SELECT 1 FROM word_exercises e
+                           JOIN exercise_topics et ON et.exercise_type = 'word' AND et.exercise_id = e.id
+                           WHERE e.module_id = m.id AND et.topic_id = t.id))))
         ORDER BY id
     `
 
+	// редакторы добавляют упражнения только в общие модули, личные наборы заполняет их владелец
 	CreateWordExerciseSql = `
 INSERT INTO word_exercises (
     exercise_type,
-    words,
-    transcriptions,
-    audio,
-    translations,
     module_id
-) VALUES ($1, $2, $3, $4, $5, $6)
+)
+SELECT $1, $2
+WHERE EXISTS (SELECT 1 FROM word_modules WHERE id = $2 AND owner_id IS NULL)
 RETURNING id;
 `
+
+	// повторное добавление слова переиспользует статью: новый перевод дописывается,
+	// озвучка заполняется, только если её ещё не было. Возвращаются сохранённые в статье
+	// озвучка и основной перевод, чтобы отличающиеся значения упражнение хранило у себя
+	UpsertSharedLexiconEntrySql = `
+        INSERT INTO lexicon_entries (word, word_key, ipa, translations, audio, phonemes)
+        VALUES ($1, lower($1), $2, array_remove(ARRAY[$3::text], ''), $4, COALESCE($5::text[], '{}'))
+        ON CONFLICT (word_key, ipa) WHERE owner_id IS NULL
+        DO UPDATE SET translations = CASE WHEN $3 = '' OR $3 = ANY(lexicon_entries.translations)
+                                          THEN lexicon_entries.translations
+                                          ELSE lexicon_entries.translations || $3::text END,
+                      audio = CASE WHEN lexicon_entries.audio = '' THEN EXCLUDED.audio
+                                   ELSE lexicon_entries.audio END,
+                      phonemes = EXCLUDED.phonemes
+        RETURNING id, audio, COALESCE(translations[1], '')
+    `
+
+	UpsertOwnedLexiconEntrySql = `
+        INSERT INTO lexicon_entries (word, word_key, ipa, translations, audio, owner_id, phonemes)
+        VALUES ($1, lower($1), $2, array_remove(ARRAY[$3::text], ''), $4, $5, COALESCE($6::text[], '{}'))
+        ON CONFLICT (owner_id, word_key, ipa) WHERE owner_id IS NOT NULL
+        DO UPDATE SET translations = CASE WHEN $3 = '' OR $3 = ANY(lexicon_entries.translations)
+                                          THEN lexicon_entries.translations
+                                          ELSE lexicon_entries.translations || $3::text END,
+                      audio = CASE WHEN lexicon_entries.audio = '' THEN EXCLUDED.audio
+                                   ELSE lexicon_entries.audio END,
+                      phonemes = EXCLUDED.phonemes
+        RETURNING id, audio, COALESCE(translations[1], '')
+    `
+
+	InsertExerciseEntrySql = `
+        INSERT INTO word_exercise_entries (exercise_id, position, entry_id, audio, translation)
+        VALUES ($1, $2, $3, $4, $5)
+    `
 	CreatePhraseExerciseSql = `
 INSERT INTO phrase_exercises (
     exercise_type,
@@ -91,45 +404,639 @@ INSERT INTO phrase_exercises (
     transcription,
     audio,
     chain,
-    module_id
-) VALUES ($1, $2, $3, $4, $5, $6, $7)
+    module_id,
+    phonemes
+) VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE($8::text[], '{}'))
 RETURNING id;
 `
 	UpsertExerciseProgressSql = `
         INSERT INTO exercise_progress (user_id, exercise_id, exercise_type, status)
         VALUES ($1, $2, $3, $4)
         ON CONFLICT (user_id, exercise_id, exercise_type)
-        DO UPDATE SET status = EXCLUDED.status, updated_at = CURRENT_TIMESTAMP
+        DO UPDATE SET status = CASE WHEN exercise_progress.attempts > 0
+                                    THEN exercise_progress.status
+                                    ELSE EXCLUDED.status END,
+                      updated_at = CURRENT_TIMESTAMP
         RETURNING id;
     `
+
+	InsertExerciseAttemptSql = `
+        INSERT INTO exercise_attempts (user_id, exercise_id, exercise_type, result, score, duration_ms, transcription, recording_id)
+        VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, ''))
+        RETURNING id, created_at;
+    `
+
+	// статус считается по попыткам начиная с последней оценённой учителем: его оценка
+	// перекрывает автоматические результаты более ранних попыток
+	RefreshExerciseProgressSql = `
+        INSERT INTO exercise_progress (user_id, exercise_id, exercise_type, status, attempts, best_score, last_attempt_at, updated_at)
+        SELECT $1::uuid, $2::integer, $3::varchar,
+               CASE WHEN bool_or(a.result = 'completed') FILTER (WHERE a.created_at >= COALESCE(
+                        (SELECT MAX(ga.created_at)
+                         FROM exercise_attempts ga
+                         JOIN recording_grades g ON g.attempt_id = ga.id
+                         WHERE ga.user_id = $1 AND ga.exercise_id = $2 AND ga.exercise_type = $3),
+                        '-infinity'::timestamp))
+                    THEN 'completed' ELSE 'failed' END,
+               COUNT(*), MAX(a.score), MAX(a.created_at), CURRENT_TIMESTAMP
+        FROM exercise_attempts a
+        WHERE a.user_id = $1 AND a.exercise_id = $2 AND a.exercise_type = $3
+        ON CONFLICT (user_id, exercise_id, exercise_type)
+        DO UPDATE SET status = EXCLUDED.status,
+                      attempts = EXCLUDED.attempts,
+                      best_score = EXCLUDED.best_score,
+                      last_attempt_at = EXCLUDED.last_attempt_at,
+                      updated_at = CURRENT_TIMESTAMP
+        RETURNING id, status;
+    `
+
+	SelectExerciseAttemptsSql = `
+        SELECT id, exercise_id, exercise_type, result, score, duration_ms,
+               COALESCE(transcription, ''), COALESCE(recording_id, ''), created_at
+        FROM exercise_attempts
+        WHERE user_id = $1 AND exercise_id = $2 AND exercise_type = $3
+        ORDER BY created_at DESC, id DESC
+    `
+	InsertDeckSql = `
+        INSERT INTO word_modules (title, owner_id)
+        VALUES ($1, $2)
+        RETURNING id, created_at
+    `
+
+	SelectDecksSql = `
+        SELECT m.id, m.title, COUNT(DISTINCT x.entry_id), m.created_at
+        FROM word_modules m
+        LEFT JOIN word_exercises e ON e.module_id = m.id
+        LEFT JOIN word_exercise_entries x ON x.exercise_id = e.id
+        WHERE m.owner_id = $1
+        GROUP BY m.id
+        ORDER BY m.created_at DESC, m.id DESC
+    `
+
+	SelectDeckSql = `
+        SELECT m.id, m.title, COUNT(DISTINCT x.entry_id), m.created_at
+        FROM word_modules m
+        LEFT JOIN word_exercises e ON e.module_id = m.id
+        LEFT JOIN word_exercise_entries x ON x.exercise_id = e.id
+        WHERE m.id = $1 AND m.owner_id = $2
+        GROUP BY m.id
+    `
+
+	UpdateDeckTitleSql = `UPDATE word_modules SET title = $3 WHERE id = $1 AND owner_id = $2`
+
+	DeleteDeckSql = `DELETE FROM word_modules WHERE id = $1 AND owner_id = $2`
+
+	// слово попадает в набор, только если набор принадлежит пользователю
+	InsertDeckWordSql = `
+        INSERT INTO word_exercises (exercise_type, module_id)
+        SELECT $3, m.id
+        FROM word_modules m
+        WHERE m.id = $1 AND m.owner_id = $2
+        RETURNING id
+    `
+
+	// пара для угадывания: последнее добавленное в набор другое слово с озвучкой
+	SelectDeckGuessPartnerSql = `
+        SELECT l.word, l.ipa, COALESCE(NULLIF(x.translation, ''), l.translations[1], ''),
+               COALESCE(NULLIF(x.audio, ''), l.audio), l.phonemes
+        FROM word_exercises e
+        JOIN word_exercise_entries x ON x.exercise_id = e.id
+        JOIN lexicon_entries l ON l.id = x.entry_id
+        WHERE e.module_id = $1 AND l.owner_id = $2 AND l.word_key <> lower($3)
+          AND COALESCE(NULLIF(x.audio, ''), l.audio) <> ''
+        ORDER BY e.id DESC, x.position
+        LIMIT 1
+    `
+
+	DeleteDeckWordSql = `
+        DELETE FROM word_exercises e
+        USING word_modules m
+        WHERE e.id = $3 AND e.module_id = m.id AND m.id = $1 AND m.owner_id = $2
+    `
+
+	// закладка ставится только на видимое пользователю упражнение и существующую позицию слова
+	UpsertWordBookmarkSql = `
+        INSERT INTO bookmarks (user_id, word_exercise_id, word_index, note)
+        SELECT $1, e.id, $3, $4
+        FROM word_exercises e
+        JOIN word_modules m ON m.id = e.module_id
+        WHERE e.id = $2 AND (m.owner_id IS NULL OR m.owner_id = $1)
+          AND EXISTS (SELECT 1 FROM word_exercise_entries x WHERE x.exercise_id = e.id AND x.position = $3)
+        ON CONFLICT (user_id, word_exercise_id, word_index) WHERE word_exercise_id IS NOT NULL
+        DO UPDATE SET note = EXCLUDED.note
+        RETURNING id
+    `
+
+	UpsertPhraseBookmarkSql = `
+        INSERT INTO bookmarks (user_id, phrase_exercise_id, note)
+        SELECT $1, e.id, $3
+        FROM phrase_exercises e
+        WHERE e.id = $2
+        ON CONFLICT (user_id, phrase_exercise_id) WHERE phrase_exercise_id IS NOT NULL
+        DO UPDATE SET note = EXCLUDED.note
+        RETURNING id
+    `
+
+	// $2 - тип ('' - любой), $3 - модуль, $4 - конкретная закладка; NULL отключает фильтр
+	SelectBookmarksSql = `
+        SELECT b.id, 'word', e.id, b.word_index, e.module_id,
+               l.word, COALESCE(NULLIF(x.translation, ''), l.translations[1], ''), l.ipa,
+               COALESCE(NULLIF(x.audio, ''), l.audio),
+               b.note, b.created_at
+        FROM bookmarks b
+        JOIN word_exercises e ON e.id = b.word_exercise_id
+        JOIN word_modules m ON m.id = e.module_id
+        JOIN word_exercise_entries x ON x.exercise_id = e.id AND x.position = b.word_index
+        JOIN lexicon_entries l ON l.id = x.entry_id
+        WHERE b.user_id = $1 AND (m.owner_id IS NULL OR m.owner_id = $1)
+          AND $2::varchar IN ('', 'word')
+          AND ($3::integer IS NULL OR e.module_id = $3)
+          AND ($4::integer IS NULL OR b.id = $4)
+        UNION ALL
+        SELECT b.id, 'phrase', e.id, 0, e.module_id,
+               COALESCE(e.sentence, ''), COALESCE(e.translate, ''), COALESCE(e.transcription, ''), e.audio,
+               b.note, b.created_at
+        FROM bookmarks b
+        JOIN phrase_exercises e ON e.id = b.phrase_exercise_id
+        WHERE b.user_id = $1
+          AND $2::varchar IN ('', 'phrase')
+          AND ($3::integer IS NULL OR e.module_id = $3)
+          AND ($4::integer IS NULL OR b.id = $4)
+        ORDER BY 11 DESC, 1 DESC
+    `
+
+	UpdateBookmarkNoteSql = `UPDATE bookmarks SET note = $3 WHERE id = $1 AND user_id = $2`
+
+	DeleteBookmarkSql = `DELETE FROM bookmarks WHERE id = $1 AND user_id = $2`
+
+	SelectBookmarkedWordExercisesSql = `
+        SELECT e.id, e.exercise_type, e.words, e.transcriptions, e.audio, e.translations, e.module_id,
+               COALESCE(p.status, 'none') AS status
+        FROM word_exercise_cards e
+        JOIN word_modules m ON m.id = e.module_id
+        LEFT JOIN exercise_progress p
+            ON p.exercise_id = e.id AND p.exercise_type = 'word' AND p.user_id = $1
+        WHERE EXISTS (SELECT 1 FROM bookmarks b WHERE b.user_id = $1 AND b.word_exercise_id = e.id)
+          AND (m.owner_id IS NULL OR m.owner_id = $1)
+          AND ($2::integer IS NULL OR e.module_id = $2)
+        ORDER BY e.id
+    `
+
+	SelectBookmarkedPhraseExercisesSql = `
+        SELECT e.id, e.exercise_type, COALESCE(e.sentence, ''), COALESCE(e.translate, ''),
+               COALESCE(e.transcription, ''), e.audio, e.chain, e.module_id,
+               COALESCE(p.status, 'none') AS status
+        FROM phrase_exercises e
+        LEFT JOIN exercise_progress p
+            ON p.exercise_id = e.id AND p.exercise_type = 'phrase' AND p.user_id = $1
+        WHERE EXISTS (SELECT 1 FROM bookmarks b WHERE b.user_id = $1 AND b.phrase_exercise_id = e.id)
+          AND ($2::integer IS NULL OR e.module_id = $2)
+        ORDER BY e.id
+    `
+
+	// по id > $1 выбирается следующая пачка: записи, в которых не нашлось ни одной фонемы,
+	// иначе выбирались бы снова и снова
+	SelectLexiconWithoutPhonemesSql = `
+        SELECT id, ipa FROM lexicon_entries
+        WHERE id > $1 AND ipa <> '' AND cardinality(phonemes) = 0
+        ORDER BY id
+        LIMIT $2
+    `
+
+	UpdateLexiconPhonemesSql = `UPDATE lexicon_entries SET ipa = $2, phonemes = $3 WHERE id = $1`
+
+	// другая статья с тем же словом и той же транскрипцией у того же владельца
+	SelectLexiconDuplicateSql = `
+        SELECT o.id
+        FROM lexicon_entries e
+        JOIN lexicon_entries o ON o.word_key = e.word_key AND o.ipa = $2
+             AND o.owner_id IS NOT DISTINCT FROM e.owner_id AND o.id <> e.id
+        WHERE e.id = $1
+        ORDER BY o.id
+        LIMIT 1
+        FOR UPDATE OF o
+    `
+
+	// слияние статьи $1 в статью $2: недостающие переводы, аудио и часть речи переходят к $2
+	MergeLexiconFieldsSql = `
+        UPDATE lexicon_entries t
+        SET translations = t.translations || ARRAY(
+                SELECT u FROM unnest(f.translations) WITH ORDINALITY AS a(u, n)
+                WHERE u <> ALL(t.translations) ORDER BY n),
+            audio = CASE WHEN t.audio = '' THEN f.audio ELSE t.audio END,
+            part_of_speech = CASE WHEN t.part_of_speech = '' THEN f.part_of_speech ELSE t.part_of_speech END
+        FROM lexicon_entries f
+        WHERE t.id = $2 AND f.id = $1
+    `
+
+	// упражнения переходят на статью $2, но сохраняют аудио и перевод, которые показывали раньше
+	MergeLexiconExerciseEntriesSql = `
+        UPDATE word_exercise_entries x
+        SET entry_id = $2,
+            audio = CASE WHEN x.audio = '' AND f.audio <> t.audio THEN f.audio ELSE x.audio END,
+            translation = CASE
+                WHEN x.translation = '' AND COALESCE(f.translations[1], '') <> COALESCE(t.translations[1], '')
+                THEN COALESCE(f.translations[1], '')
+                ELSE x.translation END
+        FROM lexicon_entries f, lexicon_entries t
+        WHERE x.entry_id = $1 AND f.id = $1 AND t.id = $2
+    `
+
+	MergeLexiconTranslationsSql = `
+        INSERT INTO lexicon_translations (entry_id, locale, translations)
+        SELECT $2, locale, translations FROM lexicon_translations WHERE entry_id = $1
+        ON CONFLICT (entry_id, locale) DO UPDATE
+        SET translations = lexicon_translations.translations || ARRAY(
+                SELECT u FROM unnest(EXCLUDED.translations) WITH ORDINALITY AS a(u, n)
+                WHERE u <> ALL(lexicon_translations.translations) ORDER BY n)
+    `
+
+	MergeLexiconVariantsSql = `
+        INSERT INTO lexicon_variants (entry_id, accent, ipa, phonemes, audio, updated_at)
+        SELECT $2, accent, ipa, phonemes, audio, updated_at FROM lexicon_variants WHERE entry_id = $1
+        ON CONFLICT (entry_id, accent) DO NOTHING
+    `
+
+	MergeLexiconTopicsSql = `
+        INSERT INTO topic_links (topic_id, kind, entry_id)
+        SELECT topic_id, kind, $2 FROM topic_links WHERE entry_id = $1
+        ON CONFLICT DO NOTHING
+    `
+
+	DeleteLexiconEntrySql = `DELETE FROM lexicon_entries WHERE id = $1`
+
+	SelectPhrasesWithoutPhonemesSql = `
+        SELECT id, transcription FROM phrase_exercises
+        WHERE id > $1 AND COALESCE(transcription, '') <> '' AND cardinality(phonemes) = 0
+        ORDER BY id
+        LIMIT $2
+    `
+
+	UpdatePhrasePhonemesSql = `UPDATE phrase_exercises SET transcription = $2, phonemes = $3 WHERE id = $1`
+
+	// без явной позиции ($3 < 0) совет встаёт в конец списка своей фонемы и языка
+	InsertTipSql = `
+        INSERT INTO word_tip (phonema, locale, position, difficulty, tip_text, tip_audio_link, tip_video_link, example_words)
+        VALUES ($1, $2,
+                CASE WHEN $3::int >= 0 THEN $3::int
+                     ELSE (SELECT COALESCE(MAX(position) + 1, 0) FROM word_tip WHERE phonema = $1 AND locale = $2) END,
+                $4, $5, $6, $7, $8)
+        RETURNING id, position
+    `
+
+	SelectTipSql = `
+        SELECT id, phonema, locale, position, difficulty, tip_text, tip_audio_link, tip_video_link, example_words
+        FROM word_tip
+        WHERE id = $1
+    `
+
+	SelectTipsSql = `
+        SELECT id, phonema, locale, position, difficulty, tip_text, tip_audio_link, tip_video_link, example_words
+        FROM word_tip
+        WHERE phonema = $1 AND locale = $2
+        ORDER BY position, id
+    `
+
+	// пустые ссылки на файлы и отрицательная позиция оставляют прежние значения
+	UpdateTipSql = `
+        UPDATE word_tip
+        SET phonema = $2,
+            locale = $3,
+            position = CASE WHEN $4::int >= 0 THEN $4::int ELSE position END,
+            difficulty = $5,
+            tip_text = $6,
+            tip_audio_link = CASE WHEN $7 = '' THEN tip_audio_link ELSE $7 END,
+            tip_video_link = CASE WHEN $8 = '' THEN tip_video_link ELSE $8 END,
+            example_words = $9,
+            updated_at = CURRENT_TIMESTAMP
+        WHERE id = $1
+    `
+
+	DeleteTipSql = `DELETE FROM word_tip WHERE id = $1`
+
+	// первый совет каждой фонемы: на языке $2, если он есть, иначе на $3
+	SelectFirstTipsSql = `
+        SELECT DISTINCT ON (phonema)
+               id, phonema, locale, position, difficulty, tip_text, tip_audio_link, tip_video_link, example_words
+        FROM word_tip
+        WHERE phonema = ANY($1) AND locale IN ($2, $3)
+        ORDER BY phonema, locale = $2 DESC, position, id
+    `
+
+	// запрос ищется сразу в английской и русской морфологии, опечатки ловит word_similarity.
+	// Слово или фраза с переводами на нескольких языках находится по лучшему из совпадений.
+	// Общее число найденного считается до LIMIT/OFFSET: страница за концом выдачи
+	// возвращает одну строку с total и пустым kind
+	SearchTextSql = `
+        WITH q AS (
+            SELECT websearch_to_tsquery('english', $1) || websearch_to_tsquery('russian', $1) ||
+                   websearch_to_tsquery('simple', $1) AS query,
+                   lower($1) AS text
+        ),
+        found AS (
+            SELECT DISTINCT ON (d.kind, d.id) d.kind, d.id, d.module_id, d.title, d.subtitle, d.transcription,
+                   ts_rank(d.search_vector, q.query) + word_similarity(q.text, d.search_text) AS score
+            FROM search_documents d CROSS JOIN q
+            WHERE (d.owner_id IS NULL OR d.owner_id = $2)
+              AND (cardinality($3::text[]) = 0 OR d.kind = ANY($3::text[]))
+              AND (d.search_vector @@ q.query OR q.text <% d.search_text)
+            ORDER BY d.kind, d.id, score DESC
+        ),
+        page AS (
+            SELECT *, ROW_NUMBER() OVER (ORDER BY score DESC, kind, id) AS n
+            FROM found
+            ORDER BY n
+            LIMIT $4 OFFSET $5
+        )
+        SELECT p.kind, COALESCE(p.id, 0), p.module_id, COALESCE(p.title, ''), COALESCE(p.subtitle, ''),
+               COALESCE(p.transcription, ''), COALESCE(p.score, 0), c.total
+        FROM (SELECT COUNT(*) AS total FROM found) c
+        LEFT JOIN page p ON true
+        ORDER BY p.n
+    `
+
+	// $1 - фонемы через пробел с пробелами по краям; сначала советы, затем самые короткие записи.
+	// Общее число найденного считается так же, как в SearchTextSql
+	SearchPhonemesSql = `
+        WITH found AS (
+            SELECT d.kind, d.id, d.module_id, d.title, d.subtitle, d.transcription,
+                   ROW_NUMBER() OVER (ORDER BY d.kind = 'tip' DESC, char_length(d.phoneme_text), d.kind, d.id) AS n
+            FROM search_documents d
+            WHERE (d.owner_id IS NULL OR d.owner_id = $2)
+              AND (cardinality($3::text[]) = 0 OR d.kind = ANY($3::text[]))
+              AND d.phoneme_text LIKE '%' || $1 || '%'
+        ),
+        page AS (
+            SELECT * FROM found
+            ORDER BY n
+            LIMIT $4 OFFSET $5
+        )
+        SELECT p.kind, COALESCE(p.id, 0), p.module_id, COALESCE(p.title, ''), COALESCE(p.subtitle, ''),
+               COALESCE(p.transcription, ''), 0::float8 AS score, c.total
+        FROM (SELECT COUNT(*) AS total FROM found) c
+        LEFT JOIN page p ON true
+        ORDER BY p.n
+    `
+
+	// перевод дописывается к переводам статьи на том же языке, как и на основном
+	UpsertLexiconTranslationSql = `
+        INSERT INTO lexicon_translations (entry_id, locale, translations)
+        VALUES ($1, $2, ARRAY[$3::text])
+        ON CONFLICT (entry_id, locale)
+        DO UPDATE SET translations = CASE WHEN $3 = ANY(lexicon_translations.translations)
+                                          THEN lexicon_translations.translations
+                                          ELSE lexicon_translations.translations || $3::text END
+    `
+
+	UpsertPhraseTranslationSql = `
+        INSERT INTO phrase_translations (exercise_id, locale, translation)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (exercise_id, locale) DO UPDATE SET translation = EXCLUDED.translation
+    `
+
+	// $2 - языки в порядке предпочтения, берётся первый, на котором перевод есть
+	SelectWordTranslationsSql = `
+        SELECT DISTINCT ON (x.exercise_id, x.position) x.exercise_id, x.position, t.translations[1]
+        FROM word_exercise_entries x
+        JOIN lexicon_translations t ON t.entry_id = x.entry_id
+        WHERE x.exercise_id = ANY($1) AND t.locale = ANY($2::text[]) AND cardinality(t.translations) > 0
+        ORDER BY x.exercise_id, x.position, array_position($2::text[], t.locale::text)
+    `
+
+	SelectPhraseTranslationsSql = `
+        SELECT DISTINCT ON (exercise_id) exercise_id, translation
+        FROM phrase_translations
+        WHERE exercise_id = ANY($1) AND locale = ANY($2::text[]) AND translation <> ''
+        ORDER BY exercise_id, array_position($2::text[], locale::text)
+    `
+
+	SelectContentSettingsSql = `SELECT COALESCE(content_locale, ''), COALESCE(accent, '') FROM users WHERE id = $1`
+
+	// NULL оставляет настройку прежней, пустая строка сбрасывает
+	UpdateContentSettingsSql = `
+        UPDATE users
+        SET content_locale = CASE WHEN $2::text IS NULL THEN content_locale ELSE NULLIF($2, '') END,
+            accent = CASE WHEN $3::text IS NULL THEN accent ELSE NULLIF($3, '') END
+        WHERE id = $1
+    `
+
+	// варианты задаются только словам общих модулей: личные наборы меняет лишь владелец
+	UpsertWordVariantSql = `
+        INSERT INTO lexicon_variants (entry_id, accent, ipa, phonemes, audio)
+        SELECT x.entry_id, $3, $4, COALESCE($5::text[], '{}'), $6
+        FROM word_exercise_entries x
+        JOIN word_exercises e ON e.id = x.exercise_id
+        JOIN word_modules m ON m.id = e.module_id
+        WHERE x.exercise_id = $1 AND x.position = $2 AND m.owner_id IS NULL
+        ON CONFLICT (entry_id, accent)
+        DO UPDATE SET ipa = EXCLUDED.ipa,
+                      phonemes = EXCLUDED.phonemes,
+                      audio = CASE WHEN EXCLUDED.audio = '' THEN lexicon_variants.audio ELSE EXCLUDED.audio END,
+                      updated_at = CURRENT_TIMESTAMP
+    `
+
+	DeleteWordVariantSql = `
+        DELETE FROM lexicon_variants v
+        USING word_exercise_entries x, word_exercises e, word_modules m
+        WHERE v.entry_id = x.entry_id AND v.accent = $3
+          AND x.exercise_id = $1 AND x.position = $2
+          AND e.id = x.exercise_id AND m.id = e.module_id AND m.owner_id IS NULL
+    `
+
+	UpsertPhraseVariantSql = `
+        INSERT INTO phrase_variants (exercise_id, accent, transcription, phonemes, audio)
+        SELECT id, $2, $3, COALESCE($4::text[], '{}'), $5
+        FROM phrase_exercises
+        WHERE id = $1
+        ON CONFLICT (exercise_id, accent)
+        DO UPDATE SET transcription = EXCLUDED.transcription,
+                      phonemes = EXCLUDED.phonemes,
+                      audio = CASE WHEN EXCLUDED.audio = '' THEN phrase_variants.audio ELSE EXCLUDED.audio END,
+                      updated_at = CURRENT_TIMESTAMP
+    `
+
+	DeletePhraseVariantSql = `DELETE FROM phrase_variants WHERE exercise_id = $1 AND accent = $2`
+
+	SelectWordVariantsSql = `
+        SELECT x.exercise_id, x.position, v.ipa, v.phonemes, v.audio
+        FROM word_exercise_entries x
+        JOIN lexicon_variants v ON v.entry_id = x.entry_id
+        WHERE x.exercise_id = ANY($1) AND v.accent = $2
+    `
+
+	SelectPhraseVariantsSql = `
+        SELECT exercise_id, transcription, phonemes, audio
+        FROM phrase_variants
+        WHERE exercise_id = ANY($1) AND accent = $2
+    `
+
+	// слова предложения заменяются целиком; одна позиция даёт несколько строк с разными ключами
+	DeletePhraseWordsSql  = `DELETE FROM sentence_words WHERE phrase_exercise_id = $1`
+	DeleteExampleWordsSql = `DELETE FROM sentence_words WHERE example_id = $1`
+
+	InsertPhraseWordsSql = `
+        INSERT INTO sentence_words (phrase_exercise_id, position, form, word_key)
+        SELECT $1, w.position, w.form, w.word_key
+        FROM unnest($2::int[], $3::text[], $4::text[]) AS w(position, form, word_key)
+        ON CONFLICT DO NOTHING
+    `
+
+	InsertExampleWordsSql = `
+        INSERT INTO sentence_words (example_id, position, form, word_key)
+        SELECT $1, w.position, w.form, w.word_key
+        FROM unnest($2::int[], $3::text[], $4::text[]) AS w(position, form, word_key)
+        ON CONFLICT DO NOTHING
+    `
+
+	SelectPhrasesWithoutWordsSql = `
+        SELECT p.id, p.sentence FROM phrase_exercises p
+        WHERE p.id > $1 AND COALESCE(p.sentence, '') <> ''
+          AND NOT EXISTS (SELECT 1 FROM sentence_words s WHERE s.phrase_exercise_id = p.id)
+        ORDER BY p.id
+        LIMIT $2
+    `
+
+	// сначала фразовые упражнения, затем отдельные примеры; короткие предложения выше
+	SelectExerciseExamplesSql = `
+        SELECT x.exercise_id, x.position, e.kind, e.id, e.module_id, e.sentence, e.translation, e.audio, e.form
+        FROM word_exercise_entries x
+        CROSS JOIN LATERAL (
+            SELECT * FROM lexicon_examples le
+            WHERE le.entry_id = x.entry_id
+            ORDER BY le.kind = 'sentence', length(le.sentence), le.id
+            LIMIT $2
+        ) e
+        WHERE x.exercise_id = ANY($1)
+        ORDER BY x.exercise_id, x.position
+    `
+
+	SelectSharedLexiconEntryExistsSql = `SELECT EXISTS (SELECT 1 FROM lexicon_entries WHERE id = $1 AND owner_id IS NULL)`
+
+	SelectLexiconExamplesSql = `
+        SELECT kind, id, module_id, sentence, translation, audio, form
+        FROM lexicon_examples
+        WHERE entry_id = $1
+        ORDER BY kind = 'sentence', length(sentence), id
+        LIMIT $2 OFFSET $3
+    `
+
+	InsertExampleSql = `INSERT INTO example_sentences (sentence, translation, audio) VALUES ($1, $2, $3) RETURNING id`
+
+	// без нового файла озвучка остаётся прежней
+	UpdateExampleSql = `
+        UPDATE example_sentences
+        SET sentence = $2, translation = $3, audio = CASE WHEN $4 = '' THEN audio ELSE $4 END
+        WHERE id = $1
+    `
+
+	DeleteExampleSql = `DELETE FROM example_sentences WHERE id = $1`
+
+	InsertTopicSql = `INSERT INTO topics (slug, title) VALUES ($1, $2) ON CONFLICT (slug) DO NOTHING RETURNING id`
+
+	DeleteTopicSql = `DELETE FROM topics WHERE id = $1`
+
+	// слова считаются по общим модулям; пройденное слово - в упражнении со статусом completed.
+	// $1 = uuid.Nil - без прогресса пользователя
+	SelectTopicsSql = `
+        SELECT t.id, t.slug, t.title,
+               COUNT(DISTINCT w.entry_id) AS all_words,
+               COUNT(DISTINCT w.entry_id) FILTER (WHERE p.status IN ('completed', 'skipped')) AS true_words
+        FROM topics t
+        LEFT JOIN topic_words w ON w.topic_id = t.id
+        LEFT JOIN exercise_progress p
+            ON p.exercise_id = w.exercise_id AND p.exercise_type = 'word' AND p.user_id = $1
+        GROUP BY t.id
+        ORDER BY t.slug
+    `
+
+	// темы привязываются только к общим материалам; повторная привязка ничего не меняет
+	InsertTopicLinkSql = `
+        INSERT INTO topic_links (topic_id, kind, word_module_id, phrase_module_id, word_exercise_id, phrase_exercise_id, entry_id)
+        SELECT t.id, $2::text,
+               CASE WHEN $2::text = 'word_module' THEN $3::int END,
+               CASE WHEN $2::text = 'phrase_module' THEN $3::int END,
+               CASE WHEN $2::text = 'word_exercise' THEN $3::int END,
+               CASE WHEN $2::text = 'phrase_exercise' THEN $3::int END,
+               CASE WHEN $2::text = 'word' THEN $3::int END
+        FROM topics t
+        WHERE t.id = $1 AND CASE $2::text
+            WHEN 'word_module' THEN EXISTS (SELECT 1 FROM word_modules WHERE id = $3 AND owner_id IS NULL)
+            WHEN 'phrase_module' THEN EXISTS (SELECT 1 FROM phrase_modules WHERE id = $3)
+            WHEN 'word_exercise' THEN EXISTS (
+                SELECT 1 FROM word_exercises e JOIN word_modules m ON m.id = e.module_id
+                WHERE e.id = $3 AND m.owner_id IS NULL)
+            WHEN 'phrase_exercise' THEN EXISTS (SELECT 1 FROM phrase_exercises WHERE id = $3)
+            WHEN 'word' THEN EXISTS (SELECT 1 FROM lexicon_entries WHERE id = $3 AND owner_id IS NULL)
+            ELSE false END
+        ON CONFLICT (topic_id, kind, target_id) DO UPDATE SET topic_id = EXCLUDED.topic_id
+    `
+
+	DeleteTopicLinkSql = `DELETE FROM topic_links WHERE topic_id = $1 AND kind = $2 AND target_id = $3`
+
+	// $1 - word или phrase
+	SelectExercisesWithTopicSql = `
+        SELECT DISTINCT et.exercise_id
+        FROM exercise_topics et
+        JOIN topics t ON t.id = et.topic_id
+        WHERE et.exercise_type = $1 AND et.exercise_id = ANY($2) AND t.slug = $3
+    `
+
 	// new sql
-	SelectWordSql                 = `SELECT word_id, word, transcription, audio_link, topic from word_etalon WHERE word = $1 AND is_deleted = FALSE;`
-	CreateWordSql                 = `INSERT INTO word_etalon (word, transcription, audio_link, topic) VALUES ($1, $2, $3, $4) RETURNING word_id;`
-	InsertWordTip                 = `INSERT INTO word_tip (phonema, tip_text, tip_audio_link, tip_video_link) VALUES ($1, $2, $3, $4);`
-	SelectWordTip                 = `SELECT phonema, tip_text, tip_audio_link, tip_video_link from word_tip WHERE phonema = $1;`
+	// word_etalon заменён словарём: запросы работают с общими статьями lexicon_entries,
+	// темы статей берутся из topic_links. У статьи с несколькими темами - первая по slug
+	SelectWordSql = `
+        SELECT l.id, l.word, l.ipa, l.audio,
+               COALESCE((SELECT t.slug FROM topic_links tl JOIN topics t ON t.id = tl.topic_id
+                         WHERE tl.entry_id = l.id ORDER BY t.slug LIMIT 1), '')
+        FROM lexicon_entries l
+        WHERE l.word_key = lower($1) AND l.owner_id IS NULL;`
+	CreateWordSql = `
+        WITH entry AS (
+            INSERT INTO lexicon_entries (word, word_key, ipa, audio) VALUES ($1, lower($1), $2, $3) RETURNING id
+        ), link AS (
+            INSERT INTO topic_links (topic_id, kind, entry_id)
+            SELECT t.id, 'word', entry.id FROM topics t, entry WHERE t.slug = $4
+            ON CONFLICT DO NOTHING
+        )
+        SELECT id FROM entry;`
+	SelectWordTip = `SELECT phonema, tip_text, tip_audio_link, tip_video_link from word_tip WHERE phonema = $1 ORDER BY locale = $2 DESC, position, id LIMIT 1;`
+	// слова темы и пройденные слова считаются так же, как в SelectTopicsSql
 	SelectWordWithProgressByTopic = `SELECT 
-    we.word,
-    we.transcription,
-    we.audio_link,
-    we.topic,
+    l.word,
+    l.ipa,
+    l.audio,
+    t.slug,
     COALESCE(
-        (SELECT wp.progress 
-         FROM word_progress wp 
-         WHERE wp.word_id = we.word_id), 
+        (SELECT ROUND(100.0 * COUNT(*) FILTER (WHERE p.status IN ('completed', 'skipped')) / COUNT(*))::int
+         FROM word_exercise_entries x
+         LEFT JOIN exercise_progress p
+             ON p.exercise_id = x.exercise_id AND p.exercise_type = 'word' AND p.user_id = $2
+         WHERE x.entry_id = l.id
+         HAVING COUNT(*) > 0),
     0) AS progress
 FROM 
-    word_etalon we
+    topics t
+    JOIN lexicon_entries l ON l.id IN (SELECT w.entry_id FROM topic_words w WHERE w.topic_id = t.id)
 WHERE 
-    we.topic = $1`
-	SelectRandomWordSql          = `SELECT word_id, word, transcription, topic, audio_link   FROM word_etalon ORDER BY RANDOM() LIMIT 1;`
-	SelectRandomWordWithTopicSql = `SELECT word_id, word, transcription, topic, audio_link   FROM word_etalon WHERE is_deleted = FALSE AND topic=$1 ORDER BY RANDOM() LIMIT 1;`
+    t.slug = $1 AND l.owner_id IS NULL`
+	SelectRandomWordSql = `
+        SELECT l.id, l.word, l.ipa,
+               COALESCE((SELECT t.slug FROM topic_links tl JOIN topics t ON t.id = tl.topic_id
+                         WHERE tl.entry_id = l.id ORDER BY t.slug LIMIT 1), ''),
+               l.audio
+        FROM lexicon_entries l
+        WHERE l.owner_id IS NULL
+        ORDER BY RANDOM() LIMIT 1;`
+	SelectRandomWordWithTopicSql = `
+        SELECT l.id, l.word, l.ipa, t.slug, l.audio
+        FROM topics t
+        JOIN lexicon_entries l ON l.id IN (SELECT w.entry_id FROM topic_words w WHERE w.topic_id = t.id)
+        WHERE t.slug = $1 AND l.owner_id IS NULL
+        ORDER BY RANDOM() LIMIT 1;`
 
 	//old sql
-	UploadLinkSql         = `UPDATE word_etalon SET audio_id = $1 WHERE word = $2 AND is_deleted = FALSE;`
-	GetWordCountSql       = `SELECT COUNT(*) from word_etalon;`
-	InsertTag             = `INSERT INTO word_tag (tag) VALUES ($1) ON CONFLICT (tag) DO NOTHING;`
-	SelectTags            = `SELECT word_tag FROM word_tag;`
-	SelectAllWordsWithTag = `SELECT word_id, word, transcription, audio_id, tags FROM word_etalon WHERE tags=$1 AND is_deleted = FALSE;`
+	UploadLinkSql   = `UPDATE lexicon_entries SET audio = $1 WHERE word_key = lower($2) AND owner_id IS NULL;`
+	GetWordCountSql = `SELECT COUNT(*) from lexicon_entries WHERE owner_id IS NULL;`
 
 	Insert1Stat        = `INSERT INTO word_user_try (word_id, result) VALUES ($1, $2);`
 	InsertPlusBigStat  = `INSERT INTO user_word_summary (word_id, total_plus, total_minus) VALUES ($1, 1, 0) ON CONFLICT (word_id) DO UPDATE SET total_plus = user_word_summary.total_plus + 1;`
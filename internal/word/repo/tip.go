@@ -0,0 +1,175 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/lib/pq"
+)
+
+func scanTip(row interface{ Scan(dest ...any) error }) (*models.TipData, error) {
+	var tip models.TipData
+	var id int
+	var examples pq.StringArray
+	if err := row.Scan(
+		&id,
+		&tip.Phonema,
+		&tip.Locale,
+		&tip.Position,
+		&tip.Difficulty,
+		&tip.TipText,
+		&tip.TipAudioLink,
+		&tip.TipMediaLink,
+		&examples,
+	); err != nil {
+		return nil, err
+	}
+	tip.TipID = &id
+	tip.ExampleWords = examples
+	return &tip, nil
+}
+
+// CreateTip сохраняет совет. Position < 0 ставит его в конец списка фонемы; итоговая позиция
+// записывается обратно в tip.
+func (r *WordRepo) CreateTip(ctx context.Context, tip *models.TipData) (int, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var id int
+	err := r.db.QueryRowContext(ctx, InsertTipSql,
+		tip.Phonema,
+		tip.Locale,
+		tip.Position,
+		tip.Difficulty,
+		tip.TipText,
+		tip.TipAudioLink,
+		tip.TipMediaLink,
+		pq.Array(tip.ExampleWords),
+	).Scan(&id, &tip.Position)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "CreateTip", err)
+		return 0, fmt.Errorf("failed to create tip: %w", err)
+	}
+
+	r.logger.LogInfo(requestId, logger.RepositoryLayer, "CreateTip", fmt.Sprintf("created tip %d", id))
+	return id, nil
+}
+
+// GetTipByID возвращает nil, если совета нет.
+func (r *WordRepo) GetTipByID(ctx context.Context, tipID int) (*models.TipData, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	tip, err := scanTip(r.db.QueryRowContext(ctx, SelectTipSql, tipID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetTipByID", err)
+		return nil, fmt.Errorf("failed to get tip: %w", err)
+	}
+	return tip, nil
+}
+
+func (r *WordRepo) GetTips(ctx context.Context, phoneme, locale string) ([]models.TipData, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, SelectTipsSql, phoneme, locale)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetTips", err)
+		return nil, fmt.Errorf("failed to query tips: %w", err)
+	}
+	defer rows.Close()
+
+	tips := make([]models.TipData, 0)
+	for rows.Next() {
+		tip, err := scanTip(rows)
+		if err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetTips", err)
+			return nil, fmt.Errorf("failed to scan tip: %w", err)
+		}
+		tips = append(tips, *tip)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetTips", err)
+		return nil, fmt.Errorf("error after iterating tips: %w", err)
+	}
+
+	return tips, nil
+}
+
+// UpdateTip возвращает false, если совета нет. Пустые ссылки на файлы оставляют прежние.
+func (r *WordRepo) UpdateTip(ctx context.Context, tip *models.TipData) (bool, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	res, err := r.db.ExecContext(ctx, UpdateTipSql,
+		*tip.TipID,
+		tip.Phonema,
+		tip.Locale,
+		tip.Position,
+		tip.Difficulty,
+		tip.TipText,
+		tip.TipAudioLink,
+		tip.TipMediaLink,
+		pq.Array(tip.ExampleWords),
+	)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "UpdateTip", err)
+		return false, fmt.Errorf("failed to update tip: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
+
+func (r *WordRepo) DeleteTip(ctx context.Context, tipID int) (bool, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	res, err := r.db.ExecContext(ctx, DeleteTipSql, tipID)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "DeleteTip", err)
+		return false, fmt.Errorf("failed to delete tip: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// GetTipsByPhonemes возвращает первый по порядку совет каждой фонемы на языке locale, а если
+// на нём совета нет - на языке по умолчанию. Фонемы без советов в результат не попадают.
+func (r *WordRepo) GetTipsByPhonemes(ctx context.Context, phonemes []string, locale string) (map[string]models.TipData, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, SelectFirstTipsSql, pq.Array(phonemes), locale, models.DefaultTipLocale)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetTipsByPhonemes", err)
+		return nil, fmt.Errorf("failed to query tips: %w", err)
+	}
+	defer rows.Close()
+
+	tips := make(map[string]models.TipData)
+	for rows.Next() {
+		tip, err := scanTip(rows)
+		if err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetTipsByPhonemes", err)
+			return nil, fmt.Errorf("failed to scan tip: %w", err)
+		}
+		tips[tip.Phonema] = *tip
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetTipsByPhonemes", err)
+		return nil, fmt.Errorf("error after iterating tips: %w", err)
+	}
+
+	return tips, nil
+}
@@ -0,0 +1,143 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/lib/pq"
+	"github.com/satori/uuid"
+)
+
+// CreateTopic возвращает false, если тема с таким именем уже есть.
+func (r *WordRepo) CreateTopic(ctx context.Context, slug, title string) (int, bool, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var id int
+	err := r.db.QueryRowContext(ctx, InsertTopicSql, slug, title).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "CreateTopic", err)
+		return 0, false, fmt.Errorf("failed to create topic: %w", err)
+	}
+	return id, true, nil
+}
+
+func (r *WordRepo) DeleteTopic(ctx context.Context, topicID int) (bool, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	res, err := r.db.ExecContext(ctx, DeleteTopicSql, topicID)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "DeleteTopic", err)
+		return false, fmt.Errorf("failed to delete topic: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// GetTopics возвращает темы с числом слов и пройденных пользователем слов.
+func (r *WordRepo) GetTopics(ctx context.Context, userID uuid.UUID) ([]models.OneTopic, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, SelectTopicsSql, userID)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetTopics", err)
+		return nil, fmt.Errorf("failed to query topics: %w", err)
+	}
+	defer rows.Close()
+
+	topics := make([]models.OneTopic, 0)
+	for rows.Next() {
+		var topicID, allWords, trueWords int
+		topic := models.OneTopic{TopicId: &topicID, AllWords: &allWords, TrueWords: &trueWords}
+		if err := rows.Scan(&topicID, &topic.Topic, &topic.Title, &allWords, &trueWords); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetTopics", err)
+			return nil, fmt.Errorf("failed to scan topic: %w", err)
+		}
+		topics = append(topics, topic)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetTopics", err)
+		return nil, fmt.Errorf("error after iterating topics: %w", err)
+	}
+
+	return topics, nil
+}
+
+// AddTopicLink возвращает false, если нет темы или материала либо материал из личного набора.
+func (r *WordRepo) AddTopicLink(ctx context.Context, link *models.TopicLink) (bool, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	res, err := r.db.ExecContext(ctx, InsertTopicLinkSql, link.TopicID, link.Kind, link.TargetID)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "AddTopicLink", err)
+		return false, fmt.Errorf("failed to add topic link: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
+
+func (r *WordRepo) DeleteTopicLink(ctx context.Context, link *models.TopicLink) (bool, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	res, err := r.db.ExecContext(ctx, DeleteTopicLinkSql, link.TopicID, link.Kind, link.TargetID)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "DeleteTopicLink", err)
+		return false, fmt.Errorf("failed to delete topic link: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// GetExercisesWithTopic возвращает те из упражнений exerciseIDs (словесных или фразовых),
+// у которых есть тема topic.
+func (r *WordRepo) GetExercisesWithTopic(ctx context.Context, phrases bool, exerciseIDs []int, topic string) ([]int, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	exerciseType := "word"
+	if phrases {
+		exerciseType = "phrase"
+	}
+
+	rows, err := r.db.QueryContext(ctx, SelectExercisesWithTopicSql, exerciseType, pq.Array(exerciseIDs), topic)
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetExercisesWithTopic", err)
+		return nil, fmt.Errorf("failed to query exercise topics: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetExercisesWithTopic", err)
+			return nil, fmt.Errorf("failed to scan exercise id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetExercisesWithTopic", err)
+		return nil, fmt.Errorf("error after iterating exercise topics: %w", err)
+	}
+
+	return ids, nil
+}
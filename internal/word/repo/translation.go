@@ -0,0 +1,108 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/lib/pq"
+	"github.com/satori/uuid"
+)
+
+// GetWordTranslations возвращает переводы слов упражнений на первом из языков locales, на котором
+// перевод есть: id упражнения -> позиция слова -> перевод. Слова без перевода на этих языках
+// в результат не попадают.
+func (r *WordRepo) GetWordTranslations(ctx context.Context, exerciseIDs []int, locales []string) (map[int]map[int]string, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, SelectWordTranslationsSql, pq.Array(exerciseIDs), pq.Array(locales))
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetWordTranslations", err)
+		return nil, fmt.Errorf("failed to query word translations: %w", err)
+	}
+	defer rows.Close()
+
+	translations := make(map[int]map[int]string)
+	for rows.Next() {
+		var exerciseID, position int
+		var translation string
+		if err := rows.Scan(&exerciseID, &position, &translation); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetWordTranslations", err)
+			return nil, fmt.Errorf("failed to scan word translation: %w", err)
+		}
+		if translations[exerciseID] == nil {
+			translations[exerciseID] = make(map[int]string)
+		}
+		translations[exerciseID][position] = translation
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetWordTranslations", err)
+		return nil, fmt.Errorf("error after iterating word translations: %w", err)
+	}
+
+	return translations, nil
+}
+
+// GetPhraseTranslations - то же для фразовых упражнений: id упражнения -> перевод.
+func (r *WordRepo) GetPhraseTranslations(ctx context.Context, exerciseIDs []int, locales []string) (map[int]string, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	rows, err := r.db.QueryContext(ctx, SelectPhraseTranslationsSql, pq.Array(exerciseIDs), pq.Array(locales))
+	if err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetPhraseTranslations", err)
+		return nil, fmt.Errorf("failed to query phrase translations: %w", err)
+	}
+	defer rows.Close()
+
+	translations := make(map[int]string)
+	for rows.Next() {
+		var exerciseID int
+		var translation string
+		if err := rows.Scan(&exerciseID, &translation); err != nil {
+			r.logger.LogError(requestId, logger.RepositoryLayer, "GetPhraseTranslations", err)
+			return nil, fmt.Errorf("failed to scan phrase translation: %w", err)
+		}
+		translations[exerciseID] = translation
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetPhraseTranslations", err)
+		return nil, fmt.Errorf("error after iterating phrase translations: %w", err)
+	}
+
+	return translations, nil
+}
+
+// GetContentSettings возвращает настройки материалов из профиля; невыбранные - nil.
+func (r *WordRepo) GetContentSettings(ctx context.Context, userID uuid.UUID) (*models.ContentSettings, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	var locale, accent string
+	if err := r.db.QueryRowContext(ctx, SelectContentSettingsSql, userID).Scan(&locale, &accent); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "GetContentSettings", err)
+		return nil, fmt.Errorf("failed to get content settings: %w", err)
+	}
+
+	settings := &models.ContentSettings{}
+	if locale != "" {
+		settings.Locale = &locale
+	}
+	if accent != "" {
+		settings.Accent = &accent
+	}
+	return settings, nil
+}
+
+// UpdateContentSettings сохраняет переданные настройки: nil оставляет прежнее значение,
+// пустая строка сбрасывает его.
+func (r *WordRepo) UpdateContentSettings(ctx context.Context, userID uuid.UUID, settings *models.ContentSettings) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	if _, err := r.db.ExecContext(ctx, UpdateContentSettingsSql, userID, settings.Locale, settings.Accent); err != nil {
+		r.logger.LogError(requestId, logger.RepositoryLayer, "UpdateContentSettings", err)
+		return fmt.Errorf("failed to update content settings: %w", err)
+	}
+	return nil
+}
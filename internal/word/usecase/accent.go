@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/internal/word"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"slices"
+	"strings"
+)
+
+// normalizeAccent приводит акцент к виду из models.Accents; en-GB и gb означают британский.
+func normalizeAccent(accent string) (string, error) {
+	accent = strings.ToLower(strings.TrimSpace(accent))
+	accent = strings.TrimPrefix(strings.TrimPrefix(accent, "en-"), "en_")
+	if accent == "gb" {
+		accent = models.AccentUK
+	}
+	if !slices.Contains(models.Accents, accent) {
+		return "", fmt.Errorf("%w: unsupported accent %q", word.ErrInvalidData, accent)
+	}
+	return accent, nil
+}
+
+// validateVariant проверяет вариант произношения автора и разбирает его транскрипцию.
+func validateVariant(variant *models.AccentVariant) error {
+	accent, err := normalizeAccent(variant.Accent)
+	if err != nil {
+		return err
+	}
+	ipa, err := parseTranscription(variant.Transcription)
+	if err != nil {
+		return err
+	}
+	if len(ipa.Phonemes) == 0 {
+		return fmt.Errorf("%w: transcription is required", word.ErrInvalidData)
+	}
+	variant.Accent, variant.Transcription, variant.Phonemes = accent, ipa.Display, ipa.Phonemes
+	return nil
+}
+
+// applyAccent заменяет транскрипции, фонемы и озвучку упражнений вариантами с акцентом accent.
+// Где варианта нет, остаётся основное произношение. При ошибке упражнения отдаются как есть.
+func (uc *WordUsecase) applyAccent(ctx context.Context, exercises []models.Exercise, phrases bool, accent *string) {
+	if len(exercises) == 0 || accent == nil {
+		return
+	}
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	ids := make([]int, 0, len(exercises))
+	for i := range exercises {
+		ids = append(ids, exercises[i].ID)
+	}
+
+	if phrases {
+		variants, err := uc.wordRepo.GetPhraseVariants(ctx, ids, *accent)
+		if err != nil {
+			uc.logger.LogError(requestId, logger.UsecaseLayer, "applyAccent", err)
+			return
+		}
+		for i := range exercises {
+			if variant, ok := variants[exercises[i].ID]; ok {
+				applyVariant(&exercises[i], 0, variant)
+			}
+		}
+		return
+	}
+
+	variants, err := uc.wordRepo.GetWordVariants(ctx, ids, *accent)
+	if err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "applyAccent", err)
+		return
+	}
+	for i := range exercises {
+		for position, variant := range variants[exercises[i].ID] {
+			applyVariant(&exercises[i], position, variant)
+		}
+	}
+}
+
+// applyVariant подставляет вариант в слово position упражнения; у фразы позиция всегда 0.
+func applyVariant(exercise *models.Exercise, position int, variant models.AccentVariant) {
+	if position >= len(exercise.Words) {
+		return
+	}
+	if position < len(exercise.Transcriptions) {
+		exercise.Transcriptions[position] = variant.Transcription
+	}
+	if position < len(exercise.Phonemes) {
+		exercise.Phonemes[position] = variant.Phonemes
+	}
+	if variant.Audio != "" && position < len(exercise.Audio) {
+		exercise.Audio[position] = variant.Audio
+	}
+}
+
+// SetWordVariant сохраняет вариант произношения слова с позиции position упражнения.
+func (uc *WordUsecase) SetWordVariant(ctx context.Context, exerciseID, position int, variant *models.AccentVariant) error {
+	if err := validateVariant(variant); err != nil {
+		return err
+	}
+
+	found, err := uc.wordRepo.SetWordVariant(ctx, exerciseID, position, variant)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "SetWordVariant", err)
+		return fmt.Errorf("failed to save word variant: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("%w: word %d of exercise %d", word.ErrNotFound, position, exerciseID)
+	}
+	return nil
+}
+
+func (uc *WordUsecase) DeleteWordVariant(ctx context.Context, exerciseID, position int, accent string) error {
+	accent, err := normalizeAccent(accent)
+	if err != nil {
+		return err
+	}
+
+	found, err := uc.wordRepo.DeleteWordVariant(ctx, exerciseID, position, accent)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "DeleteWordVariant", err)
+		return fmt.Errorf("failed to delete word variant: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("%w: %s variant of word %d of exercise %d", word.ErrNotFound, accent, position, exerciseID)
+	}
+	return nil
+}
+
+func (uc *WordUsecase) SetPhraseVariant(ctx context.Context, exerciseID int, variant *models.AccentVariant) error {
+	if err := validateVariant(variant); err != nil {
+		return err
+	}
+
+	found, err := uc.wordRepo.SetPhraseVariant(ctx, exerciseID, variant)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "SetPhraseVariant", err)
+		return fmt.Errorf("failed to save phrase variant: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("%w: phrase exercise %d", word.ErrNotFound, exerciseID)
+	}
+	return nil
+}
+
+func (uc *WordUsecase) DeletePhraseVariant(ctx context.Context, exerciseID int, accent string) error {
+	accent, err := normalizeAccent(accent)
+	if err != nil {
+		return err
+	}
+
+	found, err := uc.wordRepo.DeletePhraseVariant(ctx, exerciseID, accent)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "DeletePhraseVariant", err)
+		return fmt.Errorf("failed to delete phrase variant: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("%w: %s variant of phrase exercise %d", word.ErrNotFound, accent, exerciseID)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+)
+
+// recordAttempt сохраняет попытку и пересчитывает по ней сводку exercise_progress в рамках tx.
+// Возвращает id сводки и итоговый статус упражнения.
+func (uc *WordUsecase) recordAttempt(ctx context.Context, tx models.Transaction, attempt *models.ExerciseAttempt) (int, string, error) {
+	if _, err := uc.wordRepo.InsertExerciseAttempt(ctx, tx, attempt); err != nil {
+		return 0, "", err
+	}
+
+	progressID, status, err := uc.wordRepo.RefreshExerciseProgress(ctx, tx, attempt.UserID, attempt.ExerciseID, attempt.ExerciseType)
+	if err != nil {
+		return 0, "", err
+	}
+
+	if status == "completed" && attempt.ExerciseType == "word" {
+		if err := uc.wordRepo.AddReviewItem(ctx, tx, attempt.UserID, attempt.ExerciseID, attempt.ExerciseType); err != nil {
+			return 0, "", err
+		}
+	}
+
+	for _, listener := range uc.listeners {
+		if err := listener.OnAttempt(ctx, tx, attempt, status); err != nil {
+			return 0, "", err
+		}
+	}
+
+	return progressID, status, nil
+}
+
+func (uc *WordUsecase) GetExerciseAttempts(ctx context.Context, userID uuid.UUID, exerciseID int, exerciseType string) (*models.AttemptList, error) {
+	attempts, err := uc.wordRepo.GetExerciseAttempts(ctx, userID, exerciseID, exerciseType)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetExerciseAttempts", err)
+		return nil, fmt.Errorf("failed to get exercise attempts: %w", err)
+	}
+	return attempts, nil
+}
@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/internal/word"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+	"strings"
+	"unicode/utf8"
+)
+
+const maxBookmarkNoteLength = 2000
+
+func validateBookmarkNote(note string) (string, error) {
+	note = strings.TrimSpace(note)
+	if utf8.RuneCountInString(note) > maxBookmarkNoteLength {
+		return "", fmt.Errorf("%w: note must be at most %d characters", word.ErrInvalidData, maxBookmarkNoteLength)
+	}
+	return note, nil
+}
+
+func validateBookmarkFilter(filter *models.BookmarkFilter) error {
+	switch filter.Type {
+	case "", models.BookmarkWord, models.BookmarkPhrase:
+	default:
+		return fmt.Errorf("%w: type must be word or phrase", word.ErrInvalidData)
+	}
+	if filter.ModuleID != nil && filter.Type == "" {
+		return fmt.Errorf("%w: module_id requires type", word.ErrInvalidData)
+	}
+	return nil
+}
+
+// AddBookmark ставит закладку на слово или фразу; повторная закладка на то же место
+// только обновляет заметку.
+func (uc *WordUsecase) AddBookmark(ctx context.Context, userID uuid.UUID, data *models.BookmarkCreate) (*models.Bookmark, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	if data.Type != models.BookmarkWord && data.Type != models.BookmarkPhrase {
+		return nil, fmt.Errorf("%w: type must be word or phrase", word.ErrInvalidData)
+	}
+	if data.ExerciseID == nil || *data.ExerciseID <= 0 {
+		return nil, fmt.Errorf("%w: exercise_id is required", word.ErrInvalidData)
+	}
+	if data.WordIndex < 0 || (data.Type == models.BookmarkPhrase && data.WordIndex != 0) {
+		return nil, fmt.Errorf("%w: invalid word_index", word.ErrInvalidData)
+	}
+	note, err := validateBookmarkNote(data.Note)
+	if err != nil {
+		return nil, err
+	}
+	data.Note = note
+
+	bookmarkID, err := uc.wordRepo.UpsertBookmark(ctx, userID, data)
+	if err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "AddBookmark", err)
+		return nil, fmt.Errorf("failed to save bookmark: %w", err)
+	}
+	if bookmarkID == 0 {
+		return nil, fmt.Errorf("%w: %s exercise %d", word.ErrNotFound, data.Type, *data.ExerciseID)
+	}
+
+	bookmarks, err := uc.wordRepo.GetBookmarks(ctx, userID, &models.BookmarkFilter{Type: data.Type}, &bookmarkID)
+	if err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "AddBookmark", err)
+		return nil, fmt.Errorf("failed to get bookmark: %w", err)
+	}
+	if len(bookmarks) == 0 {
+		return nil, fmt.Errorf("%w: bookmark %d", word.ErrNotFound, bookmarkID)
+	}
+	return &bookmarks[0], nil
+}
+
+func (uc *WordUsecase) GetBookmarks(ctx context.Context, userID uuid.UUID, filter *models.BookmarkFilter) (*models.BookmarkList, error) {
+	if err := validateBookmarkFilter(filter); err != nil {
+		return nil, err
+	}
+
+	bookmarks, err := uc.wordRepo.GetBookmarks(ctx, userID, filter, nil)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetBookmarks", err)
+		return nil, fmt.Errorf("failed to get bookmarks: %w", err)
+	}
+	return &models.BookmarkList{Bookmarks: bookmarks}, nil
+}
+
+func (uc *WordUsecase) UpdateBookmarkNote(ctx context.Context, userID uuid.UUID, bookmarkID int, data *models.BookmarkNote) error {
+	note, err := validateBookmarkNote(data.Note)
+	if err != nil {
+		return err
+	}
+
+	found, err := uc.wordRepo.UpdateBookmarkNote(ctx, userID, bookmarkID, note)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "UpdateBookmarkNote", err)
+		return fmt.Errorf("failed to update bookmark: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("%w: bookmark %d", word.ErrNotFound, bookmarkID)
+	}
+	return nil
+}
+
+func (uc *WordUsecase) DeleteBookmark(ctx context.Context, userID uuid.UUID, bookmarkID int) error {
+	found, err := uc.wordRepo.DeleteBookmark(ctx, userID, bookmarkID)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "DeleteBookmark", err)
+		return fmt.Errorf("failed to delete bookmark: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("%w: bookmark %d", word.ErrNotFound, bookmarkID)
+	}
+	return nil
+}
+
+// GetBookmarkExercises собирает упражнения из закладок для тренировки; без типа берутся слова.
+// Упражнения проходятся через обычные эндпоинты произношения и прогресса.
+func (uc *WordUsecase) GetBookmarkExercises(ctx context.Context, userID uuid.UUID, filter *models.BookmarkFilter, languages []string) (*models.ExerciseList, error) {
+	if filter.Type == "" {
+		filter.Type = models.BookmarkWord
+	}
+	if err := validateBookmarkFilter(filter); err != nil {
+		return nil, err
+	}
+
+	var exercises []models.Exercise
+	var err error
+	if filter.Type == models.BookmarkWord {
+		exercises, err = uc.wordRepo.GetBookmarkedWordExercises(ctx, userID, filter.ModuleID)
+	} else {
+		exercises, err = uc.wordRepo.GetBookmarkedPhraseExercises(ctx, userID, filter.ModuleID)
+	}
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetBookmarkExercises", err)
+		return nil, fmt.Errorf("failed to get bookmarked exercises: %w", err)
+	}
+	uc.prepareForUser(ctx, userID, exercises, filter.Type == models.BookmarkPhrase, languages)
+	return &models.ExerciseList{Exercises: exercises}, nil
+}
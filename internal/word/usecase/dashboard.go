@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+	"math"
+)
+
+const dashboardRecentWords = 10
+
+// GetProgressDashboard собирает прогресс по модулям обоих курсов и недавние слова.
+// Пропущенные после вступительного теста упражнения засчитываются как пройденные.
+func (uc *WordUsecase) GetProgressDashboard(ctx context.Context, userID uuid.UUID) (*models.ProgressDashboard, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	wordTrack, err := uc.trackProgress(ctx, userID, "word")
+	if err != nil {
+		return nil, err
+	}
+	phraseTrack, err := uc.trackProgress(ctx, userID, "phrase")
+	if err != nil {
+		return nil, err
+	}
+
+	totals := models.TrackProgress{Track: "all"}
+	addTrackProgress(&totals, wordTrack)
+	addTrackProgress(&totals, phraseTrack)
+	totals.CompletionPercent = completionPercent(totals.Completed+totals.Skipped, totals.Total)
+
+	recent, err := uc.wordRepo.GetRecentWords(ctx, userID, dashboardRecentWords)
+	if err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetProgressDashboard", err)
+		return nil, fmt.Errorf("failed to get recent words: %w", err)
+	}
+
+	return &models.ProgressDashboard{
+		Totals:      totals,
+		Word:        *wordTrack,
+		Phrase:      *phraseTrack,
+		RecentWords: recent,
+	}, nil
+}
+
+func (uc *WordUsecase) trackProgress(ctx context.Context, userID uuid.UUID, track string) (*models.TrackProgress, error) {
+	modules, err := uc.wordRepo.GetModuleProgress(ctx, userID, track)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetProgressDashboard", err)
+		return nil, fmt.Errorf("failed to get %s progress: %w", track, err)
+	}
+
+	result := &models.TrackProgress{Track: track, Modules: modules}
+	for i := range modules {
+		m := &modules[i]
+		m.CompletionPercent = completionPercent(m.Completed+m.Skipped, m.Total)
+
+		result.Total += m.Total
+		result.Attempted += m.Attempted
+		result.Completed += m.Completed
+		result.Failed += m.Failed
+		result.Skipped += m.Skipped
+		result.Attempts += m.Attempts
+		result.TimeSpentMs += m.TimeSpentMs
+	}
+	result.CompletionPercent = completionPercent(result.Completed+result.Skipped, result.Total)
+
+	return result, nil
+}
+
+func addTrackProgress(totals *models.TrackProgress, track *models.TrackProgress) {
+	totals.Total += track.Total
+	totals.Attempted += track.Attempted
+	totals.Completed += track.Completed
+	totals.Failed += track.Failed
+	totals.Skipped += track.Skipped
+	totals.Attempts += track.Attempts
+	totals.TimeSpentMs += track.TimeSpentMs
+}
+
+func completionPercent(done, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(done)*1000/float64(total)) / 10
+}
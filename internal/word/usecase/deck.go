@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/internal/word"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+const maxDeckTitleLength = 100
+
+func validateDeckTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return "", fmt.Errorf("%w: title is required", word.ErrInvalidData)
+	}
+	if utf8.RuneCountInString(title) > maxDeckTitleLength {
+		return "", fmt.Errorf("%w: title must be at most %d characters", word.ErrInvalidData, maxDeckTitleLength)
+	}
+	return title, nil
+}
+
+func (uc *WordUsecase) CreateDeck(ctx context.Context, userID uuid.UUID, data *models.DeckCreate) (*models.Deck, error) {
+	title, err := validateDeckTitle(data.Title)
+	if err != nil {
+		return nil, err
+	}
+
+	deck := &models.Deck{Title: title}
+	if err := uc.wordRepo.CreateDeck(ctx, userID, deck); err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "CreateDeck", err)
+		return nil, fmt.Errorf("failed to create deck: %w", err)
+	}
+	return deck, nil
+}
+
+func (uc *WordUsecase) GetDecks(ctx context.Context, userID uuid.UUID) (*models.DeckList, error) {
+	decks, err := uc.wordRepo.GetDecks(ctx, userID)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetDecks", err)
+		return nil, fmt.Errorf("failed to get decks: %w", err)
+	}
+	return &models.DeckList{Decks: decks}, nil
+}
+
+// GetDeck возвращает набор вместе со словами и прогрессом пользователя по ним.
+// Переводы слов выбираются по профилю и языкам languages.
+func (uc *WordUsecase) GetDeck(ctx context.Context, userID uuid.UUID, deckID int, languages []string) (*models.Deck, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	deck, err := uc.wordRepo.GetDeck(ctx, userID, deckID)
+	if err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetDeck", err)
+		return nil, fmt.Errorf("failed to get deck: %w", err)
+	}
+	if deck == nil {
+		return nil, fmt.Errorf("%w: deck %d", word.ErrNotFound, deckID)
+	}
+
+	exercises, err := uc.wordRepo.GetWordModuleExercises(ctx, userID.String(), deckID)
+	if err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetDeck", err)
+		return nil, fmt.Errorf("failed to get deck words: %w", err)
+	}
+	deck.Exercises = exercises.Exercises
+	uc.prepareForUser(ctx, userID, deck.Exercises, false, languages)
+
+	return deck, nil
+}
+
+func (uc *WordUsecase) RenameDeck(ctx context.Context, userID uuid.UUID, deckID int, data *models.DeckCreate) error {
+	title, err := validateDeckTitle(data.Title)
+	if err != nil {
+		return err
+	}
+
+	found, err := uc.wordRepo.RenameDeck(ctx, userID, deckID, title)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "RenameDeck", err)
+		return fmt.Errorf("failed to rename deck: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("%w: deck %d", word.ErrNotFound, deckID)
+	}
+	return nil
+}
+
+func (uc *WordUsecase) DeleteDeck(ctx context.Context, userID uuid.UUID, deckID int) error {
+	found, err := uc.wordRepo.DeleteDeck(ctx, userID, deckID)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "DeleteDeck", err)
+		return fmt.Errorf("failed to delete deck: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("%w: deck %d", word.ErrNotFound, deckID)
+	}
+	return nil
+}
+
+// deckExerciseTypes - виды упражнений общих модулей, которые можно создать для слова набора.
+var deckExerciseTypes = []string{"pronounce", "guessWord"}
+
+// AddDeckWord добавляет слово в набор упражнениями тех же видов, что и в общих модулях,
+// чтобы его можно было проходить и повторять так же. Для угадывания слово ставится в пару
+// с последним добавленным в набор другим словом, поэтому оба должны быть с озвучкой.
+func (uc *WordUsecase) AddDeckWord(ctx context.Context, userID uuid.UUID, data *models.DeckWordCreate) ([]int, error) {
+	data.Word = strings.TrimSpace(data.Word)
+	data.Translation = strings.TrimSpace(data.Translation)
+	data.Transcription = strings.TrimSpace(data.Transcription)
+	if data.Word == "" || data.Translation == "" || data.Transcription == "" {
+		return nil, fmt.Errorf("%w: word, translation and transcription are required", word.ErrInvalidData)
+	}
+	ipa, err := parseTranscription(data.Transcription)
+	if err != nil {
+		return nil, err
+	}
+	data.Transcription, data.Phonemes = ipa.Display, ipa.Phonemes
+
+	if len(data.Exercises) == 0 {
+		data.Exercises = []string{"pronounce"}
+	}
+	for i, exerciseType := range data.Exercises {
+		if !slices.Contains(deckExerciseTypes, exerciseType) || slices.Contains(data.Exercises[:i], exerciseType) {
+			return nil, fmt.Errorf("%w: exercises must be distinct values of %s", word.ErrInvalidData, strings.Join(deckExerciseTypes, ", "))
+		}
+	}
+	if slices.Contains(data.Exercises, "guessWord") && data.AudioLink == "" {
+		return nil, fmt.Errorf("%w: guessWord needs audio", word.ErrInvalidData)
+	}
+
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	tx, err := uc.wordRepo.BeginTx(ctx)
+	if err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "AddDeckWord", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	entry := models.LexiconEntry{
+		Word:         data.Word,
+		IPA:          data.Transcription,
+		Translations: []string{data.Translation},
+		Audio:        data.AudioLink,
+		Phonemes:     data.Phonemes,
+	}
+
+	exerciseIDs := make([]int, 0, len(data.Exercises))
+	for _, exerciseType := range data.Exercises {
+		entries := []models.LexiconEntry{entry}
+		if exerciseType == "guessWord" {
+			var partner *models.LexiconEntry
+			if partner, err = uc.wordRepo.GetDeckGuessPartner(ctx, tx, userID, data.DeckID, data.Word); err != nil {
+				uc.logger.LogError(requestId, logger.UsecaseLayer, "AddDeckWord", err)
+				return nil, fmt.Errorf("failed to add deck word: %w", err)
+			}
+			if partner == nil {
+				err = fmt.Errorf("%w: guessWord needs another word with audio in deck %d", word.ErrInvalidData, data.DeckID)
+				return nil, err
+			}
+			entries = append(entries, *partner)
+		}
+
+		var exerciseID int
+		if exerciseID, err = uc.wordRepo.AddDeckWord(ctx, tx, userID, data.DeckID, exerciseType, entries); err != nil {
+			uc.logger.LogError(requestId, logger.UsecaseLayer, "AddDeckWord", err)
+			return nil, fmt.Errorf("failed to add deck word: %w", err)
+		}
+		if exerciseID == 0 {
+			err = fmt.Errorf("%w: deck %d", word.ErrNotFound, data.DeckID)
+			return nil, err
+		}
+		exerciseIDs = append(exerciseIDs, exerciseID)
+	}
+
+	if err = tx.Commit(); err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "AddDeckWord", err)
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return exerciseIDs, nil
+}
+
+func (uc *WordUsecase) DeleteDeckWord(ctx context.Context, userID uuid.UUID, deckID, exerciseID int) error {
+	found, err := uc.wordRepo.DeleteDeckWord(ctx, userID, deckID, exerciseID)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "DeleteDeckWord", err)
+		return fmt.Errorf("failed to delete deck word: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("%w: word %d in deck %d", word.ErrNotFound, exerciseID, deckID)
+	}
+	return nil
+}
@@ -0,0 +1,351 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/internal/word"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	maxExampleLength = 300
+	// exercisesExamplesPerWord - сколько примеров показывается к каждому слову упражнения
+	exercisesExamplesPerWord = 3
+	examplesBackfillBatch    = 500
+)
+
+// irregularForms - неправильные формы частых слов и их начальные формы.
+var irregularForms = map[string]string{
+	"am": "be", "is": "be", "are": "be", "was": "be", "were": "be", "been": "be",
+	"has": "have", "had": "have", "does": "do", "did": "do", "done": "do",
+	"went": "go", "gone": "go", "made": "make", "took": "take", "taken": "take",
+	"saw": "see", "seen": "see", "came": "come", "got": "get", "gotten": "get",
+	"gave": "give", "given": "give", "knew": "know", "known": "know",
+	"thought": "think", "told": "tell", "said": "say", "found": "find",
+	"left": "leave", "felt": "feel", "brought": "bring", "bought": "buy",
+	"ate": "eat", "eaten": "eat", "wrote": "write", "written": "write",
+	"spoke": "speak", "spoken": "speak", "ran": "run", "sang": "sing", "sung": "sing",
+	"men": "man", "women": "woman", "children": "child", "people": "person",
+	"feet": "foot", "teeth": "tooth", "mice": "mouse",
+	"better": "good", "best": "good", "worse": "bad", "worst": "bad",
+}
+
+// contractions - отрицания, основа которых не получается отбрасыванием n't.
+var contractions = map[string]string{"can't": "can", "won't": "will", "shan't": "shall"}
+
+// sentenceWords разбивает предложение на слова и для каждого слова перечисляет ключи
+// словаря, которыми может оказаться его начальная форма.
+func sentenceWords(sentence string) []models.SentenceWord {
+	var words []models.SentenceWord
+	position := 0
+	for _, form := range strings.FieldsFunc(sentence, isWordSeparator) {
+		form = strings.Trim(form, "'’")
+		if form == "" {
+			continue
+		}
+		for _, key := range wordKeys(form) {
+			words = append(words, models.SentenceWord{Position: position, Form: form, Key: key})
+		}
+		position++
+	}
+	return words
+}
+
+// isWordSeparator - всё, кроме букв и апострофа: дефис тоже разделяет слова (well-known).
+func isWordSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && r != '\'' && r != '’'
+}
+
+// wordKeys возвращает само слово в нижнем регистре и его возможные начальные формы:
+// без притяжательного 's и сокращений, единственное число, глагол без -s, -ed и -ing.
+// Основы подбираются по правилам написания, а не перебором: вариант, совпавший с другим
+// словом словаря (seed -> see, thing -> the), связал бы предложение с чужой статьёй.
+func wordKeys(form string) []string {
+	w := strings.ToLower(strings.ReplaceAll(form, "’", "'"))
+	keys := []string{w}
+	add := func(key string) {
+		if utf8.RuneCountInString(key) >= 2 && !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+
+	if base, ok := contractions[w]; ok {
+		add(base)
+		return keys
+	}
+	if strings.HasSuffix(w, "n't") {
+		w = strings.TrimSuffix(w, "n't")
+		add(w)
+	} else if i := strings.IndexByte(w, '\''); i > 0 {
+		// cat's, cats', it's, we'll
+		w = w[:i]
+		add(w)
+	}
+
+	if base, ok := irregularForms[w]; ok {
+		add(base)
+	}
+	if utf8.RuneCountInString(w) < 4 || uninflected[w] {
+		return keys
+	}
+
+	switch {
+	case strings.HasSuffix(w, "ies"), strings.HasSuffix(w, "ied"):
+		add(w[:len(w)-3] + "y")
+		add(w[:len(w)-1])
+	case strings.HasSuffix(w, "es"):
+		// -es после шипящих и o: boxes, watches, goes; иначе это -s после немой e: notes
+		stem := w[:len(w)-2]
+		if strings.HasSuffix(stem, "ss") || strings.HasSuffix(stem, "ch") || strings.HasSuffix(stem, "sh") ||
+			strings.HasSuffix(stem, "x") || strings.HasSuffix(stem, "z") || strings.HasSuffix(stem, "o") {
+			add(stem)
+		}
+		add(w[:len(w)-1])
+	case strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss"):
+		add(w[:len(w)-1])
+	case strings.HasSuffix(w, "eed"):
+		// agreed, freed; у seed и need основы нет
+		if len(w) > 4 {
+			add(w[:len(w)-1])
+		}
+	case strings.HasSuffix(w, "ed"):
+		for _, stem := range verbStems(w[:len(w)-2]) {
+			add(stem)
+		}
+	case strings.HasSuffix(w, "ying"):
+		add(w[:len(w)-3])
+		add(w[:len(w)-4] + "ie")
+	case strings.HasSuffix(w, "ing"):
+		for _, stem := range verbStems(w[:len(w)-3]) {
+			add(stem)
+		}
+	}
+	return keys
+}
+
+// uninflected - частые слова на -ing и -ed, которые не являются формами глагола, но по
+// правилам дали бы другое слово словаря.
+var uninflected = map[string]bool{
+	"morning": true, "evening": true, "herring": true, "wedding": true, "wicked": true,
+}
+
+// verbStems возвращает возможные глаголы для основы, оставшейся после -ed или -ing:
+// stopp -> stop, be -> be, hop -> hope, visit -> visit, danc -> dance. Основа без гласных
+// (th из thing, w из wing) глаголом не считается.
+func verbStems(stem string) []string {
+	n := len(stem)
+	if n < 2 || !strings.ContainsAny(stem, "aeiouy") {
+		return nil
+	}
+
+	last := stem[n-1]
+	switch {
+	case n >= 3 && last == stem[n-2] && !isVowel(last):
+		// удвоенная согласная: running -> run, но calling -> call
+		return []string{stem[:n-1], stem}
+	case isVowel(last) || last == 'w' || last == 'x' || last == 'y':
+		// being -> be, seeing -> see, played -> play: немая e перед -ing не выпадает
+		return []string{stem}
+	case !isVowel(stem[n-2]) || (n >= 3 && isVowel(stem[n-3])):
+		// основа на стечение согласных или на две гласные: walked, danced, rained
+		return []string{stem, stem + "e"}
+	case vowelGroups(stem) == 1:
+		// в коротком слове согласная перед окончанием удваивается (hopped),
+		// поэтому hoped и using образованы от hope и use
+		return []string{stem + "e"}
+	default:
+		// visited, opened, completed
+		return []string{stem, stem + "e"}
+	}
+}
+
+func isVowel(b byte) bool {
+	return strings.IndexByte("aeiou", b) >= 0
+}
+
+// vowelGroups считает группы гласных - приблизительное число слогов.
+func vowelGroups(s string) int {
+	groups := 0
+	for i := 0; i < len(s); i++ {
+		if isVowel(s[i]) && (i == 0 || !isVowel(s[i-1])) {
+			groups++
+		}
+	}
+	return groups
+}
+
+// attachExamples добавляет к упражнениям предложения, где встречаются их слова. При ошибке
+// упражнения отдаются без примеров.
+func (uc *WordUsecase) attachExamples(ctx context.Context, exercises []models.Exercise) {
+	if len(exercises) == 0 {
+		return
+	}
+
+	ids := make([]int, 0, len(exercises))
+	for i := range exercises {
+		ids = append(ids, exercises[i].ID)
+	}
+
+	examples, err := uc.wordRepo.GetExerciseExamples(ctx, ids, exercisesExamplesPerWord)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "attachExamples", err)
+		return
+	}
+	for i := range exercises {
+		exercises[i].Examples = examples[exercises[i].ID]
+	}
+}
+
+// GetLexiconExamples возвращает предложения, где встречается слово общей статьи словаря.
+func (uc *WordUsecase) GetLexiconExamples(ctx context.Context, entryID, limit, offset int) (*models.ExampleList, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	examples, found, err := uc.wordRepo.GetLexiconExamples(ctx, entryID, limit, offset)
+	if err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetLexiconExamples", err)
+		return nil, fmt.Errorf("failed to get examples: %w", err)
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: lexicon entry %d", word.ErrNotFound, entryID)
+	}
+	return &models.ExampleList{Examples: examples}, nil
+}
+
+func validateExample(example *models.ExampleData) error {
+	example.Sentence = strings.TrimSpace(example.Sentence)
+	example.Translation = strings.TrimSpace(example.Translation)
+	if example.Sentence == "" {
+		return fmt.Errorf("%w: sentence is required", word.ErrInvalidData)
+	}
+	if utf8.RuneCountInString(example.Sentence) > maxExampleLength {
+		return fmt.Errorf("%w: sentence is longer than %d characters", word.ErrInvalidData, maxExampleLength)
+	}
+	if utf8.RuneCountInString(example.Translation) > maxExampleLength {
+		return fmt.Errorf("%w: translation is longer than %d characters", word.ErrInvalidData, maxExampleLength)
+	}
+	return nil
+}
+
+// CreateExample добавляет отдельный пример и сразу связывает его со словами словаря.
+func (uc *WordUsecase) CreateExample(ctx context.Context, example *models.ExampleData) (int, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+	if err := validateExample(example); err != nil {
+		return 0, err
+	}
+
+	tx, err := uc.wordRepo.BeginTx(ctx)
+	if err != nil {
+		return 0, errors.New("error begin tx")
+	}
+
+	exampleID, err := uc.wordRepo.CreateExample(ctx, tx, example)
+	if err == nil {
+		err = uc.wordRepo.SetSentenceWords(ctx, tx, false, exampleID, sentenceWords(example.Sentence))
+	}
+	if err != nil {
+		tx.Rollback()
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "CreateExample", err)
+		return 0, fmt.Errorf("failed to create example: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "CreateExample", err)
+		return 0, fmt.Errorf("failed to commit example: %w", err)
+	}
+	return exampleID, nil
+}
+
+// UpdateExample заменяет текст примера и заново разбирает его на слова. Без нового
+// файла озвучка остаётся прежней.
+func (uc *WordUsecase) UpdateExample(ctx context.Context, example *models.ExampleData) error {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+	if err := validateExample(example); err != nil {
+		return err
+	}
+
+	tx, err := uc.wordRepo.BeginTx(ctx)
+	if err != nil {
+		return errors.New("error begin tx")
+	}
+
+	found, err := uc.wordRepo.UpdateExample(ctx, tx, example)
+	if err == nil && found {
+		err = uc.wordRepo.SetSentenceWords(ctx, tx, false, example.ID, sentenceWords(example.Sentence))
+	}
+	if err != nil {
+		tx.Rollback()
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "UpdateExample", err)
+		return fmt.Errorf("failed to update example: %w", err)
+	}
+	if !found {
+		tx.Rollback()
+		return fmt.Errorf("%w: example %d", word.ErrNotFound, example.ID)
+	}
+
+	if err := tx.Commit(); err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "UpdateExample", err)
+		return fmt.Errorf("failed to commit example: %w", err)
+	}
+	return nil
+}
+
+func (uc *WordUsecase) DeleteExample(ctx context.Context, exampleID int) error {
+	found, err := uc.wordRepo.DeleteExample(ctx, exampleID)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "DeleteExample", err)
+		return fmt.Errorf("failed to delete example: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("%w: example %d", word.ErrNotFound, exampleID)
+	}
+	return nil
+}
+
+// BackfillSentenceWords разбирает на слова предложения фразовых упражнений, созданных до
+// появления примеров, чтобы они показывались к словам словаря.
+func (uc *WordUsecase) BackfillSentenceWords(ctx context.Context) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	indexed, afterID := 0, 0
+	for ctx.Err() == nil {
+		batch, err := uc.wordRepo.GetPhrasesWithoutWords(ctx, afterID, examplesBackfillBatch)
+		if err != nil {
+			uc.logger.LogError(requestId, logger.UsecaseLayer, "BackfillSentenceWords", err)
+			return
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		tx, err := uc.wordRepo.BeginTx(ctx)
+		if err != nil {
+			uc.logger.LogError(requestId, logger.UsecaseLayer, "BackfillSentenceWords", err)
+			return
+		}
+		for _, sentence := range batch {
+			afterID = sentence.ID
+			if err := uc.wordRepo.SetSentenceWords(ctx, tx, true, sentence.ID, sentenceWords(sentence.Sentence)); err != nil {
+				tx.Rollback()
+				uc.logger.LogError(requestId, logger.UsecaseLayer, "BackfillSentenceWords", err)
+				return
+			}
+		}
+		if err := tx.Commit(); err != nil {
+			uc.logger.LogError(requestId, logger.UsecaseLayer, "BackfillSentenceWords", err)
+			return
+		}
+		indexed += len(batch)
+	}
+	if indexed > 0 {
+		uc.logger.LogInfo(requestId, logger.UsecaseLayer, "BackfillSentenceWords", fmt.Sprintf("indexed %d phrase sentences", indexed))
+	}
+}
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestWordKeys(t *testing.T) {
+	tests := []struct {
+		form    string
+		want    []string // ключи, которые должны быть среди вариантов
+		notWant []string // другие слова словаря, с которыми форму путать нельзя
+	}{
+		{form: "Cat", want: []string{"cat"}},
+		{form: "cat's", want: []string{"cat's", "cat"}},
+		{form: "don't", want: []string{"do"}},
+		{form: "won't", want: []string{"will"}},
+		{form: "went", want: []string{"go"}},
+		{form: "cities", want: []string{"city"}},
+		{form: "boxes", want: []string{"box"}},
+		{form: "goes", want: []string{"go"}},
+		{form: "notes", want: []string{"note"}, notWant: []string{"not"}},
+		{form: "books", want: []string{"book"}},
+		{form: "class", want: []string{"class"}, notWant: []string{"clas"}},
+		{form: "walked", want: []string{"walk"}},
+		{form: "danced", want: []string{"dance"}},
+		{form: "hoped", want: []string{"hope"}, notWant: []string{"hop"}},
+		{form: "hopped", want: []string{"hop"}},
+		{form: "used", want: []string{"use"}, notWant: []string{"us"}},
+		{form: "visited", want: []string{"visit"}},
+		{form: "played", want: []string{"play"}},
+		{form: "agreed", want: []string{"agree"}},
+		{form: "studied", want: []string{"study"}},
+		{form: "seed", want: []string{"seed"}, notWant: []string{"see"}},
+		{form: "need", want: []string{"need"}, notWant: []string{"nee"}},
+		{form: "walking", want: []string{"walk"}},
+		{form: "making", want: []string{"make"}},
+		{form: "writing", want: []string{"write"}, notWant: []string{"writ"}},
+		{form: "running", want: []string{"run"}},
+		{form: "calling", want: []string{"call"}},
+		{form: "seeing", want: []string{"see"}},
+		{form: "being", want: []string{"be"}, notWant: []string{"bee"}},
+		{form: "lying", want: []string{"lie"}},
+		{form: "playing", want: []string{"play"}},
+		{form: "thing", want: []string{"thing"}, notWant: []string{"th", "the"}},
+		{form: "wing", want: []string{"wing"}, notWant: []string{"we"}},
+		{form: "bring", want: []string{"bring"}, notWant: []string{"br", "bre"}},
+		{form: "morning", want: []string{"morning"}, notWant: []string{"morn"}},
+		{form: "evening", want: []string{"evening"}, notWant: []string{"even"}},
+	}
+
+	for _, tt := range tests {
+		keys := wordKeys(tt.form)
+		for _, key := range tt.want {
+			if !slices.Contains(keys, key) {
+				t.Errorf("wordKeys(%q) = %q, want key %q", tt.form, keys, key)
+			}
+		}
+		for _, key := range tt.notWant {
+			if slices.Contains(keys, key) {
+				t.Errorf("wordKeys(%q) = %q, must not contain %q", tt.form, keys, key)
+			}
+		}
+	}
+}
+
+func TestSentenceWords(t *testing.T) {
+	tests := []struct {
+		sentence string
+		want     []models.SentenceWord
+	}{
+		{
+			sentence: "",
+			want:     nil,
+		},
+		{
+			sentence: "I can't see.",
+			want: []models.SentenceWord{
+				{Position: 0, Form: "I", Key: "i"},
+				{Position: 1, Form: "can't", Key: "can't"},
+				{Position: 1, Form: "can't", Key: "can"},
+				{Position: 2, Form: "see", Key: "see"},
+			},
+		},
+		{
+			sentence: "A well-known 'seed'",
+			want: []models.SentenceWord{
+				{Position: 0, Form: "A", Key: "a"},
+				{Position: 1, Form: "well", Key: "well"},
+				{Position: 2, Form: "known", Key: "known"},
+				{Position: 2, Form: "known", Key: "know"},
+				{Position: 3, Form: "seed", Key: "seed"},
+			},
+		},
+		{
+			sentence: "Cats’ toys",
+			want: []models.SentenceWord{
+				{Position: 0, Form: "Cats", Key: "cats"},
+				{Position: 0, Form: "Cats", Key: "cat"},
+				{Position: 1, Form: "toys", Key: "toys"},
+				{Position: 1, Form: "toys", Key: "toy"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := sentenceWords(tt.sentence); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("sentenceWords(%q) = %+v, want %+v", tt.sentence, got, tt.want)
+		}
+	}
+}
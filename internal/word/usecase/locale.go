@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/internal/word"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+	"slices"
+	"strings"
+)
+
+// contentLocale приводит язык переводов к виду из models.ContentLocales: kk-KZ -> kk.
+func contentLocale(locale string) (string, error) {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(locale, "-_"); i >= 0 {
+		locale = locale[:i]
+	}
+	if !slices.Contains(models.ContentLocales, locale) {
+		return "", fmt.Errorf("%w: unsupported locale %q", word.ErrInvalidData, locale)
+	}
+	return locale, nil
+}
+
+// localizedLocale проверяет язык перевода автора: язык поддерживается и не основной -
+// перевод на основном языке передаётся отдельно и хранится в самом упражнении.
+func localizedLocale(locale string) (string, error) {
+	normalized, err := contentLocale(locale)
+	if err != nil {
+		return "", err
+	}
+	if normalized == models.DefaultContentLocale {
+		return "", fmt.Errorf("%w: %s translation must be passed as the main one", word.ErrInvalidData, normalized)
+	}
+	return normalized, nil
+}
+
+// normalizeTranslations проверяет языки переводов одного слова или фразы.
+func normalizeTranslations(translations map[string]string) (map[string]string, error) {
+	result := make(map[string]string, len(translations))
+	for locale, translation := range translations {
+		normalized, err := localizedLocale(locale)
+		if err != nil {
+			return nil, err
+		}
+		result[normalized] = translation
+	}
+	return result, nil
+}
+
+// normalizeTranslationLists проверяет языки переводов упражнения из count слов: на каждом
+// языке переводов столько же, сколько слов.
+func normalizeTranslationLists(translations map[string][]string, count int) (map[string][]string, error) {
+	result := make(map[string][]string, len(translations))
+	for locale, list := range translations {
+		normalized, err := localizedLocale(locale)
+		if err != nil {
+			return nil, err
+		}
+		if len(list) != count {
+			return nil, fmt.Errorf("%w: %s translations must match words", word.ErrInvalidData, normalized)
+		}
+		result[normalized] = list
+	}
+	return result, nil
+}
+
+// contentSettings возвращает настройки материалов ученика. Настройки вспомогательные:
+// у анонимного пользователя и при ошибке они пустые.
+func (uc *WordUsecase) contentSettings(ctx context.Context, userID uuid.UUID) *models.ContentSettings {
+	if uuid.Equal(userID, uuid.Nil) {
+		return &models.ContentSettings{}
+	}
+
+	settings, err := uc.wordRepo.GetContentSettings(ctx, userID)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "contentSettings", err)
+		return &models.ContentSettings{}
+	}
+	return settings
+}
+
+// translationLocales возвращает языки переводов для ученика в порядке предпочтения: язык из
+// профиля, затем из Accept-Language. Список обрывается на основном языке: его переводы
+// уже лежат в упражнениях.
+func translationLocales(settings *models.ContentSettings, languages []string) []string {
+	candidates := languages
+	if settings.Locale != nil {
+		candidates = append([]string{*settings.Locale}, languages...)
+	}
+
+	var locales []string
+	for _, candidate := range candidates {
+		locale, err := contentLocale(candidate)
+		if err != nil || slices.Contains(locales, locale) {
+			continue
+		}
+		if locale == models.DefaultContentLocale {
+			break
+		}
+		locales = append(locales, locale)
+	}
+	return locales
+}
+
+// localizeExercises заменяет переводы упражнений переводами на языках locales. Где перевода
+// на них нет, остаётся перевод на основном языке. При ошибке упражнения отдаются без замены.
+func (uc *WordUsecase) localizeExercises(ctx context.Context, exercises []models.Exercise, phrases bool, locales []string) {
+	if len(exercises) == 0 || len(locales) == 0 {
+		return
+	}
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	ids := make([]int, 0, len(exercises))
+	for i := range exercises {
+		ids = append(ids, exercises[i].ID)
+	}
+
+	if phrases {
+		translations, err := uc.wordRepo.GetPhraseTranslations(ctx, ids, locales)
+		if err != nil {
+			uc.logger.LogError(requestId, logger.UsecaseLayer, "localizeExercises", err)
+			return
+		}
+		for i := range exercises {
+			if translation, ok := translations[exercises[i].ID]; ok {
+				exercises[i].Translations = []string{translation}
+			}
+		}
+		return
+	}
+
+	translations, err := uc.wordRepo.GetWordTranslations(ctx, ids, locales)
+	if err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "localizeExercises", err)
+		return
+	}
+	for i := range exercises {
+		for position, translation := range translations[exercises[i].ID] {
+			if position < len(exercises[i].Translations) {
+				exercises[i].Translations[position] = translation
+			}
+		}
+	}
+}
+
+// prepareForUser готовит упражнения для ученика userID: выбирает переводы по профилю и языкам
+// languages из Accept-Language и произношение с акцентом из профиля. Возвращает выбранные
+// языки переводов - по ним подбираются советы.
+func (uc *WordUsecase) prepareForUser(ctx context.Context, userID uuid.UUID, exercises []models.Exercise, phrases bool, languages []string) []string {
+	settings := uc.contentSettings(ctx, userID)
+	locales := translationLocales(settings, languages)
+	uc.localizeExercises(ctx, exercises, phrases, locales)
+	uc.applyAccent(ctx, exercises, phrases, settings.Accent)
+	return locales
+}
+
+func (uc *WordUsecase) GetContentSettings(ctx context.Context, userID uuid.UUID) (*models.ContentSettings, error) {
+	settings, err := uc.wordRepo.GetContentSettings(ctx, userID)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetContentSettings", err)
+		return nil, fmt.Errorf("failed to get content settings: %w", err)
+	}
+	return settings, nil
+}
+
+// UpdateContentSettings меняет только переданные настройки, пустая строка сбрасывает настройку.
+func (uc *WordUsecase) UpdateContentSettings(ctx context.Context, userID uuid.UUID, settings *models.ContentSettings) (*models.ContentSettings, error) {
+	var err error
+	if settings.Locale != nil {
+		locale := strings.TrimSpace(*settings.Locale)
+		if locale != "" {
+			if locale, err = contentLocale(locale); err != nil {
+				return nil, err
+			}
+		}
+		settings.Locale = &locale
+	}
+	if settings.Accent != nil {
+		accent := strings.TrimSpace(*settings.Accent)
+		if accent != "" {
+			if accent, err = normalizeAccent(accent); err != nil {
+				return nil, err
+			}
+		}
+		settings.Accent = &accent
+	}
+
+	if err := uc.wordRepo.UpdateContentSettings(ctx, userID, settings); err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "UpdateContentSettings", err)
+		return nil, fmt.Errorf("failed to update content settings: %w", err)
+	}
+
+	return uc.GetContentSettings(ctx, userID)
+}
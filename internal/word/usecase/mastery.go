@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// каждое новое наблюдение уменьшает вес прошлых ещё и независимо от времени
+	masteryAttemptDecay = 0.85
+	// за это время вес старых наблюдений падает вдвое
+	masteryHalfLife       = 14 * 24 * time.Hour
+	masteryTrendSmoothing = 0.3
+	masteryTrendThreshold = 0.02
+)
+
+// phonemeObservations сводит выравнивание попытки к средней точности по каждой ожидаемой фонеме:
+// совпадение даёт 1, замена - 1 минус её цена, пропуск - 0. Вставки фонемам не засчитываются.
+func phonemeObservations(ops []models.PhonemeAlignment) map[string]float64 {
+	sums := make(map[string]float64)
+	counts := make(map[string]int)
+
+	for _, op := range ops {
+		if op.Expected == "" {
+			continue
+		}
+		value := 0.0
+		switch op.Operation {
+		case "match":
+			value = 1
+		case "substitution":
+			value = math.Max(0, 1-op.Cost)
+		}
+		sums[op.Expected] += value
+		counts[op.Expected]++
+	}
+
+	observations := make(map[string]float64, len(sums))
+	for phoneme, sum := range sums {
+		observations[phoneme] = sum / float64(counts[phoneme])
+	}
+	return observations
+}
+
+// applyObservation добавляет наблюдение в экспоненциально затухающее среднее.
+func applyObservation(m *models.PhonemeMastery, observed float64, now time.Time) {
+	decay := masteryAttemptDecay
+	if !m.UpdatedAt.IsZero() && now.After(m.UpdatedAt) {
+		decay *= math.Pow(0.5, float64(now.Sub(m.UpdatedAt))/float64(masteryHalfLife))
+	}
+
+	previous := m.Accuracy
+	oldWeight := m.Weight * decay
+	m.Weight = oldWeight + 1
+	m.Accuracy = (previous*oldWeight + observed) / m.Weight
+
+	if m.Attempts > 0 {
+		m.TrendValue = m.TrendValue*(1-masteryTrendSmoothing) + (m.Accuracy-previous)*masteryTrendSmoothing
+	}
+	m.Attempts++
+	if observed >= 1 {
+		m.Correct++
+	}
+	m.UpdatedAt = now
+}
+
+// updatePhonemeMastery обновляет освоение всех фонем, встретившихся в оценённой попытке.
+func (uc *WordUsecase) updatePhonemeMastery(ctx context.Context, tx models.Transaction, userID uuid.UUID, ops []models.PhonemeAlignment) error {
+	observations := phonemeObservations(ops)
+	if len(observations) == 0 {
+		return nil
+	}
+
+	phonemes := make([]string, 0, len(observations))
+	for phoneme := range observations {
+		phonemes = append(phonemes, phoneme)
+	}
+	sort.Strings(phonemes)
+
+	current, err := uc.wordRepo.GetPhonemeMasteryForUpdate(ctx, tx, userID, phonemes)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, phoneme := range phonemes {
+		m, ok := current[phoneme]
+		if !ok {
+			m = &models.PhonemeMastery{Phoneme: phoneme}
+		}
+		applyObservation(m, observations[phoneme], now)
+
+		if err := uc.wordRepo.UpsertPhonemeMastery(ctx, tx, userID, m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (uc *WordUsecase) GetUserPhonemes(ctx context.Context, userID uuid.UUID, languages []string) (*models.PhonemeMasteryList, error) {
+	locale := tipLocale(translationLocales(uc.contentSettings(ctx, userID), languages))
+	phonemes, err := uc.wordRepo.GetUserPhonemes(ctx, userID, locale)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetUserPhonemes", err)
+		return nil, fmt.Errorf("failed to get user phonemes: %w", err)
+	}
+
+	threshold := float64(uc.scorer.Threshold())
+	for i := range phonemes {
+		m := &phonemes[i]
+		m.Accuracy = math.Round(m.Accuracy*1000) / 10
+		m.TrendValue = math.Round(m.TrendValue*1000) / 10
+
+		switch {
+		case m.TrendValue >= masteryTrendThreshold*100:
+			m.Trend = "improving"
+		case m.TrendValue <= -masteryTrendThreshold*100:
+			m.Trend = "declining"
+		default:
+			m.Trend = "stable"
+		}
+
+		m.Weak = m.Accuracy < threshold
+		if !m.Weak {
+			m.Tip = nil
+		}
+	}
+
+	return &models.PhonemeMasteryList{Phonemes: phonemes}, nil
+}
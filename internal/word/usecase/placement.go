@@ -0,0 +1,317 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/internal/word"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	"github.com/TeaStealers-backend-sem4/pkg/phonetics"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+	"time"
+)
+
+const (
+	placementInProgress = "in_progress"
+	placementFinished   = "finished"
+
+	// столько верных ответов подряд нужно, чтобы модуль засчитался освоенным. Модули,
+	// где столько заданий не набрать, в тест не попадают
+	placementItemsPerProbe = 2
+)
+
+// StartPlacement начинает новый вступительный тест, закрывая незаконченные.
+// Тест ищет бинарным поиском первый неосвоенный модуль: из проверяемого модуля даётся
+// до placementItemsPerProbe заданий, ошибка сдвигает поиск к началу курса.
+func (uc *WordUsecase) StartPlacement(ctx context.Context, userID uuid.UUID, languages []string) (*models.PlacementTest, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	tx, err := uc.wordRepo.BeginTx(ctx)
+	if err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "StartPlacement",
+			fmt.Errorf("failed to begin transaction: %w", err))
+		return nil, errors.New("failed to start transaction")
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = uc.wordRepo.AbandonPlacementTests(ctx, tx, userID); err != nil {
+		return nil, errors.New("failed to start placement test")
+	}
+
+	moduleIDs, err := uc.wordRepo.GetPlacementModuleIDs(ctx, tx, placementItemsPerProbe)
+	if err != nil {
+		return nil, errors.New("failed to start placement test")
+	}
+	if len(moduleIDs) == 0 {
+		err = word.ErrNotFound
+		return nil, err
+	}
+
+	test := &models.PlacementTest{
+		UserID:    userID,
+		Status:    placementInProgress,
+		ModuleIDs: moduleIDs,
+		Low:       0,
+		High:      len(moduleIDs),
+	}
+	if test.ID, err = uc.wordRepo.CreatePlacementTest(ctx, tx, test); err != nil {
+		return nil, errors.New("failed to start placement test")
+	}
+
+	if err = uc.nextPlacementItem(ctx, tx, test); err != nil {
+		return nil, errors.New("failed to start placement test")
+	}
+	if err = uc.wordRepo.UpdatePlacementTest(ctx, tx, test); err != nil {
+		return nil, errors.New("failed to start placement test")
+	}
+
+	if err = tx.Commit(); err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "StartPlacement",
+			fmt.Errorf("failed to commit transaction: %w", err))
+		return nil, errors.New("failed to start placement test")
+	}
+
+	test.MaxItems = uc.cfg.Placement.MaxItems
+	uc.preparePlacementItem(ctx, test, languages)
+	return test, nil
+}
+
+func (uc *WordUsecase) GetPlacement(ctx context.Context, userID uuid.UUID, testID int, languages []string) (*models.PlacementTest, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	tx, err := uc.wordRepo.BeginTx(ctx)
+	if err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetPlacement",
+			fmt.Errorf("failed to begin transaction: %w", err))
+		return nil, errors.New("failed to start transaction")
+	}
+	defer tx.Rollback()
+
+	test, err := uc.wordRepo.GetPlacementTest(ctx, tx, testID, userID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get placement test: %w", err)
+	}
+	if test == nil {
+		return nil, word.ErrNotFound
+	}
+
+	if test.CurrentExerciseID != nil {
+		if test.Item, err = uc.wordRepo.GetWordExercise(ctx, userID, *test.CurrentExerciseID); err != nil {
+			return nil, fmt.Errorf("failed to get placement item: %w", err)
+		}
+	}
+
+	test.MaxItems = uc.cfg.Placement.MaxItems
+	uc.preparePlacementItem(ctx, test, languages)
+	return test, nil
+}
+
+// AnswerPlacement проверяет ответ на текущее задание теста, сохраняет его как обычную попытку
+// упражнения и выдаёт следующее задание либо итоговый стартовый модуль.
+func (uc *WordUsecase) AnswerPlacement(ctx context.Context, answer *models.PlacementAnswer, languages []string) (*models.PlacementTest, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	if answer.ExerciseID == nil {
+		return nil, fmt.Errorf("%w: exercise_id is required", word.ErrInvalidData)
+	}
+
+	tx, err := uc.wordRepo.BeginTx(ctx)
+	if err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "AnswerPlacement",
+			fmt.Errorf("failed to begin transaction: %w", err))
+		return nil, errors.New("failed to start transaction")
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	test, err := uc.wordRepo.GetPlacementTest(ctx, tx, answer.TestID, answer.UserID, true)
+	if err != nil {
+		return nil, errors.New("failed to get placement test")
+	}
+	if test == nil {
+		err = word.ErrNotFound
+		return nil, err
+	}
+	if test.Status != placementInProgress || test.CurrentExerciseID == nil || *test.CurrentExerciseID != *answer.ExerciseID {
+		err = fmt.Errorf("%w: exercise %d is not the current placement item", word.ErrInvalidData, *answer.ExerciseID)
+		return nil, err
+	}
+
+	exercise, err := uc.wordRepo.GetWordExercise(ctx, answer.UserID, *answer.ExerciseID)
+	if err != nil {
+		return nil, errors.New("failed to get placement item")
+	}
+	if exercise == nil {
+		err = word.ErrNotFound
+		return nil, err
+	}
+	// ответ сверяется с тем же произношением, что было показано ученику
+	items := []models.Exercise{*exercise}
+	uc.applyAccent(ctx, items, false, uc.contentSettings(ctx, answer.UserID).Accent)
+	exercise = &items[0]
+
+	attempt := &models.ExerciseAttempt{
+		UserID:       answer.UserID,
+		ExerciseID:   exercise.ID,
+		ExerciseType: "word",
+		DurationMs:   answer.DurationMs,
+	}
+
+	var score *models.PronunciationScore
+	if exercise.ExerciseType == "guessWord" {
+		if answer.Status != "completed" && answer.Status != "failed" {
+			err = fmt.Errorf("%w: status must be completed or failed", word.ErrInvalidData)
+			return nil, err
+		}
+		attempt.Result = answer.Status
+	} else {
+		if answer.Transcription == "" {
+			err = fmt.Errorf("%w: transcription is required", word.ErrInvalidData)
+			return nil, err
+		}
+		var expected *phonetics.IPA
+		if _, expected, err = pronunciationTarget(exercise, answer.WordIndex); err != nil {
+			return nil, err
+		}
+		if expected == nil {
+			err = fmt.Errorf("%w: exercise %d has no transcription", word.ErrInvalidData, exercise.ID)
+			return nil, err
+		}
+
+		score = uc.scorer.ScoreIPA([]phonetics.IPA{*expected}, answer.Transcription)
+		attempt.Result = "failed"
+		if score.Passed {
+			attempt.Result = "completed"
+		}
+		attempt.Score = &score.Score
+		attempt.Transcription = answer.Transcription
+	}
+	passed := attempt.Result == "completed"
+
+	if _, _, err = uc.recordAttempt(ctx, tx, attempt); err != nil {
+		return nil, errors.New("failed to save placement answer")
+	}
+	if score != nil {
+		if err = uc.updatePhonemeMastery(ctx, tx, answer.UserID, score.Phonemes); err != nil {
+			return nil, errors.New("failed to save placement answer")
+		}
+	}
+	if err = uc.wordRepo.InsertPlacementAnswer(ctx, tx, test.ID, exercise, passed, attempt.Score); err != nil {
+		return nil, errors.New("failed to save placement answer")
+	}
+
+	test.Answered++
+	test.ProbeItems++
+	if passed {
+		test.Correct++
+		test.ProbePassed++
+	}
+	if !passed || test.ProbeItems >= placementItemsPerProbe || test.Answered >= uc.cfg.Placement.MaxItems {
+		resolvePlacementProbe(test)
+	}
+
+	if err = uc.nextPlacementItem(ctx, tx, test); err != nil {
+		return nil, errors.New("failed to save placement answer")
+	}
+	if err = uc.wordRepo.UpdatePlacementTest(ctx, tx, test); err != nil {
+		return nil, errors.New("failed to save placement answer")
+	}
+
+	if err = tx.Commit(); err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "AnswerPlacement",
+			fmt.Errorf("failed to commit transaction: %w", err))
+		return nil, errors.New("failed to save placement answer")
+	}
+
+	test.MaxItems = uc.cfg.Placement.MaxItems
+	test.Result = score
+	uc.preparePlacementItem(ctx, test, languages)
+	return test, nil
+}
+
+// preparePlacementItem готовит текущее задание теста для ученика: переводы по профилю и языкам
+// languages, произношение с акцентом из профиля.
+func (uc *WordUsecase) preparePlacementItem(ctx context.Context, test *models.PlacementTest, languages []string) {
+	if test.Item == nil {
+		return
+	}
+	items := []models.Exercise{*test.Item}
+	uc.prepareForUser(ctx, test.UserID, items, false, languages)
+	test.Item = &items[0]
+}
+
+// resolvePlacementProbe подводит итог по проверяемому модулю: он освоен, только если
+// дано не меньше placementItemsPerProbe ответов и все они верные.
+func resolvePlacementProbe(test *models.PlacementTest) {
+	mid := (test.Low + test.High) / 2
+	if test.ProbeItems >= placementItemsPerProbe && test.ProbePassed == test.ProbeItems {
+		test.Low = mid + 1
+	} else {
+		test.High = mid
+	}
+	test.ProbeItems = 0
+	test.ProbePassed = 0
+}
+
+// nextPlacementItem подбирает следующее задание, чередуя произношение и угадывание слова.
+// Если задания модуля кончились раньше, чем набралось placementItemsPerProbe ответов,
+// модуль не считается освоенным. Когда поиск сошёлся или задания закончились, тест завершается.
+func (uc *WordUsecase) nextPlacementItem(ctx context.Context, tx models.Transaction, test *models.PlacementTest) error {
+	test.CurrentExerciseID = nil
+	test.Item = nil
+
+	for test.Low < test.High && test.Answered < uc.cfg.Placement.MaxItems {
+		preferType := "pronounce"
+		if test.Answered%2 == 1 {
+			preferType = "guessWord"
+		}
+
+		moduleID := test.ModuleIDs[(test.Low+test.High)/2]
+		item, err := uc.wordRepo.GetPlacementItem(ctx, tx, test.ID, moduleID, preferType)
+		if err != nil {
+			return err
+		}
+		if item != nil {
+			test.CurrentExerciseID = &item.ID
+			test.Item = item
+			return nil
+		}
+
+		resolvePlacementProbe(test)
+	}
+
+	return uc.finishPlacement(ctx, tx, test)
+}
+
+// finishPlacement делает стартовым первый неосвоенный модуль, а предыдущие помечает пропущенными.
+func (uc *WordUsecase) finishPlacement(ctx context.Context, tx models.Transaction, test *models.PlacementTest) error {
+	start := min(test.Low, len(test.ModuleIDs)-1)
+	startModule := test.ModuleIDs[start]
+
+	if err := uc.wordRepo.SkipWordModules(ctx, tx, test.UserID, test.ModuleIDs[:start]); err != nil {
+		return err
+	}
+	if err := uc.wordRepo.UpdateUserStartModule(ctx, tx, test.UserID, startModule); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	test.Status = placementFinished
+	test.StartModuleID = &startModule
+	test.FinishedAt = &now
+
+	uc.logger.LogInfo(utils.GetRequestIDFromCtx(ctx), logger.UsecaseLayer, "AnswerPlacement",
+		fmt.Sprintf("placement test %d finished, start module %d", test.ID, startModule))
+	return nil
+}
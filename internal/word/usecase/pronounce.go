@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/internal/word"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	"github.com/TeaStealers-backend-sem4/pkg/phonetics"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+	"strings"
+)
+
+// GetExercise возвращает упражнение, если оно доступно пользователю: общее или из его личного набора.
+// Эталон произношения берётся в акценте, а переводы - на языке, выбранных в профиле.
+func (uc *WordUsecase) GetExercise(ctx context.Context, userID uuid.UUID, exerciseType string, exerciseID int) (*models.Exercise, error) {
+	var exercise *models.Exercise
+	var err error
+
+	switch exerciseType {
+	case "word":
+		exercise, err = uc.wordRepo.GetWordExercise(ctx, userID, exerciseID)
+	case "phrase":
+		exercise, err = uc.wordRepo.GetPhraseExercise(ctx, exerciseID)
+	default:
+		return nil, fmt.Errorf("invalid exercise type: %s", exerciseType)
+	}
+
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetExercise", err)
+		return nil, fmt.Errorf("failed to get exercise: %w", err)
+	}
+	if exercise != nil {
+		exercises := []models.Exercise{*exercise}
+		uc.prepareForUser(ctx, userID, exercises, exerciseType == "phrase", nil)
+		exercise = &exercises[0]
+	}
+	return exercise, nil
+}
+
+// SubmitPronunciation оценивает распознанное ML-сервисом произношение относительно эталона
+// упражнения, сохраняет попытку и обновляет сводку прогресса одной транзакцией.
+func (uc *WordUsecase) SubmitPronunciation(ctx context.Context, data *models.PronunciationAttempt) (*models.PronunciationResult, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+	exercise := data.Exercise
+
+	text, expected, err := pronunciationTarget(exercise, data.WordIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	var score *models.PronunciationScore
+	scoredByPhonemes := data.Transcription != "" && expected != nil
+	if scoredByPhonemes {
+		score = uc.scorer.ScoreIPA([]phonetics.IPA{*expected}, data.Transcription)
+	} else {
+		score = uc.scorer.ScoreText(text, data.Text)
+	}
+
+	result := "failed"
+	if score.Passed {
+		result = "completed"
+	}
+
+	recognized := data.Transcription
+	if recognized == "" {
+		recognized = data.Text
+	}
+	attempt := &models.ExerciseAttempt{
+		UserID:        data.UserID,
+		ExerciseID:    exercise.ID,
+		ExerciseType:  data.ExerciseType,
+		Result:        result,
+		Score:         &score.Score,
+		DurationMs:    data.DurationMs,
+		Transcription: recognized,
+		RecordingID:   data.RecordingID,
+	}
+
+	tx, err := uc.wordRepo.BeginTx(ctx)
+	if err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "SubmitPronunciation",
+			fmt.Errorf("failed to begin transaction: %w", err))
+		return nil, errors.New("failed to start transaction")
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	_, status, err := uc.recordAttempt(ctx, tx, attempt)
+	if err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "SubmitPronunciation",
+			fmt.Errorf("failed to save attempt: %w", err))
+		return nil, errors.New("failed to save attempt")
+	}
+
+	if scoredByPhonemes {
+		if err = uc.updatePhonemeMastery(ctx, tx, data.UserID, score.Phonemes); err != nil {
+			uc.logger.LogError(requestId, logger.UsecaseLayer, "SubmitPronunciation",
+				fmt.Errorf("failed to update phoneme mastery: %w", err))
+			return nil, errors.New("failed to save attempt")
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "SubmitPronunciation",
+			fmt.Errorf("failed to commit transaction: %w", err))
+		return nil, errors.New("failed to save attempt")
+	}
+
+	uc.logger.LogInfo(requestId, logger.UsecaseLayer, "SubmitPronunciation",
+		fmt.Sprintf("scored %s exercise %d: %d", data.ExerciseType, exercise.ID, score.Score))
+
+	return &models.PronunciationResult{
+		AttemptID:    attempt.ID,
+		ExerciseID:   exercise.ID,
+		ExerciseType: data.ExerciseType,
+		Result:       result,
+		Status:       status,
+		Text:         data.Text,
+		Score:        score,
+	}, nil
+}
+
+// pronunciationTarget собирает эталон для оценки записи: слово wordIndex или, если индекс
+// не передан, все слова упражнения подряд. Попытка засчитывается за всё упражнение, поэтому
+// в упражнениях из нескольких слов отдельное слово не оценивается. expected равен nil,
+// если у какого-то из слов нет транскрипции - тогда запись оценивается по тексту.
+func pronunciationTarget(exercise *models.Exercise, wordIndex *int) (string, *phonetics.IPA, error) {
+	indexes := make([]int, 0, len(exercise.Words))
+	if wordIndex != nil {
+		if len(exercise.Words) > 1 {
+			return "", nil, fmt.Errorf("%w: word_index is not supported for exercises with several words", word.ErrInvalidData)
+		}
+		if *wordIndex < 0 || *wordIndex >= len(exercise.Words) {
+			return "", nil, fmt.Errorf("%w: word_index out of range", word.ErrInvalidData)
+		}
+		indexes = append(indexes, *wordIndex)
+	} else {
+		for i := range exercise.Words {
+			indexes = append(indexes, i)
+		}
+	}
+
+	words := make([]string, 0, len(indexes))
+	displays := make([]string, 0, len(indexes))
+	expected := &phonetics.IPA{}
+	for _, i := range indexes {
+		words = append(words, exercise.Words[i])
+		if expected == nil {
+			continue
+		}
+		if i >= len(exercise.Transcriptions) || exercise.Transcriptions[i] == "" {
+			expected = nil
+			continue
+		}
+
+		displays = append(displays, exercise.Transcriptions[i])
+		// у записей, созданных до разбора транскрипций, фонем может ещё не быть
+		if i < len(exercise.Phonemes) && len(exercise.Phonemes[i]) > 0 {
+			expected.Phonemes = append(expected.Phonemes, exercise.Phonemes[i]...)
+		} else {
+			expected.Phonemes = append(expected.Phonemes, phonetics.Tokenize(exercise.Transcriptions[i])...)
+		}
+	}
+
+	if expected != nil {
+		expected.Display = strings.Join(displays, " ")
+	}
+	return strings.Join(words, " "), expected, nil
+}
@@ -0,0 +1,207 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/internal/word"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	"github.com/TeaStealers-backend-sem4/pkg/phonetics"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+	"hash/fnv"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	recommendCandidateLimit = 300
+
+	recommendWeaknessWeight = 0.4
+	recommendDueWeight      = 0.3
+	recommendFailureWeight  = 0.2
+	recommendNoveltyWeight  = 0.1
+
+	// слабость фонемы, которую пользователь ещё ни разу не произносил
+	recommendUnknownWeakness = 0.5
+	// через столько дней просрочки повторение получает максимальный вес
+	recommendOverdueDays = 7
+	recommendMaxFailures = 3
+)
+
+// GetNextExercises подбирает следующие упражнения выбранной стратегией. Если стратегия
+// не указана, пользователь детерминированно попадает в группу A/B-теста по своему id.
+// Переводы выбираются по профилю и языкам languages, произношение - по акценту из профиля,
+// к упражнениям обеих стратегий добавляются советы по фонемам.
+func (uc *WordUsecase) GetNextExercises(ctx context.Context, userID uuid.UUID, count int, strategy string, languages []string) (*models.RecommendationList, error) {
+	if strategy == "" {
+		strategy = uc.defaultStrategy(userID)
+	}
+
+	var result *models.RecommendationList
+	var err error
+	switch strategy {
+	case models.StrategyAdaptive:
+		result, err = uc.recommendAdaptive(ctx, userID, count)
+	case models.StrategyFirstIncomplete:
+		result, err = uc.recommendFirstIncomplete(ctx, userID, count)
+	default:
+		return nil, fmt.Errorf("%w: unknown strategy %q", word.ErrInvalidData, strategy)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	exercises := make([]models.Exercise, 0, len(result.Exercises))
+	for _, rec := range result.Exercises {
+		exercises = append(exercises, rec.Exercise)
+	}
+	locales := uc.prepareForUser(ctx, userID, exercises, false, languages)
+	uc.attachTips(ctx, userID, exercises, tipLocale(locales))
+	for i := range result.Exercises {
+		result.Exercises[i].Exercise = exercises[i]
+	}
+	return result, nil
+}
+
+func (uc *WordUsecase) defaultStrategy(userID uuid.UUID) string {
+	h := fnv.New32a()
+	h.Write(userID.Bytes())
+	if int(h.Sum32()%100) < uc.cfg.Recommend.AdaptivePercent {
+		return models.StrategyAdaptive
+	}
+	return models.StrategyFirstIncomplete
+}
+
+// recommendFirstIncomplete повторяет прежнее поведение: незавершённые упражнения первого
+// незавершённого модуля по порядку.
+func (uc *WordUsecase) recommendFirstIncomplete(ctx context.Context, userID uuid.UUID, count int) (*models.RecommendationList, error) {
+	result := &models.RecommendationList{Strategy: models.StrategyFirstIncomplete, Exercises: []models.RecommendedExercise{}}
+
+	module, err := uc.GetNextWordModule(ctx, userID.String())
+	if err != nil {
+		return nil, err
+	}
+	if module == nil {
+		return result, nil
+	}
+
+	exercises, err := uc.wordRepo.GetWordModuleExercises(ctx, userID.String(), module.ID)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetNextExercises", err)
+		return nil, fmt.Errorf("failed to get module exercises: %w", err)
+	}
+
+	for _, exercise := range exercises.Exercises {
+		if len(result.Exercises) == count {
+			break
+		}
+		if exercise.Status == "completed" || exercise.Status == "skipped" {
+			continue
+		}
+		result.Exercises = append(result.Exercises, models.RecommendedExercise{Exercise: exercise})
+	}
+
+	return result, nil
+}
+
+// recommendAdaptive ранжирует кандидатов по взвешенной сумме слабости фонем, срока повторения,
+// недавних ошибок и новизны. При равном балле сохраняется порядок модулей.
+func (uc *WordUsecase) recommendAdaptive(ctx context.Context, userID uuid.UUID, count int) (*models.RecommendationList, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	candidates, err := uc.wordRepo.GetRecommendationCandidates(ctx, userID, recommendCandidateLimit)
+	if err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetNextExercises", err)
+		return nil, fmt.Errorf("failed to get recommendation candidates: %w", err)
+	}
+
+	phonemes, err := uc.wordRepo.GetUserPhonemes(ctx, userID, models.DefaultTipLocale)
+	if err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetNextExercises", err)
+		return nil, fmt.Errorf("failed to get user phonemes: %w", err)
+	}
+	accuracy := make(map[string]float64, len(phonemes))
+	for _, m := range phonemes {
+		accuracy[m.Phoneme] = m.Accuracy
+	}
+
+	now := time.Now()
+	weakLimit := 1 - float64(uc.scorer.Threshold())/100
+
+	ranked := make([]models.RecommendedExercise, 0, len(candidates))
+	for _, c := range candidates {
+		rec := models.RecommendedExercise{Exercise: c.Exercise}
+
+		weakness, weakest := exerciseWeakness(c.Exercise.Transcriptions, accuracy)
+		if weakest > weakLimit {
+			rec.Reasons = append(rec.Reasons, "weak_phonemes")
+		}
+
+		due := 0.0
+		if c.DueAt != nil && !c.DueAt.After(now) {
+			overdue := now.Sub(*c.DueAt).Hours() / 24
+			due = 0.5 + 0.5*math.Min(1, overdue/recommendOverdueDays)
+			rec.Exercise.ReviewID = c.ReviewID
+			rec.Reasons = append(rec.Reasons, "review_due")
+		}
+
+		failures := float64(min(c.RecentFailures, recommendMaxFailures)) / recommendMaxFailures
+		if c.RecentFailures > 0 {
+			rec.Reasons = append(rec.Reasons, "recent_failures")
+		}
+
+		novelty := 0.0
+		if c.Attempts == 0 {
+			novelty = 1
+			rec.Reasons = append(rec.Reasons, "new")
+		}
+
+		score := recommendWeaknessWeight*weakness +
+			recommendDueWeight*due +
+			recommendFailureWeight*failures +
+			recommendNoveltyWeight*novelty
+		rec.Score = math.Round(score*1000) / 1000
+
+		ranked = append(ranked, rec)
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
+	if len(ranked) > count {
+		ranked = ranked[:count]
+	}
+
+	return &models.RecommendationList{Strategy: models.StrategyAdaptive, Exercises: ranked}, nil
+}
+
+// exerciseWeakness возвращает среднюю и максимальную слабость (1 - точность) фонем из транскрипций
+// упражнения. Неизвестные инвентарю символы не учитываются.
+func exerciseWeakness(transcriptions []string, accuracy map[string]float64) (float64, float64) {
+	seen := make(map[string]bool)
+	sum, worst := 0.0, 0.0
+
+	for _, transcription := range transcriptions {
+		for _, phoneme := range phonetics.Tokenize(transcription) {
+			if _, ok := phonetics.Lookup(phoneme); !ok || seen[phoneme] {
+				continue
+			}
+			seen[phoneme] = true
+
+			weakness := recommendUnknownWeakness
+			if value, ok := accuracy[phoneme]; ok {
+				weakness = 1 - value
+				worst = math.Max(worst, weakness)
+			}
+			sum += weakness
+		}
+	}
+
+	if len(seen) == 0 {
+		return 0, 0
+	}
+	return sum / float64(len(seen)), worst
+}
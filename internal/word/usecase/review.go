@@ -0,0 +1,229 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/internal/word"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	"github.com/TeaStealers-backend-sem4/pkg/srs"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+	"time"
+)
+
+const maxReviewsPerDay = 1000
+
+func (uc *WordUsecase) GetReviewSettings(ctx context.Context, userID uuid.UUID) (*models.ReviewSettings, error) {
+	settings, err := uc.wordRepo.GetReviewSettings(ctx, userID)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetReviewSettings", err)
+		return nil, fmt.Errorf("failed to get review settings: %w", err)
+	}
+
+	if settings == nil {
+		newPerDay, reviewsPerDay := uc.cfg.Review.NewPerDay, uc.cfg.Review.ReviewsPerDay
+		settings = &models.ReviewSettings{NewPerDay: &newPerDay, ReviewsPerDay: &reviewsPerDay}
+	}
+	return settings, nil
+}
+
+func (uc *WordUsecase) UpdateReviewSettings(ctx context.Context, userID uuid.UUID, settings *models.ReviewSettings) error {
+	if settings.NewPerDay == nil || settings.ReviewsPerDay == nil {
+		return fmt.Errorf("%w: new_per_day and reviews_per_day are required", word.ErrInvalidData)
+	}
+	if *settings.NewPerDay < 0 || *settings.NewPerDay > maxReviewsPerDay ||
+		*settings.ReviewsPerDay < 0 || *settings.ReviewsPerDay > maxReviewsPerDay {
+		return fmt.Errorf("%w: limits must be between 0 and %d", word.ErrInvalidData, maxReviewsPerDay)
+	}
+
+	if err := uc.wordRepo.UpsertReviewSettings(ctx, userID, settings); err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "UpdateReviewSettings", err)
+		return fmt.Errorf("failed to save review settings: %w", err)
+	}
+	return nil
+}
+
+// GetReviewQueue возвращает карточки, которые пора повторить, с учётом оставшихся на сегодня лимитов.
+// Сначала идут повторения, затем новые карточки. Переводы выбираются по профилю и languages,
+// произношение - по акценту из профиля.
+func (uc *WordUsecase) GetReviewQueue(ctx context.Context, userID uuid.UUID, languages []string) (*models.ExerciseList, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	settings, err := uc.GetReviewSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	newDone, reviewsDone, err := uc.wordRepo.CountReviewsToday(ctx, userID)
+	if err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetReviewQueue", err)
+		return nil, fmt.Errorf("failed to get review queue: %w", err)
+	}
+
+	reviews, err := uc.wordRepo.GetDueReviewExercises(ctx, userID, false, *settings.ReviewsPerDay-reviewsDone)
+	if err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetReviewQueue", err)
+		return nil, fmt.Errorf("failed to get review queue: %w", err)
+	}
+
+	fresh, err := uc.wordRepo.GetDueReviewExercises(ctx, userID, true, *settings.NewPerDay-newDone)
+	if err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetReviewQueue", err)
+		return nil, fmt.Errorf("failed to get review queue: %w", err)
+	}
+
+	exercises := append(reviews, fresh...)
+	uc.prepareForUser(ctx, userID, exercises, false, languages)
+	return &models.ExerciseList{Exercises: exercises}, nil
+}
+
+// GradeReview сохраняет ответ на карточку как попытку упражнения и переносит карточку
+// по алгоритму SM-2. Оценку можно передать явно или получить из статуса и балла попытки.
+// Оценить можно только карточку, срок которой настал, и в пределах дневных лимитов очереди.
+func (uc *WordUsecase) GradeReview(ctx context.Context, data *models.ReviewGrade) (*models.ReviewResult, error) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	if data.Grade != nil && (*data.Grade < 0 || *data.Grade > 5) {
+		return nil, fmt.Errorf("%w: grade must be between 0 and 5", word.ErrInvalidData)
+	}
+	if data.Status != "" && data.Status != "completed" && data.Status != "failed" {
+		return nil, fmt.Errorf("%w: status must be completed or failed", word.ErrInvalidData)
+	}
+	if data.Grade == nil && data.Status == "" {
+		return nil, fmt.Errorf("%w: grade or status is required", word.ErrInvalidData)
+	}
+	// оценка и статус вместе должны совпадать: иначе прогресс засчитал бы провал,
+	// а SM-2 - успешный повтор
+	if data.Grade != nil && data.Status != "" && (*data.Grade >= srs.PassGrade) != (data.Status == "completed") {
+		return nil, fmt.Errorf("%w: grade %d contradicts status %s", word.ErrInvalidData, *data.Grade, data.Status)
+	}
+	if data.Score != nil && (*data.Score < 0 || *data.Score > 100) {
+		return nil, fmt.Errorf("%w: score must be between 0 and 100", word.ErrInvalidData)
+	}
+
+	tx, err := uc.wordRepo.BeginTx(ctx)
+	if err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GradeReview",
+			fmt.Errorf("failed to begin transaction: %w", err))
+		return nil, errors.New("failed to start transaction")
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	item, err := uc.wordRepo.GetReviewItemForUpdate(ctx, tx, data.ReviewID, data.UserID)
+	if err != nil {
+		return nil, errors.New("failed to get review item")
+	}
+	if item == nil {
+		err = word.ErrNotFound
+		return nil, err
+	}
+
+	now := time.Now()
+	if item.DueAt.After(now) {
+		err = fmt.Errorf("%w: review %d is not due until %s", word.ErrInvalidData, item.ID, item.DueAt.Format(time.RFC3339))
+		return nil, err
+	}
+	wasNew := item.LastReviewedAt == nil
+	if err = uc.checkReviewLimit(ctx, data.UserID, wasNew); err != nil {
+		return nil, err
+	}
+
+	grade := 0
+	result := data.Status
+	if data.Grade != nil {
+		grade = *data.Grade
+		if result == "" {
+			result = "failed"
+			if grade >= srs.PassGrade {
+				result = "completed"
+			}
+		}
+	} else {
+		grade = srs.GradeFromAttempt(data.Status, data.Score, uc.scorer.Threshold())
+	}
+
+	attempt := &models.ExerciseAttempt{
+		UserID:        data.UserID,
+		ExerciseID:    item.ExerciseID,
+		ExerciseType:  item.ExerciseType,
+		Result:        result,
+		Score:         data.Score,
+		DurationMs:    data.DurationMs,
+		Transcription: data.Transcription,
+	}
+	if _, _, err = uc.recordAttempt(ctx, tx, attempt); err != nil {
+		return nil, errors.New("failed to save review")
+	}
+
+	card, due := srs.Schedule(srs.Card{
+		Ease:         item.Ease,
+		IntervalDays: item.IntervalDays,
+		Repetitions:  item.Repetitions,
+		Lapses:       item.Lapses,
+	}, grade, now)
+
+	item.Ease = card.Ease
+	item.IntervalDays = card.IntervalDays
+	item.Repetitions = card.Repetitions
+	item.Lapses = card.Lapses
+	item.DueAt = due
+	item.LastReviewedAt = &now
+
+	if err = uc.wordRepo.UpdateReviewItem(ctx, tx, item); err != nil {
+		return nil, errors.New("failed to save review")
+	}
+	if err = uc.wordRepo.InsertReviewLog(ctx, tx, item, grade, wasNew); err != nil {
+		return nil, errors.New("failed to save review")
+	}
+
+	if err = tx.Commit(); err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GradeReview",
+			fmt.Errorf("failed to commit transaction: %w", err))
+		return nil, errors.New("failed to save review")
+	}
+
+	uc.logger.LogInfo(requestId, logger.UsecaseLayer, "GradeReview",
+		fmt.Sprintf("review %d graded %d, next in %d days", item.ID, grade, item.IntervalDays))
+
+	return &models.ReviewResult{
+		ReviewID:     item.ID,
+		Grade:        grade,
+		Ease:         item.Ease,
+		IntervalDays: item.IntervalDays,
+		Repetitions:  item.Repetitions,
+		DueAt:        item.DueAt,
+	}, nil
+}
+
+// checkReviewLimit проверяет, что на сегодня остались повторения нужного вида: те же лимиты,
+// по которым GetReviewQueue собирает очередь.
+func (uc *WordUsecase) checkReviewLimit(ctx context.Context, userID uuid.UUID, wasNew bool) error {
+	settings, err := uc.GetReviewSettings(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	newDone, reviewsDone, err := uc.wordRepo.CountReviewsToday(ctx, userID)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GradeReview", err)
+		return fmt.Errorf("failed to count reviews: %w", err)
+	}
+
+	if wasNew && newDone >= *settings.NewPerDay {
+		return fmt.Errorf("%w: daily limit of new cards reached", word.ErrInvalidData)
+	}
+	if !wasNew && reviewsDone >= *settings.ReviewsPerDay {
+		return fmt.Errorf("%w: daily limit of reviews reached", word.ErrInvalidData)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/internal/word"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+const maxSearchLength = 100
+
+var searchTypes = []string{
+	models.SearchWordModule,
+	models.SearchPhraseModule,
+	models.SearchDeck,
+	models.SearchWord,
+	models.SearchPhrase,
+	models.SearchTip,
+}
+
+// isIPAQuery - запрос в косых или квадратных скобках ищется по транскрипции: /θ/, [ʃə].
+func isIPAQuery(text string) bool {
+	if len(text) < 2 {
+		return false
+	}
+	return (text[0] == '/' && text[len(text)-1] == '/') || (text[0] == '[' && text[len(text)-1] == ']')
+}
+
+// Search ищет модули, слова, фразы и советы. Запрос в скобках транскрипции ищет
+// последовательность фонем, остальные - по тексту с учётом морфологии и опечаток.
+func (uc *WordUsecase) Search(ctx context.Context, query *models.SearchQuery) (*models.SearchResults, error) {
+	query.Text = strings.TrimSpace(query.Text)
+	if query.Text == "" || utf8.RuneCountInString(query.Text) > maxSearchLength {
+		return nil, fmt.Errorf("%w: query must be 1-%d characters", word.ErrInvalidData, maxSearchLength)
+	}
+	for _, t := range query.Types {
+		if !slices.Contains(searchTypes, t) {
+			return nil, fmt.Errorf("%w: unknown type %q", word.ErrInvalidData, t)
+		}
+	}
+
+	query.Mode = models.SearchModeText
+	if isIPAQuery(query.Text) {
+		ipa, err := parseTranscription(query.Text)
+		if err != nil {
+			return nil, err
+		}
+		// пустой шаблон нашёл бы все записи без фонем
+		if len(ipa.Phonemes) == 0 {
+			return nil, fmt.Errorf("%w: transcription query has no phonemes", word.ErrInvalidData)
+		}
+		query.Mode = models.SearchModeIPA
+		query.Phonemes = ipa.Phonemes
+	}
+
+	results, total, err := uc.wordRepo.Search(ctx, query)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "Search", err)
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+
+	return &models.SearchResults{
+		Query:   query.Text,
+		Mode:    query.Mode,
+		Total:   total,
+		Results: results,
+	}, nil
+}
@@ -0,0 +1,284 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/internal/word"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	"github.com/TeaStealers-backend-sem4/pkg/phonetics"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// maxExerciseTips - сколько советов показывается в одном упражнении.
+	maxExerciseTips = 3
+
+	maxTipTextLength    = 2000
+	maxTipExampleWords  = 10
+	maxTipExampleLength = 50
+)
+
+// localePattern - язык объяснения: код языка и, при необходимости, региона (ru, en, pt-br).
+var localePattern = regexp.MustCompile(`^[a-z]{2}(-[a-z]{2})?$`)
+
+// normalizeLocale приводит язык к нижнему регистру; пустой язык - язык по умолчанию.
+func normalizeLocale(locale string) (string, error) {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if locale == "" {
+		return models.DefaultTipLocale, nil
+	}
+	if !localePattern.MatchString(locale) {
+		return "", fmt.Errorf("%w: invalid locale %q", word.ErrInvalidData, locale)
+	}
+	return locale, nil
+}
+
+// canonicalPhoneme разбирает фонему совета и возвращает её написание в инвентаре.
+func canonicalPhoneme(phoneme string) (string, error) {
+	ipa, err := parseTranscription(phoneme)
+	if err != nil {
+		return "", err
+	}
+	if len(ipa.Phonemes) != 1 {
+		return "", fmt.Errorf("%w: tip must describe exactly one phoneme", word.ErrInvalidData)
+	}
+	return ipa.Phonemes[0], nil
+}
+
+// validateTip проверяет совет редактора и приводит поля к хранимому виду.
+func validateTip(tip *models.TipData) error {
+	var err error
+	if tip.Phonema, err = canonicalPhoneme(tip.Phonema); err != nil {
+		return err
+	}
+	if tip.Locale, err = normalizeLocale(tip.Locale); err != nil {
+		return err
+	}
+
+	tip.TipText = strings.TrimSpace(tip.TipText)
+	if tip.TipText == "" || utf8.RuneCountInString(tip.TipText) > maxTipTextLength {
+		return fmt.Errorf("%w: text must be 1-%d characters", word.ErrInvalidData, maxTipTextLength)
+	}
+
+	switch tip.Difficulty {
+	case "":
+		tip.Difficulty = models.TipBeginner
+	case models.TipBeginner, models.TipIntermediate, models.TipAdvanced:
+	default:
+		return fmt.Errorf("%w: difficulty must be beginner, intermediate or advanced", word.ErrInvalidData)
+	}
+
+	examples := make([]string, 0, len(tip.ExampleWords))
+	for _, example := range tip.ExampleWords {
+		example = strings.TrimSpace(example)
+		if example == "" {
+			continue
+		}
+		if utf8.RuneCountInString(example) > maxTipExampleLength {
+			return fmt.Errorf("%w: example word %q is too long", word.ErrInvalidData, example)
+		}
+		examples = append(examples, example)
+	}
+	if len(examples) > maxTipExampleWords {
+		return fmt.Errorf("%w: at most %d example words", word.ErrInvalidData, maxTipExampleWords)
+	}
+	tip.ExampleWords = examples
+
+	return nil
+}
+
+// CreateTip добавляет совет к фонеме. Без позиции (Position < 0) совет встаёт последним.
+func (uc *WordUsecase) CreateTip(ctx context.Context, tip *models.TipData) (int, error) {
+	if err := validateTip(tip); err != nil {
+		return 0, err
+	}
+
+	id, err := uc.wordRepo.CreateTip(ctx, tip)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "CreateTip", err)
+		return 0, fmt.Errorf("failed to create tip: %w", err)
+	}
+	return id, nil
+}
+
+func (uc *WordUsecase) GetTipByID(ctx context.Context, tipID int) (*models.TipData, error) {
+	tip, err := uc.wordRepo.GetTipByID(ctx, tipID)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetTipByID", err)
+		return nil, fmt.Errorf("failed to get tip: %w", err)
+	}
+	if tip == nil {
+		return nil, fmt.Errorf("%w: tip %d", word.ErrNotFound, tipID)
+	}
+	return tip, nil
+}
+
+// GetTips возвращает советы фонемы в порядке редактора. Если на запрошенном языке советов нет,
+// отдаются советы на языке по умолчанию.
+func (uc *WordUsecase) GetTips(ctx context.Context, filter *models.TipFilter) (*models.TipList, error) {
+	phoneme, err := canonicalPhoneme(filter.Phoneme)
+	if err != nil {
+		return nil, err
+	}
+	locale, err := normalizeLocale(filter.Locale)
+	if err != nil {
+		return nil, err
+	}
+
+	tips, err := uc.wordRepo.GetTips(ctx, phoneme, locale)
+	if err == nil && len(tips) == 0 && locale != models.DefaultTipLocale {
+		tips, err = uc.wordRepo.GetTips(ctx, phoneme, models.DefaultTipLocale)
+	}
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetTips", err)
+		return nil, fmt.Errorf("failed to get tips: %w", err)
+	}
+
+	return &models.TipList{Tips: tips}, nil
+}
+
+// UpdateTip заменяет совет целиком. Файлы без новой загрузки и позиция < 0 остаются прежними.
+func (uc *WordUsecase) UpdateTip(ctx context.Context, tip *models.TipData) error {
+	if err := validateTip(tip); err != nil {
+		return err
+	}
+
+	found, err := uc.wordRepo.UpdateTip(ctx, tip)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "UpdateTip", err)
+		return fmt.Errorf("failed to update tip: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("%w: tip %d", word.ErrNotFound, *tip.TipID)
+	}
+	return nil
+}
+
+func (uc *WordUsecase) DeleteTip(ctx context.Context, tipID int) error {
+	found, err := uc.wordRepo.DeleteTip(ctx, tipID)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "DeleteTip", err)
+		return fmt.Errorf("failed to delete tip: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("%w: tip %d", word.ErrNotFound, tipID)
+	}
+	return nil
+}
+
+// exercisePhonemes возвращает фонемы упражнения без повторов в порядке появления. Для записей,
+// у которых фонемы ещё не сохранены, транскрипция разбирается на лету.
+func exercisePhonemes(exercise *models.Exercise) []string {
+	var result []string
+	seen := make(map[string]bool)
+
+	for i, transcription := range exercise.Transcriptions {
+		var phonemes []string
+		if i < len(exercise.Phonemes) && len(exercise.Phonemes[i]) > 0 {
+			phonemes = exercise.Phonemes[i]
+		} else {
+			phonemes = phonetics.Tokenize(transcription)
+		}
+
+		for _, phoneme := range phonemes {
+			if _, ok := phonetics.Lookup(phoneme); !ok || seen[phoneme] {
+				continue
+			}
+			seen[phoneme] = true
+			result = append(result, phoneme)
+		}
+	}
+	return result
+}
+
+// tipLocale - язык советов для ученика: первый из его языков переводов. Если их нет,
+// советы даются на языке по умолчанию.
+func tipLocale(locales []string) string {
+	if len(locales) == 0 {
+		return models.DefaultTipLocale
+	}
+	return locales[0]
+}
+
+// attachTips добавляет к упражнениям советы по их фонемам на языке locale; для фонем
+// без советов на нём берутся советы на языке по умолчанию. Сначала идут фонемы, которые
+// пользователь произносит хуже порога, от самой слабой; остальные - в порядке появления.
+// Советы вспомогательные: при ошибке упражнения отдаются без них.
+func (uc *WordUsecase) attachTips(ctx context.Context, userID uuid.UUID, exercises []models.Exercise, locale string) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	perExercise := make([][]string, len(exercises))
+	var all []string
+	seen := make(map[string]bool)
+	for i := range exercises {
+		perExercise[i] = exercisePhonemes(&exercises[i])
+		for _, phoneme := range perExercise[i] {
+			if !seen[phoneme] {
+				seen[phoneme] = true
+				all = append(all, phoneme)
+			}
+		}
+	}
+	if len(all) == 0 {
+		return
+	}
+
+	tips, err := uc.wordRepo.GetTipsByPhonemes(ctx, all, locale)
+	if err != nil {
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "attachTips", err)
+		return
+	}
+	if len(tips) == 0 {
+		return
+	}
+
+	weak := make(map[string]float64)
+	if !uuid.Equal(userID, uuid.Nil) {
+		mastery, err := uc.wordRepo.GetUserPhonemes(ctx, userID, models.DefaultTipLocale)
+		if err != nil {
+			uc.logger.LogError(requestId, logger.UsecaseLayer, "attachTips", err)
+		}
+		threshold := float64(uc.scorer.Threshold()) / 100
+		for _, m := range mastery {
+			if m.Accuracy < threshold {
+				weak[m.Phoneme] = m.Accuracy
+			}
+		}
+	}
+
+	for i := range exercises {
+		phonemes := make([]string, 0, len(perExercise[i]))
+		for _, phoneme := range perExercise[i] {
+			if _, ok := tips[phoneme]; ok {
+				phonemes = append(phonemes, phoneme)
+			}
+		}
+
+		sort.SliceStable(phonemes, func(a, b int) bool {
+			accA, weakA := weak[phonemes[a]]
+			accB, weakB := weak[phonemes[b]]
+			if weakA != weakB {
+				return weakA
+			}
+			return weakA && accA < accB
+		})
+		if len(phonemes) > maxExerciseTips {
+			phonemes = phonemes[:maxExerciseTips]
+		}
+
+		for _, phoneme := range phonemes {
+			exercises[i].Tips = append(exercises[i].Tips, tips[phoneme])
+		}
+	}
+}
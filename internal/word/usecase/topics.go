@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/internal/word"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+const maxTopicTitleLength = 100
+
+// topicPattern - имя темы: латиница и цифры, слова через дефис (th-sounds)
+var topicPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+var topicKinds = []string{
+	models.TopicWordModule,
+	models.TopicPhraseModule,
+	models.TopicWordExercise,
+	models.TopicPhraseExercise,
+	models.TopicWord,
+}
+
+func normalizeTopic(topic string) (string, error) {
+	topic = strings.ToLower(strings.TrimSpace(topic))
+	if !topicPattern.MatchString(topic) {
+		return "", fmt.Errorf("%w: topic must contain latin letters and digits separated by hyphens", word.ErrInvalidData)
+	}
+	return topic, nil
+}
+
+// normalizeTopicFilter - то же для фильтра списков, где пустая тема означает все материалы.
+func normalizeTopicFilter(topic string) (string, error) {
+	if strings.TrimSpace(topic) == "" {
+		return "", nil
+	}
+	return normalizeTopic(topic)
+}
+
+// filterByTopic оставляет упражнения с темой topic: своей, их модуля или их слов.
+func (uc *WordUsecase) filterByTopic(ctx context.Context, exercises []models.Exercise, phrases bool, topic string) ([]models.Exercise, error) {
+	if topic == "" || len(exercises) == 0 {
+		return exercises, nil
+	}
+
+	ids := make([]int, 0, len(exercises))
+	for i := range exercises {
+		ids = append(ids, exercises[i].ID)
+	}
+
+	matched, err := uc.wordRepo.GetExercisesWithTopic(ctx, phrases, ids, topic)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "filterByTopic", err)
+		return nil, fmt.Errorf("failed to filter exercises by topic: %w", err)
+	}
+
+	filtered := make([]models.Exercise, 0, len(matched))
+	for _, exercise := range exercises {
+		if slices.Contains(matched, exercise.ID) {
+			filtered = append(filtered, exercise)
+		}
+	}
+	return filtered, nil
+}
+
+// GetTopics возвращает темы с числом их слов. Для пользователя (userID != uuid.Nil)
+// заполняется и число пройденных слов.
+func (uc *WordUsecase) GetTopics(ctx context.Context, userID uuid.UUID) (*models.TopicsList, error) {
+	topics, err := uc.wordRepo.GetTopics(ctx, userID)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetTopics", err)
+		return nil, fmt.Errorf("failed to get topics: %w", err)
+	}
+
+	if userID == uuid.Nil {
+		for i := range topics {
+			topics[i].TrueWords = nil
+		}
+	}
+	return &models.TopicsList{Topics: topics}, nil
+}
+
+func (uc *WordUsecase) CreateTopic(ctx context.Context, topic *models.OneTopic) (int, error) {
+	slug, err := normalizeTopic(topic.Topic)
+	if err != nil {
+		return 0, err
+	}
+	title := strings.TrimSpace(topic.Title)
+	if utf8.RuneCountInString(title) > maxTopicTitleLength {
+		return 0, fmt.Errorf("%w: title is longer than %d characters", word.ErrInvalidData, maxTopicTitleLength)
+	}
+
+	topicID, created, err := uc.wordRepo.CreateTopic(ctx, slug, title)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "CreateTopic", err)
+		return 0, fmt.Errorf("failed to create topic: %w", err)
+	}
+	if !created {
+		return 0, fmt.Errorf("%w: topic %q already exists", word.ErrInvalidData, slug)
+	}
+	return topicID, nil
+}
+
+func (uc *WordUsecase) DeleteTopic(ctx context.Context, topicID int) error {
+	found, err := uc.wordRepo.DeleteTopic(ctx, topicID)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "DeleteTopic", err)
+		return fmt.Errorf("failed to delete topic: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("%w: topic %d", word.ErrNotFound, topicID)
+	}
+	return nil
+}
+
+// AddTopicLink привязывает тему к общему модулю, упражнению или статье словаря.
+// Повторная привязка не считается ошибкой.
+func (uc *WordUsecase) AddTopicLink(ctx context.Context, link *models.TopicLink) error {
+	if !slices.Contains(topicKinds, link.Kind) {
+		return fmt.Errorf("%w: unknown topic target %q", word.ErrInvalidData, link.Kind)
+	}
+
+	found, err := uc.wordRepo.AddTopicLink(ctx, link)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "AddTopicLink", err)
+		return fmt.Errorf("failed to add topic link: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("%w: topic %d or %s %d", word.ErrNotFound, link.TopicID, link.Kind, link.TargetID)
+	}
+	return nil
+}
+
+func (uc *WordUsecase) DeleteTopicLink(ctx context.Context, link *models.TopicLink) error {
+	if !slices.Contains(topicKinds, link.Kind) {
+		return fmt.Errorf("%w: unknown topic target %q", word.ErrInvalidData, link.Kind)
+	}
+
+	found, err := uc.wordRepo.DeleteTopicLink(ctx, link)
+	if err != nil {
+		requestId := utils.GetRequestIDFromCtx(ctx)
+		uc.logger.LogError(requestId, logger.UsecaseLayer, "DeleteTopicLink", err)
+		return fmt.Errorf("failed to delete topic link: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("%w: topic %d is not linked to %s %d", word.ErrNotFound, link.TopicID, link.Kind, link.TargetID)
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/internal/word"
+	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	"github.com/TeaStealers-backend-sem4/pkg/phonetics"
+	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxTranscribeLength = 200
+	// ниже этого балла введённая транскрипция считается расходящейся со словарной
+	transcriptionWarnScore = 90
+)
+
+// transcribe строит транскрипцию текста. Источник всей фразы - dictionary,
+// только если все её слова нашлись в словаре.
+func transcribe(text string) *models.TranscriptionSuggestion {
+	ipa, words := phonetics.TranscribeText(text)
+	suggestion := &models.TranscriptionSuggestion{
+		Text:   text,
+		IPA:    ipa,
+		Source: phonetics.SourceDictionary,
+		Words:  make([]models.WordTranscription, 0, len(words)),
+	}
+	for _, w := range words {
+		if w.Source != phonetics.SourceDictionary {
+			suggestion.Source = phonetics.SourceRules
+		}
+		suggestion.Words = append(suggestion.Words, models.WordTranscription{Word: w.Word, Variants: w.Variants, Source: w.Source})
+	}
+	return suggestion
+}
+
+// checkTranscription сравнивает введённую транскрипцию с предложенной. По правилам чтения
+// транскрипция слишком приблизительна, поэтому сравнивается только то, что нашлось в словаре.
+// У фразы из нескольких слов сравнивается основной вариант, у слова - лучший из вариантов.
+// Если часть слов фразы словарь не знает, проверяются по отдельности остальные слова.
+func checkTranscription(suggestion *models.TranscriptionSuggestion, submitted string) *models.TranscriptionWarning {
+	if len(suggestion.Words) == 0 || strings.TrimSpace(submitted) == "" {
+		return nil
+	}
+	if suggestion.Source != phonetics.SourceDictionary {
+		return checkDictionaryWords(suggestion.Words, submitted)
+	}
+
+	variants := []string{suggestion.IPA}
+	if len(suggestion.Words) == 1 {
+		variants = suggestion.Words[0].Variants
+	}
+	return compareTranscription(suggestion.Text, variants, submitted)
+}
+
+// checkDictionaryWords сверяет слова из словаря с соответствующими словами введённой
+// транскрипции. Если число слов не совпадает, сопоставить их нельзя и проверки нет.
+func checkDictionaryWords(words []models.WordTranscription, submitted string) *models.TranscriptionWarning {
+	parts := strings.Fields(strings.NewReplacer("/", " ", "[", " ", "]", " ").Replace(submitted))
+	if len(parts) != len(words) {
+		return nil
+	}
+	for i, w := range words {
+		if w.Source != phonetics.SourceDictionary {
+			continue
+		}
+		if warning := compareTranscription(w.Word, w.Variants, parts[i]); warning != nil {
+			return warning
+		}
+	}
+	return nil
+}
+
+func compareTranscription(text string, variants []string, submitted string) *models.TranscriptionWarning {
+	best, bestScore := variants[0], -1
+	for _, variant := range variants {
+		if score := phonetics.Similarity(variant, submitted); score > bestScore {
+			best, bestScore = variant, score
+		}
+	}
+	if bestScore >= transcriptionWarnScore {
+		return nil
+	}
+	return &models.TranscriptionWarning{Text: text, Submitted: submitted, Suggested: best, Score: bestScore}
+}
+
+// parseTranscription проверяет и нормализует транскрипцию, введённую автором.
+func parseTranscription(transcription string) (*phonetics.IPA, error) {
+	ipa, err := phonetics.ParseIPA(transcription)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", word.ErrInvalidData, err.Error())
+	}
+	return ipa, nil
+}
+
+func (uc *WordUsecase) SuggestTranscription(ctx context.Context, data *models.TranscriptionRequest) (*models.TranscriptionSuggestion, error) {
+	text := strings.TrimSpace(data.Text)
+	if text == "" || utf8.RuneCountInString(text) > maxTranscribeLength {
+		return nil, fmt.Errorf("%w: text must be 1-%d characters", word.ErrInvalidData, maxTranscribeLength)
+	}
+	if len(phonetics.Words(text)) == 0 {
+		return nil, fmt.Errorf("%w: text has no english words", word.ErrInvalidData)
+	}
+
+	suggestion := transcribe(text)
+	suggestion.Warning = checkTranscription(suggestion, data.Transcription)
+	return suggestion, nil
+}
+
+// CheckTranscriptions возвращает предупреждения для пар текст-транскрипция, введённых автором.
+func (uc *WordUsecase) CheckTranscriptions(ctx context.Context, texts, transcriptions []string) []models.TranscriptionWarning {
+	var warnings []models.TranscriptionWarning
+	for i := 0; i < len(texts) && i < len(transcriptions); i++ {
+		if len(phonetics.Words(texts[i])) == 0 {
+			continue
+		}
+		if warning := checkTranscription(transcribe(texts[i]), transcriptions[i]); warning != nil {
+			warnings = append(warnings, *warning)
+		}
+	}
+	return warnings
+}
+
+const phonemesBackfillBatch = 500
+
+// BackfillPhonemes строит последовательности фонем для транскрипций, сохранённых до того,
+// как их стали разбирать при записи, и переписывает сами транскрипции в нормализованном виде,
+// как их сохраняет запись. Статьи словаря, совпавшие после нормализации, сливаются.
+// Нераспознанные символы сохраняются как есть, чтобы оценка не отличалась от прежней.
+func (uc *WordUsecase) BackfillPhonemes(ctx context.Context) {
+	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	for _, phrases := range []bool{false, true} {
+		updated, merged, afterID := 0, 0, 0
+		for ctx.Err() == nil {
+			batch, err := uc.wordRepo.GetTranscriptionsWithoutPhonemes(ctx, phrases, afterID, phonemesBackfillBatch)
+			if err != nil {
+				uc.logger.LogError(requestId, logger.UsecaseLayer, "BackfillPhonemes", err)
+				return
+			}
+			if len(batch) == 0 {
+				break
+			}
+
+			for i := range batch {
+				t := &batch[i]
+				afterID = t.ID
+				if ipa, err := phonetics.ParseIPA(t.Transcription); err == nil {
+					t.Transcription, t.Phonemes = ipa.Display, ipa.Phonemes
+				} else {
+					t.Phonemes = phonetics.Tokenize(t.Transcription)
+				}
+				if len(t.Phonemes) == 0 {
+					continue
+				}
+				isMerged, err := uc.saveNormalizedTranscription(ctx, phrases, t)
+				if err != nil {
+					uc.logger.LogError(requestId, logger.UsecaseLayer, "BackfillPhonemes", err)
+					return
+				}
+				updated++
+				if isMerged {
+					merged++
+				}
+			}
+		}
+		if updated > 0 {
+			uc.logger.LogInfo(requestId, logger.UsecaseLayer, "BackfillPhonemes",
+				fmt.Sprintf("normalized %d transcriptions, merged %d duplicate entries", updated, merged))
+		}
+	}
+}
+
+// saveNormalizedTranscription сохраняет транскрипцию. Если статья словаря после нормализации
+// совпала с другой статьёй того же владельца, она сливается в неё, и возвращается true.
+func (uc *WordUsecase) saveNormalizedTranscription(ctx context.Context, phrases bool, t *models.StoredTranscription) (merged bool, err error) {
+	tx, err := uc.wordRepo.BeginTx(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	duplicateID := 0
+	if !phrases {
+		if duplicateID, err = uc.wordRepo.GetLexiconDuplicate(ctx, tx, t.ID, t.Transcription); err != nil {
+			return false, err
+		}
+	}
+
+	if duplicateID != 0 {
+		if err = uc.wordRepo.MergeLexiconEntries(ctx, tx, t.ID, duplicateID); err != nil {
+			return false, err
+		}
+		t.ID = duplicateID
+	}
+	if err = uc.wordRepo.UpdateTranscription(ctx, tx, phrases, t); err != nil {
+		return false, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return duplicateID != 0, nil
+}
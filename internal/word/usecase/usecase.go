@@ -5,25 +5,50 @@ import (
 	"errors"
 	"fmt"
 	"github.com/TeaStealers-backend-sem4/internal/models"
+	"github.com/TeaStealers-backend-sem4/internal/word"
 	"github.com/TeaStealers-backend-sem4/internal/word/repo"
+	"github.com/TeaStealers-backend-sem4/pkg/config"
 	"github.com/TeaStealers-backend-sem4/pkg/logger"
+	"github.com/TeaStealers-backend-sem4/pkg/phonetics"
 	utils "github.com/TeaStealers-backend-sem4/pkg/utils"
+	"github.com/satori/uuid"
 )
 
 type WordUsecase struct {
 	wordRepo *repo.WordRepo
 	logger   logger.Logger
+	scorer   *phonetics.Scorer
+	cfg      *config.Config
+
+	listeners []word.AttemptListener
 }
 
-func NewWordUsecase(repoWord *repo.WordRepo, logger logger.Logger) *WordUsecase {
+func NewWordUsecase(repoWord *repo.WordRepo, logger logger.Logger, scorer *phonetics.Scorer, cfg *config.Config) *WordUsecase {
 	return &WordUsecase{
 		wordRepo: repoWord,
 		logger:   logger,
+		scorer:   scorer,
+		cfg:      cfg,
 	}
 }
 
+// AddAttemptListener подписывает обработчик на сохранение попыток упражнений.
+func (uc *WordUsecase) AddAttemptListener(listener word.AttemptListener) {
+	uc.listeners = append(uc.listeners, listener)
+}
+
 func (uc *WordUsecase) CreateWordExercise(ctx context.Context, wordCreateData *models.CreateWordData) (int, error) {
 	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	ipa, err := parseTranscription(wordCreateData.Transcription)
+	if err != nil {
+		return 0, err
+	}
+	wordCreateData.Transcription, wordCreateData.Phonemes = ipa.Display, ipa.Phonemes
+	if wordCreateData.LocalizedTranslations, err = normalizeTranslations(wordCreateData.LocalizedTranslations); err != nil {
+		return 0, err
+	}
+
 	tx, err := uc.wordRepo.BeginTx(ctx)
 	if err != nil {
 		return 0, errors.New("error begin tx")
@@ -35,6 +60,10 @@ func (uc *WordUsecase) CreateWordExercise(ctx context.Context, wordCreateData *m
 		uc.logger.LogError(requestId, logger.UsecaseLayer, "CreateWord", err)
 		return 0, errors.New("failed to create word")
 	}
+	if wordId == 0 {
+		tx.Rollback()
+		return 0, fmt.Errorf("%w: exercises are added only to existing shared word modules", word.ErrInvalidData)
+	}
 	tx.Commit()
 	uc.logger.LogInfo(requestId, logger.UsecaseLayer, "CreateWord", fmt.Sprintf("created new word, id: %d", wordId))
 	return wordId, nil
@@ -42,6 +71,27 @@ func (uc *WordUsecase) CreateWordExercise(ctx context.Context, wordCreateData *m
 
 func (uc *WordUsecase) CreateWordExerciseList(ctx context.Context, wordCreateData *models.CreateWordDataList) (int, error) {
 	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	count := len(wordCreateData.Word)
+	if count == 0 || len(wordCreateData.Transcription) != count ||
+		len(wordCreateData.Translation) != count || len(wordCreateData.AudioLink) != count {
+		return 0, fmt.Errorf("%w: words, transcriptions, translations and audio must have equal length", word.ErrInvalidData)
+	}
+
+	wordCreateData.Phonemes = make([][]string, count)
+	for i, transcription := range wordCreateData.Transcription {
+		ipa, err := parseTranscription(transcription)
+		if err != nil {
+			return 0, fmt.Errorf("word %d: %w", i+1, err)
+		}
+		wordCreateData.Transcription[i], wordCreateData.Phonemes[i] = ipa.Display, ipa.Phonemes
+	}
+	localized, err := normalizeTranslationLists(wordCreateData.LocalizedTranslations, count)
+	if err != nil {
+		return 0, err
+	}
+	wordCreateData.LocalizedTranslations = localized
+
 	tx, err := uc.wordRepo.BeginTx(ctx)
 	if err != nil {
 		return 0, errors.New("error begin tx")
@@ -53,6 +103,10 @@ func (uc *WordUsecase) CreateWordExerciseList(ctx context.Context, wordCreateDat
 		uc.logger.LogError(requestId, logger.UsecaseLayer, "CreateWord", err)
 		return 0, errors.New("failed to create word")
 	}
+	if wordId == 0 {
+		tx.Rollback()
+		return 0, fmt.Errorf("%w: exercises are added only to existing shared word modules", word.ErrInvalidData)
+	}
 	tx.Commit()
 	uc.logger.LogInfo(requestId, logger.UsecaseLayer, "CreateWord", fmt.Sprintf("created new word, id: %d", wordId))
 	return wordId, nil
@@ -60,12 +114,25 @@ func (uc *WordUsecase) CreateWordExerciseList(ctx context.Context, wordCreateDat
 
 func (uc *WordUsecase) CreatePhraseExercise(ctx context.Context, phraseCreateData *models.CreatePhraseData) (int, error) {
 	requestId := utils.GetRequestIDFromCtx(ctx)
+
+	ipa, err := parseTranscription(phraseCreateData.Transcription)
+	if err != nil {
+		return 0, err
+	}
+	phraseCreateData.Transcription, phraseCreateData.Phonemes = ipa.Display, ipa.Phonemes
+	if phraseCreateData.LocalizedTranslations, err = normalizeTranslations(phraseCreateData.LocalizedTranslations); err != nil {
+		return 0, err
+	}
+
 	tx, err := uc.wordRepo.BeginTx(ctx)
 	if err != nil {
 		return 0, errors.New("error begin tx")
 	}
 
 	wordId, err := uc.wordRepo.CreatePhraseExercise(ctx, tx, phraseCreateData)
+	if err == nil {
+		err = uc.wordRepo.SetSentenceWords(ctx, tx, true, wordId, sentenceWords(phraseCreateData.Sentence))
+	}
 	if err != nil {
 		tx.Rollback()
 		uc.logger.LogError(requestId, logger.UsecaseLayer, "CreateWord", err)
@@ -92,7 +159,35 @@ func (uc *WordUsecase) CreateUpdateProgress(ctx context.Context, progress *model
 		}
 	}()
 
-	progressID, err := uc.wordRepo.CreateOrUpdateExerciseProgress(ctx, tx, progress)
+	if progress.ExerciseID == nil {
+		err = errors.New("exercise id is required")
+		return 0, err
+	}
+
+	exercise, err := uc.GetExercise(ctx, progress.UserID, progress.ExerciseType, *progress.ExerciseID)
+	if err != nil {
+		return 0, err
+	}
+	if exercise == nil {
+		err = fmt.Errorf("%w: %s exercise %d", word.ErrNotFound, progress.ExerciseType, *progress.ExerciseID)
+		return 0, err
+	}
+
+	var progressID int
+	if progress.Status == "completed" || progress.Status == "failed" {
+		attempt := &models.ExerciseAttempt{
+			UserID:        progress.UserID,
+			ExerciseID:    *progress.ExerciseID,
+			ExerciseType:  progress.ExerciseType,
+			Result:        progress.Status,
+			Score:         progress.Score,
+			DurationMs:    progress.DurationMs,
+			Transcription: progress.Transcription,
+		}
+		progressID, _, err = uc.recordAttempt(ctx, tx, attempt)
+	} else {
+		progressID, err = uc.wordRepo.CreateOrUpdateExerciseProgress(ctx, tx, progress)
+	}
 	if err != nil {
 		uc.logger.LogError(requestId, logger.UsecaseLayer, "CreateUpdateProgress",
 			fmt.Errorf("failed to save progress: %w", err))
@@ -111,8 +206,14 @@ func (uc *WordUsecase) CreateUpdateProgress(ctx context.Context, progress *model
 	return progressID, nil
 }
 
-func (uc *WordUsecase) GetPhraseModules(ctx context.Context) (*models.ModuleList, error) {
-	modules, err := uc.wordRepo.GetPhraseModules(ctx)
+// GetPhraseModules с непустой темой topic возвращает только модули этой темы.
+func (uc *WordUsecase) GetPhraseModules(ctx context.Context, topic string) (*models.ModuleList, error) {
+	topic, err := normalizeTopicFilter(topic)
+	if err != nil {
+		return nil, err
+	}
+
+	modules, err := uc.wordRepo.GetPhraseModules(ctx, topic)
 	if err != nil {
 		requestId := utils.GetRequestIDFromCtx(ctx)
 		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetPhraseModules", err)
@@ -121,8 +222,13 @@ func (uc *WordUsecase) GetPhraseModules(ctx context.Context) (*models.ModuleList
 	return modules, nil
 }
 
-func (uc *WordUsecase) GetWordModules(ctx context.Context) (*models.ModuleList, error) {
-	modules, err := uc.wordRepo.GetWordModules(ctx)
+func (uc *WordUsecase) GetWordModules(ctx context.Context, topic string) (*models.ModuleList, error) {
+	topic, err := normalizeTopicFilter(topic)
+	if err != nil {
+		return nil, err
+	}
+
+	modules, err := uc.wordRepo.GetWordModules(ctx, topic)
 	if err != nil {
 		requestId := utils.GetRequestIDFromCtx(ctx)
 		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetWordModules", err)
@@ -131,42 +237,70 @@ func (uc *WordUsecase) GetWordModules(ctx context.Context) (*models.ModuleList,
 	return modules, nil
 }
 
-func (uc *WordUsecase) GetWordModuleExercises(ctx context.Context, userID string, moduleId int) (*models.ExerciseList, error) {
+// GetWordModuleExercises возвращает упражнения модуля. languages - языки из Accept-Language,
+// по ним и профилю выбираются переводы. С withExamples к упражнениям добавляются предложения
+// с их словами, с непустой темой topic остаются только упражнения этой темы.
+func (uc *WordUsecase) GetWordModuleExercises(ctx context.Context, userID string, moduleId int, languages []string, withExamples bool, topic string) (*models.ExerciseList, error) {
+	topic, err := normalizeTopicFilter(topic)
+	if err != nil {
+		return nil, err
+	}
+
 	modules, err := uc.wordRepo.GetWordModuleExercises(ctx, userID, moduleId)
 	if err != nil {
 		requestId := utils.GetRequestIDFromCtx(ctx)
 		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetWordModuleExercises", err)
 		return nil, fmt.Errorf("failed to  modules: %w", err)
 	}
+	if modules.Exercises, err = uc.filterByTopic(ctx, modules.Exercises, false, topic); err != nil {
+		return nil, err
+	}
+
+	// для анонимного пользователя uuid.FromString вернёт uuid.Nil: советы без учёта слабых фонем
+	userUUID, _ := uuid.FromString(userID)
+	locales := uc.prepareForUser(ctx, userUUID, modules.Exercises, false, languages)
+	uc.attachTips(ctx, userUUID, modules.Exercises, tipLocale(locales))
+	if withExamples {
+		uc.attachExamples(ctx, modules.Exercises)
+	}
+
 	return modules, nil
 }
 
-func (uc *WordUsecase) GetPhraseModuleExercises(ctx context.Context, userID string, moduleId int) (*models.ExerciseList, error) {
+func (uc *WordUsecase) GetPhraseModuleExercises(ctx context.Context, userID string, moduleId int, languages []string, topic string) (*models.ExerciseList, error) {
+	topic, err := normalizeTopicFilter(topic)
+	if err != nil {
+		return nil, err
+	}
+
 	modules, err := uc.wordRepo.GetPhraseModuleExercises(ctx, userID, moduleId)
 	if err != nil {
 		requestId := utils.GetRequestIDFromCtx(ctx)
 		uc.logger.LogError(requestId, logger.UsecaseLayer, "GetWordModuleExercises", err)
 		return nil, fmt.Errorf("failed to  modules: %w", err)
 	}
+	if modules.Exercises, err = uc.filterByTopic(ctx, modules.Exercises, true, topic); err != nil {
+		return nil, err
+	}
+
+	userUUID, _ := uuid.FromString(userID)
+	uc.prepareForUser(ctx, userUUID, modules.Exercises, true, languages)
+
 	return modules, nil
 }
 
+// UploadTip - прежний способ добавить совет: на языке по умолчанию, последним в списке фонемы.
 func (uc *WordUsecase) UploadTip(ctx context.Context, data *models.TipData) error {
-	tx, err := uc.wordRepo.BeginTx(ctx)
-	if err != nil {
-		return err
-	}
-	err = uc.wordRepo.UploadTip(ctx, tx, data)
+	data.Position = -1
+	_, err := uc.CreateTip(ctx, data)
 	return err
 }
 
 func (uc *WordUsecase) GetTip(ctx context.Context, data *models.TipData) (*models.TipData, error) {
-	tx, err := uc.wordRepo.BeginTx(ctx)
-	if err != nil {
-		return nil, err
+	if phonemes := phonetics.Tokenize(data.Phonema); len(phonemes) == 1 {
+		data.Phonema = phonemes[0]
 	}
-	gotTip, err := uc.wordRepo.GetTip(ctx, tx, data)
-	return gotTip, err
+	return uc.wordRepo.GetTip(ctx, data)
 }
 
 func (uc *WordUsecase) GetNextPhraseModule(ctx context.Context, userID string) (*models.ModuleCreate, error) {
@@ -15,6 +15,12 @@ type Config struct {
 	AudioExampleDir string `env:"EXAMPLE_AUDIO_DIR" env-default:"/ouzi/examples/"`
 	MinioService    MinioS3
 	MinCli          MinioClient
+	Scoring         Scoring
+	Review          Review
+	Recommend       Recommend
+	Placement       Placement
+	Streak          Streak
+	Leaderboard     Leaderboard
 }
 
 /*
@@ -48,6 +54,41 @@ type MlService struct {
 	TranscribePhraseEndpoint string        `env:"ML_ENDPOINT_PHRASE"`
 	HelpTextEndpoint         string        `env:"ML_ENDPOINT_HELPTEXT"`
 	DialogEndpoint           string        `env:"ML_ENDPOINT_DIALOG"`
+	SpeechEndpoint           string        `env:"ML_ENDPOINT_SPEECH"` // синтез речи; пусто - озвучка слов недоступна
+}
+
+type Scoring struct {
+	PassThreshold int `env:"SCORE_PASS_THRESHOLD" env-default:"70"`
+}
+
+type Review struct {
+	NewPerDay     int `env:"REVIEW_NEW_PER_DAY" env-default:"10"`
+	ReviewsPerDay int `env:"REVIEW_REVIEWS_PER_DAY" env-default:"50"`
+}
+
+// Recommend.AdaptivePercent - доля пользователей (0-100), которым по умолчанию
+// достаётся адаптивная стратегия рекомендаций; остальные получают первый незавершённый модуль.
+type Recommend struct {
+	AdaptivePercent int `env:"RECOMMEND_ADAPTIVE_PERCENT" env-default:"100"`
+}
+
+type Placement struct {
+	MaxItems int `env:"PLACEMENT_MAX_ITEMS" env-default:"10"`
+}
+
+// Streak.FreezeEvery - за каждые столько дней серии подряд выдаётся заморозка, но не больше MaxFreezes.
+type Streak struct {
+	FreezeEvery int `env:"STREAK_FREEZE_EVERY" env-default:"7"`
+	MaxFreezes  int `env:"STREAK_MAX_FREEZES" env-default:"2"`
+}
+
+// Leaderboard.PromoteCount и DemoteCount - сколько лучших и худших участников недельной лиги
+// переходят на уровень выше и ниже; итоги прошедшей недели проверяются раз в RolloverInterval.
+type Leaderboard struct {
+	Size             int           `env:"LEADERBOARD_SIZE" env-default:"50"`
+	PromoteCount     int           `env:"LEAGUE_PROMOTE_COUNT" env-default:"5"`
+	DemoteCount      int           `env:"LEAGUE_DEMOTE_COUNT" env-default:"5"`
+	RolloverInterval time.Duration `env:"LEAGUE_ROLLOVER_INTERVAL" env-default:"1h"`
 }
 
 func MustLoad() *Config {
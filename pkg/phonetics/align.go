@@ -0,0 +1,249 @@
+package phonetics
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/TeaStealers-backend-sem4/internal/models"
+)
+
+const (
+	OpMatch        = "match"
+	OpSubstitution = "substitution"
+	OpDeletion     = "deletion"
+	OpInsertion    = "insertion"
+)
+
+// SubstitutionCost возвращает цену замены a на b в диапазоне [0, 1]: чем ближе
+// артикуляционно звуки, тем дешевле замена.
+func SubstitutionCost(a, b string) float64 {
+	a, b = Canonical(a), Canonical(b)
+	if a == b {
+		return 0
+	}
+
+	pa, okA := inventory[a]
+	pb, okB := inventory[b]
+	if !okA || !okB {
+		return 1
+	}
+
+	if pa.Kind == Diphthong || pb.Kind == Diphthong {
+		return diphthongCost(pa, pb)
+	}
+	if pa.Kind != pb.Kind {
+		return 1
+	}
+
+	cost := 0.2
+	if pa.Kind == Consonant {
+		if pa.Place != pb.Place {
+			cost += 0.3
+		}
+		if pa.Manner != pb.Manner {
+			cost += 0.3
+		}
+		if pa.Voiced != pb.Voiced {
+			cost += 0.2
+		}
+	} else {
+		cost += math.Abs(pa.Height-pb.Height) / 3 * 0.4
+		cost += math.Abs(pa.Backness-pb.Backness) / 2 * 0.3
+		if pa.Rounded != pb.Rounded {
+			cost += 0.1
+		}
+		if pa.Long != pb.Long {
+			cost += 0.1
+		}
+	}
+
+	return math.Min(cost, 1)
+}
+
+func diphthongCost(a, b Phoneme) float64 {
+	switch {
+	case a.Kind == Diphthong && b.Kind == Diphthong:
+		return math.Min(0.2+(SubstitutionCost(a.From, b.From)+SubstitutionCost(a.To, b.To))/2, 1)
+	case a.Kind == Diphthong && b.Kind == Vowel:
+		return math.Min(0.3+SubstitutionCost(a.From, b.Symbol), 1)
+	case a.Kind == Vowel && b.Kind == Diphthong:
+		return math.Min(0.3+SubstitutionCost(a.Symbol, b.From), 1)
+	}
+	return 1
+}
+
+// IndelCost возвращает цену пропуска или вставки фонемы; редуцированный шва штрафуется слабее.
+func IndelCost(symbol string) float64 {
+	if Canonical(symbol) == "ə" {
+		return 0.5
+	}
+	return 1
+}
+
+// Align выравнивает распознанные фонемы относительно ожидаемых взвешенным расстоянием
+// редактирования и возвращает операции выравнивания и их суммарную цену.
+func Align(expected, recognized []string) ([]models.PhonemeAlignment, float64) {
+	return align(expected, recognized, SubstitutionCost, IndelCost)
+}
+
+func align(expected, recognized []string, substitution func(a, b string) float64, indel func(symbol string) float64) ([]models.PhonemeAlignment, float64) {
+	n, m := len(expected), len(recognized)
+
+	dist := make([][]float64, n+1)
+	for i := range dist {
+		dist[i] = make([]float64, m+1)
+	}
+	for i := 1; i <= n; i++ {
+		dist[i][0] = dist[i-1][0] + indel(expected[i-1])
+	}
+	for j := 1; j <= m; j++ {
+		dist[0][j] = dist[0][j-1] + indel(recognized[j-1])
+	}
+
+	for i := 1; i <= n; i++ {
+		for j := 1; j <= m; j++ {
+			dist[i][j] = math.Min(
+				dist[i-1][j-1]+substitution(expected[i-1], recognized[j-1]),
+				math.Min(
+					dist[i-1][j]+indel(expected[i-1]),
+					dist[i][j-1]+indel(recognized[j-1]),
+				),
+			)
+		}
+	}
+
+	ops := make([]models.PhonemeAlignment, 0, n+m)
+	i, j := n, m
+	for i > 0 || j > 0 {
+		switch {
+		case i > 0 && j > 0 && almostEqual(dist[i][j], dist[i-1][j-1]+substitution(expected[i-1], recognized[j-1])):
+			cost := substitution(expected[i-1], recognized[j-1])
+			op := OpSubstitution
+			if cost == 0 {
+				op = OpMatch
+			}
+			ops = append(ops, models.PhonemeAlignment{Expected: expected[i-1], Recognized: recognized[j-1], Operation: op, Cost: round2(cost)})
+			i, j = i-1, j-1
+		case i > 0 && almostEqual(dist[i][j], dist[i-1][j]+indel(expected[i-1])):
+			ops = append(ops, models.PhonemeAlignment{Expected: expected[i-1], Operation: OpDeletion, Cost: indel(expected[i-1])})
+			i--
+		default:
+			ops = append(ops, models.PhonemeAlignment{Recognized: recognized[j-1], Operation: OpInsertion, Cost: indel(recognized[j-1])})
+			j--
+		}
+	}
+
+	for l, r := 0, len(ops)-1; l < r; l, r = l+1, r-1 {
+		ops[l], ops[r] = ops[r], ops[l]
+	}
+
+	return ops, dist[n][m]
+}
+
+// Scorer оценивает произношение по распознанной ML-сервисом транскрипции.
+type Scorer struct {
+	passThreshold int
+}
+
+func NewScorer(passThreshold int) *Scorer {
+	return &Scorer{passThreshold: passThreshold}
+}
+
+func (s *Scorer) Threshold() int {
+	return s.passThreshold
+}
+
+// Score сравнивает распознанную транскрипцию с каждым допустимым эталоном и возвращает
+// лучший результат: балл 0-100, выравнивание по фонемам и вердикт по порогу.
+func (s *Scorer) Score(expected []string, recognized string) *models.PronunciationScore {
+	variants := make([]IPA, 0, len(expected))
+	for _, variant := range expected {
+		parsed, _ := parse(variant)
+		variants = append(variants, *parsed)
+	}
+	return s.ScoreIPA(variants, recognized)
+}
+
+// ScoreIPA работает как Score, но с уже разобранными эталонами - например, с сохранённой
+// последовательностью фонем упражнения.
+func (s *Scorer) ScoreIPA(expected []IPA, recognized string) *models.PronunciationScore {
+	recognizedPhonemes := Tokenize(recognized)
+
+	var best *models.PronunciationScore
+	for _, variant := range expected {
+		if len(variant.Phonemes) == 0 {
+			continue
+		}
+
+		ops, cost := Align(variant.Phonemes, recognizedPhonemes)
+		score := int(math.Round(100 * math.Max(0, 1-cost/float64(len(variant.Phonemes)))))
+
+		if best == nil || score > best.Score {
+			best = &models.PronunciationScore{
+				Score:      score,
+				Expected:   strings.TrimSpace(variant.Display),
+				Recognized: strings.TrimSpace(recognized),
+				Phonemes:   ops,
+			}
+		}
+	}
+
+	if best == nil {
+		best = &models.PronunciationScore{Recognized: strings.TrimSpace(recognized), Phonemes: []models.PhonemeAlignment{}}
+	}
+	best.Threshold = s.passThreshold
+	best.Passed = best.Score >= s.passThreshold
+
+	return best
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// ScoreText оценивает фразу по распознанному тексту, выравнивая слова вместо фонем.
+// Используется, когда ML-сервис не вернул транскрипцию.
+func (s *Scorer) ScoreText(expected, recognized string) *models.PronunciationScore {
+	expectedWords := Words(expected)
+	recognizedWords := Words(recognized)
+
+	result := &models.PronunciationScore{
+		Expected:   strings.TrimSpace(expected),
+		Recognized: strings.TrimSpace(recognized),
+		Threshold:  s.passThreshold,
+		Phonemes:   []models.PhonemeAlignment{},
+	}
+	if len(expectedWords) == 0 {
+		return result
+	}
+
+	ops, cost := align(expectedWords, recognizedWords, wordSubstitutionCost, wordIndelCost)
+	result.Phonemes = ops
+	result.Score = int(math.Round(100 * math.Max(0, 1-cost/float64(len(expectedWords)))))
+	result.Passed = result.Score >= s.passThreshold
+
+	return result
+}
+
+// Words разбивает текст на слова в нижнем регистре без знаков препинания.
+func Words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+}
+
+func wordSubstitutionCost(a, b string) float64 {
+	if a == b {
+		return 0
+	}
+	return 1
+}
+
+func wordIndelCost(string) float64 {
+	return 1
+}
@@ -0,0 +1,283 @@
+package phonetics
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	_ "embed"
+	"fmt"
+	"strings"
+)
+
+const (
+	SourceDictionary = "dictionary"
+	SourceRules      = "rules"
+)
+
+// В репозитории лежит небольшая выборка из CMUdict - около пятисот слов; остальные
+// слова транскрибируются по правилам чтения. go generate заменяет архив
+// полной версией словаря (формат cmusphinx: слова в нижнем регистре, варианты - word(2),
+// комментарии после #).
+//
+//go:generate sh -c "curl -fsSL https://raw.githubusercontent.com/cmusphinx/cmudict/master/cmudict.dict | gzip -9n > data/cmudict.dict.gz"
+//go:embed data/cmudict.dict.gz
+var cmudictData []byte
+
+// dictionary хранит варианты произношения слова в ARPAbet в порядке словаря.
+var dictionary = map[string][][]string{}
+
+func init() {
+	reader, err := gzip.NewReader(bytes.NewReader(cmudictData))
+	if err != nil {
+		panic(fmt.Sprintf("phonetics: broken cmudict archive: %v", err))
+	}
+	defer reader.Close()
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, ";;;") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		word := strings.ToLower(fields[0])
+		if i := strings.IndexByte(word, '('); i > 0 {
+			word = word[:i]
+		}
+		phones := fields[1:]
+		for i := range phones {
+			phones[i] = strings.ToUpper(phones[i])
+		}
+		dictionary[word] = append(dictionary[word], phones)
+	}
+	if err := scanner.Err(); err != nil {
+		panic(fmt.Sprintf("phonetics: broken cmudict archive: %v", err))
+	}
+}
+
+// arpabetVowels - гласные ARPAbet; AH и ER зависят от ударения и разбираются отдельно.
+var arpabetVowels = map[string]string{
+	"AA": "ɑː",
+	"AE": "æ",
+	"AO": "ɔː",
+	"AW": "aʊ",
+	"AY": "aɪ",
+	"EH": "e",
+	"EY": "eɪ",
+	"IH": "ɪ",
+	"IY": "iː",
+	"OW": "oʊ",
+	"OY": "ɔɪ",
+	"UH": "ʊ",
+	"UW": "uː",
+}
+
+var arpabetConsonants = map[string]string{
+	"B":  "b",
+	"CH": "tʃ",
+	"D":  "d",
+	"DH": "ð",
+	"F":  "f",
+	"G":  "ɡ",
+	"HH": "h",
+	"JH": "dʒ",
+	"K":  "k",
+	"L":  "l",
+	"M":  "m",
+	"N":  "n",
+	"NG": "ŋ",
+	"P":  "p",
+	"R":  "r",
+	"S":  "s",
+	"SH": "ʃ",
+	"T":  "t",
+	"TH": "θ",
+	"V":  "v",
+	"W":  "w",
+	"Y":  "j",
+	"Z":  "z",
+	"ZH": "ʒ",
+}
+
+// onsets - сочетания согласных, с которых может начинаться английский слог.
+// По ним знак ударения ставится перед началом ударного слога, а не перед гласной.
+var onsets = map[string]bool{
+	"P R": true, "P L": true, "B R": true, "B L": true, "T R": true, "D R": true,
+	"K R": true, "K L": true, "G R": true, "G L": true, "F R": true, "F L": true,
+	"TH R": true, "SH R": true, "S P": true, "S T": true, "S K": true, "S M": true,
+	"S N": true, "S L": true, "S W": true, "T W": true, "D W": true, "K W": true,
+	"P Y": true, "B Y": true, "K Y": true, "F Y": true, "M Y": true, "V Y": true,
+	"HH Y": true, "S P R": true, "S P L": true, "S T R": true, "S K R": true,
+	"S K W": true, "S P Y": true, "S K Y": true,
+}
+
+func splitStress(phone string) (string, byte) {
+	if n := len(phone); n > 0 && phone[n-1] >= '0' && phone[n-1] <= '2' {
+		return phone[:n-1], phone[n-1]
+	}
+	return phone, 0
+}
+
+func isArpabetVowel(phone string) bool {
+	base, _ := splitStress(phone)
+	_, ok := arpabetVowels[base]
+	return ok || base == "AH" || base == "ER"
+}
+
+// onsetStart возвращает индекс, с которого начинается слог гласной vowel:
+// из согласных после предыдущей гласной в слог уходит самое длинное допустимое сочетание.
+func onsetStart(phones []string, prevVowel, vowel int) int {
+	if prevVowel < 0 {
+		return 0
+	}
+	start := vowel
+	for i := vowel - 1; i > prevVowel && vowel-i <= 3; i-- {
+		cluster := phones[i:vowel]
+		if len(cluster) == 1 {
+			if cluster[0] != "NG" {
+				start = i
+			}
+			continue
+		}
+		if onsets[strings.Join(cluster, " ")] {
+			start = i
+		}
+	}
+	return start
+}
+
+// ArpabetToIPA переводит произношение CMUdict в IPA со знаками ударения.
+// В односложных словах ударение не отмечается.
+func ArpabetToIPA(phones []string) string {
+	vowels := make([]int, 0, len(phones))
+	for i, phone := range phones {
+		if isArpabetVowel(phone) {
+			vowels = append(vowels, i)
+		}
+	}
+
+	marks := make(map[int]string)
+	if len(vowels) > 1 {
+		prev := -1
+		for _, v := range vowels {
+			switch _, stress := splitStress(phones[v]); stress {
+			case '1':
+				marks[onsetStart(phones, prev, v)] = "ˈ"
+			case '2':
+				marks[onsetStart(phones, prev, v)] = "ˌ"
+			}
+			prev = v
+		}
+	}
+
+	var b strings.Builder
+	for i, phone := range phones {
+		b.WriteString(marks[i])
+
+		base, stress := splitStress(phone)
+		switch {
+		case base == "AH":
+			if stress == '0' {
+				b.WriteString("ə")
+			} else {
+				b.WriteString("ʌ")
+			}
+		case base == "ER":
+			if stress == '0' {
+				b.WriteString("ər")
+			} else {
+				b.WriteString("ɜːr")
+			}
+		case base == "IY" && stress == '0' && i == len(phones)-1:
+			// безударное конечное -y, как в happy
+			b.WriteString("i")
+		default:
+			if ipa, ok := arpabetVowels[base]; ok {
+				b.WriteString(ipa)
+			} else if ipa, ok := arpabetConsonants[base]; ok {
+				b.WriteString(ipa)
+			}
+		}
+	}
+	return b.String()
+}
+
+// WordTranscription - предложенные транскрипции слова и их источник.
+type WordTranscription struct {
+	Word     string
+	Variants []string
+	Source   string
+}
+
+// TranscribeWord предлагает IPA для английского слова: сначала по встроенному словарю, иначе по правилам чтения.
+func TranscribeWord(word string) WordTranscription {
+	word = strings.ToLower(strings.TrimSpace(word))
+	if prons, ok := dictionary[word]; ok {
+		variants := make([]string, 0, len(prons))
+		for _, phones := range prons {
+			variants = append(variants, ArpabetToIPA(phones))
+		}
+		return WordTranscription{Word: word, Variants: variants, Source: SourceDictionary}
+	}
+
+	return WordTranscription{Word: word, Variants: []string{ArpabetToIPA(letterToSound(word))}, Source: SourceRules}
+}
+
+// TranscribeText транскрибирует каждое слово текста; основной вариант фразы - первые варианты слов через пробел.
+func TranscribeText(text string) (string, []WordTranscription) {
+	words := Words(text)
+	result := make([]WordTranscription, 0, len(words))
+	parts := make([]string, 0, len(words))
+	for _, word := range words {
+		t := TranscribeWord(word)
+		result = append(result, t)
+		parts = append(parts, t.Variants[0])
+	}
+	return strings.Join(parts, " "), result
+}
+
+// Similarity сравнивает транскрипции так же, как оценивается произношение, и возвращает
+// балл 0-100. Ударения и r после гласной не учитываются, чтобы американская и британская
+// записи одного слова не считались расхождением.
+func Similarity(expected, submitted string) int {
+	expectedPhonemes := dropPostvocalicR(Tokenize(expected))
+	submittedPhonemes := dropPostvocalicR(Tokenize(submitted))
+	if len(expectedPhonemes) == 0 {
+		if len(submittedPhonemes) == 0 {
+			return 100
+		}
+		return 0
+	}
+
+	_, cost := Align(expectedPhonemes, submittedPhonemes)
+	score := 100 * (1 - cost/float64(len(expectedPhonemes)))
+	if score < 0 {
+		return 0
+	}
+	return int(score + 0.5)
+}
+
+func dropPostvocalicR(phonemes []string) []string {
+	result := make([]string, 0, len(phonemes))
+	for i, phoneme := range phonemes {
+		if phoneme == "ɹ" && i > 0 {
+			prev, _ := Lookup(phonemes[i-1])
+			next := Phoneme{}
+			if i+1 < len(phonemes) {
+				next, _ = Lookup(phonemes[i+1])
+			}
+			if prev.Kind != Consonant && (i+1 == len(phonemes) || next.Kind == Consonant) {
+				continue
+			}
+		}
+		result = append(result, phoneme)
+	}
+	return result
+}
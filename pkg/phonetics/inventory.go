@@ -0,0 +1,162 @@
+package phonetics
+
+// Kind разделяет фонемы на классы, между которыми замена всегда стоит максимально.
+type Kind int
+
+const (
+	Consonant Kind = iota
+	Vowel
+	Diphthong
+)
+
+const (
+	Bilabial = iota
+	Labiodental
+	Dental
+	Alveolar
+	Postalveolar
+	Palatal
+	Velar
+	Glottal
+)
+
+const (
+	Plosive = iota
+	Fricative
+	Affricate
+	Nasal
+	Lateral
+	Approximant
+	Tap
+)
+
+// Phoneme описывает артикуляционные признаки, по которым считается цена замены.
+// Для согласных используются Place, Manner и Voiced, для гласных Height (0 - закрытый,
+// 3 - открытый), Backness (0 - передний, 2 - задний), Rounded и Long.
+// Дифтонг задаётся начальной и конечной гласной.
+type Phoneme struct {
+	Symbol   string
+	Kind     Kind
+	Place    int
+	Manner   int
+	Voiced   bool
+	Height   float64
+	Backness float64
+	Rounded  bool
+	Long     bool
+	From     string
+	To       string
+}
+
+var inventory = map[string]Phoneme{}
+
+// aliases сводит эквивалентные написания к одному символу инвентаря.
+var aliases = map[string]string{
+	"g":  "ɡ",
+	"r":  "ɹ",
+	"ʧ":  "tʃ",
+	"ʤ":  "dʒ",
+	"ɝ":  "ɜː",
+	"ɚ":  "ə",
+	"ɜ":  "ɜː",
+	"i:": "iː",
+	"u:": "uː",
+	"ɑ:": "ɑː",
+	"ɔ:": "ɔː",
+	"ɜ:": "ɜː",
+	"e":  "ɛ",
+	"o":  "ɔ",
+	"a":  "æ",
+	"ɐ":  "ʌ",
+	"ɫ":  "l",
+	"ɹ̩": "ɹ",
+	"ʍ":  "w",
+}
+
+func consonant(symbol string, place, manner int, voiced bool) {
+	inventory[symbol] = Phoneme{Symbol: symbol, Kind: Consonant, Place: place, Manner: manner, Voiced: voiced}
+}
+
+func vowel(symbol string, height, backness float64, rounded, long bool) {
+	inventory[symbol] = Phoneme{Symbol: symbol, Kind: Vowel, Height: height, Backness: backness, Rounded: rounded, Long: long}
+}
+
+func diphthong(symbol, from, to string) {
+	inventory[symbol] = Phoneme{Symbol: symbol, Kind: Diphthong, From: from, To: to}
+}
+
+func init() {
+	consonant("p", Bilabial, Plosive, false)
+	consonant("b", Bilabial, Plosive, true)
+	consonant("t", Alveolar, Plosive, false)
+	consonant("d", Alveolar, Plosive, true)
+	consonant("k", Velar, Plosive, false)
+	consonant("ɡ", Velar, Plosive, true)
+	consonant("ʔ", Glottal, Plosive, false)
+	consonant("f", Labiodental, Fricative, false)
+	consonant("v", Labiodental, Fricative, true)
+	consonant("θ", Dental, Fricative, false)
+	consonant("ð", Dental, Fricative, true)
+	consonant("s", Alveolar, Fricative, false)
+	consonant("z", Alveolar, Fricative, true)
+	consonant("ʃ", Postalveolar, Fricative, false)
+	consonant("ʒ", Postalveolar, Fricative, true)
+	consonant("x", Velar, Fricative, false)
+	consonant("h", Glottal, Fricative, false)
+	consonant("tʃ", Postalveolar, Affricate, false)
+	consonant("dʒ", Postalveolar, Affricate, true)
+	consonant("m", Bilabial, Nasal, true)
+	consonant("n", Alveolar, Nasal, true)
+	consonant("ŋ", Velar, Nasal, true)
+	consonant("l", Alveolar, Lateral, true)
+	consonant("ɹ", Alveolar, Approximant, true)
+	consonant("j", Palatal, Approximant, true)
+	consonant("w", Bilabial, Approximant, true)
+	consonant("ɾ", Alveolar, Tap, true)
+
+	vowel("iː", 0, 0, false, true)
+	vowel("i", 0, 0, false, false)
+	vowel("ɪ", 0.5, 0.3, false, false)
+	vowel("ɛ", 2, 0, false, false)
+	vowel("æ", 2.7, 0, false, false)
+	vowel("ʌ", 2, 1.8, false, false)
+	vowel("ə", 1.5, 1, false, false)
+	vowel("ɜː", 2, 1, false, true)
+	vowel("ɑː", 3, 2, false, true)
+	vowel("ɑ", 3, 2, false, false)
+	vowel("ɒ", 3, 2, true, false)
+	vowel("ɔː", 2, 2, true, true)
+	vowel("ɔ", 2, 2, true, false)
+	vowel("ʊ", 0.5, 1.7, true, false)
+	vowel("uː", 0, 2, true, true)
+	vowel("u", 0, 2, true, false)
+
+	diphthong("eɪ", "ɛ", "ɪ")
+	diphthong("aɪ", "æ", "ɪ")
+	diphthong("ɔɪ", "ɔ", "ɪ")
+	diphthong("aʊ", "æ", "ʊ")
+	diphthong("əʊ", "ə", "ʊ")
+	diphthong("oʊ", "ɔ", "ʊ")
+	diphthong("ɪə", "ɪ", "ə")
+	diphthong("eə", "ɛ", "ə")
+	diphthong("ʊə", "ʊ", "ə")
+
+	buildSymbols()
+}
+
+// Lookup возвращает фонему инвентаря с учётом эквивалентных написаний.
+func Lookup(symbol string) (Phoneme, bool) {
+	if canonical, ok := aliases[symbol]; ok {
+		symbol = canonical
+	}
+	p, ok := inventory[symbol]
+	return p, ok
+}
+
+// Canonical приводит символ к написанию, принятому в инвентаре.
+func Canonical(symbol string) string {
+	if canonical, ok := aliases[symbol]; ok {
+		return canonical
+	}
+	return symbol
+}
@@ -0,0 +1,201 @@
+package phonetics
+
+import (
+	"strings"
+)
+
+// letterRule - правило чтения буквосочетания. Правила проверяются по порядку, побеждает
+// первое совпавшее, поэтому длинные сочетания стоят раньше коротких.
+type letterRule struct {
+	letters string
+	phones  []string
+	// atStart/atEnd ограничивают правило началом или концом слова
+	atStart bool
+	atEnd   bool
+}
+
+var letterRules = []letterRule{
+	{letters: "tion", phones: []string{"SH", "AH", "N"}},
+	{letters: "sion", phones: []string{"ZH", "AH", "N"}},
+	{letters: "ture", phones: []string{"CH", "ER"}, atEnd: true},
+	{letters: "igh", phones: []string{"AY"}},
+	{letters: "tch", phones: []string{"CH"}},
+	{letters: "kn", phones: []string{"N"}, atStart: true},
+	{letters: "wr", phones: []string{"R"}, atStart: true},
+	{letters: "mb", phones: []string{"M"}, atEnd: true},
+	{letters: "gh", phones: []string{"G"}, atStart: true},
+	{letters: "gh", phones: []string{}},
+	{letters: "ch", phones: []string{"CH"}},
+	{letters: "sh", phones: []string{"SH"}},
+	{letters: "th", phones: []string{"TH"}},
+	{letters: "ph", phones: []string{"F"}},
+	{letters: "wh", phones: []string{"W"}},
+	{letters: "ck", phones: []string{"K"}},
+	{letters: "ng", phones: []string{"NG"}},
+	{letters: "nk", phones: []string{"NG", "K"}},
+	{letters: "qu", phones: []string{"K", "W"}},
+	{letters: "ee", phones: []string{"IY"}},
+	{letters: "ea", phones: []string{"IY"}},
+	{letters: "oo", phones: []string{"UW"}},
+	{letters: "ou", phones: []string{"AW"}},
+	{letters: "ow", phones: []string{"OW"}},
+	{letters: "oi", phones: []string{"OY"}},
+	{letters: "oy", phones: []string{"OY"}},
+	{letters: "ai", phones: []string{"EY"}},
+	{letters: "ay", phones: []string{"EY"}},
+	{letters: "au", phones: []string{"AO"}},
+	{letters: "aw", phones: []string{"AO"}},
+	{letters: "ew", phones: []string{"UW"}},
+	{letters: "oa", phones: []string{"OW"}},
+	{letters: "ue", phones: []string{"UW"}},
+	{letters: "ie", phones: []string{"AY"}, atEnd: true},
+	{letters: "ie", phones: []string{"IY"}},
+	{letters: "ei", phones: []string{"EY"}},
+}
+
+var shortVowels = map[byte]string{'a': "AE", 'e': "EH", 'i': "IH", 'o': "AA", 'u': "AH"}
+var longVowels = map[byte]string{'a': "EY", 'e': "IY", 'i': "AY", 'o': "OW", 'u': "UW"}
+var rColoredVowels = map[byte][]string{'a': {"AA", "R"}, 'o': {"AO", "R"}, 'e': {"ER"}, 'i': {"ER"}, 'u': {"ER"}}
+
+var letterConsonants = map[byte][]string{
+	'b': {"B"}, 'd': {"D"}, 'f': {"F"}, 'h': {"HH"}, 'j': {"JH"}, 'k': {"K"},
+	'l': {"L"}, 'm': {"M"}, 'n': {"N"}, 'p': {"P"}, 'q': {"K"}, 'r': {"R"},
+	't': {"T"}, 'v': {"V"}, 'w': {"W"}, 'z': {"Z"},
+}
+
+func isVowelLetter(c byte) bool {
+	return strings.IndexByte("aeiou", c) >= 0
+}
+
+// letterToSound строит примерное произношение незнакомого слова по правилам чтения.
+// Ударение ставится на первую гласную - для большинства английских слов этого достаточно.
+func letterToSound(word string) []string {
+	word = strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			return r
+		}
+		return -1
+	}, strings.ToLower(word))
+
+	vowelCount := 0
+	for i := 0; i < len(word); i++ {
+		if isVowelLetter(word[i]) {
+			vowelCount++
+		}
+	}
+
+	phones := make([]string, 0, len(word))
+	for i := 0; i < len(word); {
+		if rule, ok := matchLetterRule(word, i); ok {
+			phones = append(phones, rule.phones...)
+			i += len(rule.letters)
+			continue
+		}
+
+		c := word[i]
+		var next byte
+		if i+1 < len(word) {
+			next = word[i+1]
+		}
+
+		switch {
+		case c == 'e' && i == len(word)-1 && vowelCount > 1:
+			// немая конечная e
+		case isVowelLetter(c) && next == 'r' && (i+2 >= len(word) || !isVowelLetter(word[i+2])):
+			phones = append(phones, rColoredVowels[c]...)
+			i++
+		case isVowelLetter(c):
+			phones = append(phones, readVowel(word, i, vowelCount))
+		case c == 'y':
+			switch {
+			case i == 0:
+				phones = append(phones, "Y")
+			case i == len(word)-1 && vowelCount > 0:
+				phones = append(phones, "IY")
+			case i == len(word)-1:
+				phones = append(phones, "AY")
+			default:
+				phones = append(phones, "IH")
+			}
+		case c == 'c':
+			if next == 'e' || next == 'i' || next == 'y' {
+				phones = append(phones, "S")
+			} else {
+				phones = append(phones, "K")
+			}
+		case c == 'g':
+			if next == 'e' || next == 'i' || next == 'y' {
+				phones = append(phones, "JH")
+			} else {
+				phones = append(phones, "G")
+			}
+		case c == 'x':
+			if i == 0 {
+				phones = append(phones, "Z")
+			} else {
+				phones = append(phones, "K", "S")
+			}
+		case c == 's':
+			if i == len(word)-1 && i > 0 && word[i-1] != 's' && !strings.ContainsRune("ptkf", rune(word[i-1])) {
+				phones = append(phones, "Z")
+			} else {
+				phones = append(phones, "S")
+			}
+		default:
+			phones = append(phones, letterConsonants[c]...)
+		}
+
+		// удвоенная согласная читается как одна
+		if !isVowelLetter(c) && next == c {
+			i++
+		}
+		i++
+	}
+
+	return stressFirstVowel(phones)
+}
+
+func matchLetterRule(word string, i int) (letterRule, bool) {
+	for _, rule := range letterRules {
+		if !strings.HasPrefix(word[i:], rule.letters) {
+			continue
+		}
+		if rule.atStart && i != 0 {
+			continue
+		}
+		if rule.atEnd && i+len(rule.letters) != len(word) {
+			continue
+		}
+		return rule, true
+	}
+	return letterRule{}, false
+}
+
+// readVowel читает одиночную гласную: перед согласной и конечной e она долгая (make, home),
+// иначе краткая. Единственная гласная на конце слова тоже долгая (he, go).
+func readVowel(word string, i, vowelCount int) string {
+	c := word[i]
+	if i == len(word)-1 {
+		return longVowels[c]
+	}
+	if i+2 == len(word)-1 && word[i+2] == 'e' && !isVowelLetter(word[i+1]) && vowelCount > 1 {
+		return longVowels[c]
+	}
+	return shortVowels[c]
+}
+
+func stressFirstVowel(phones []string) []string {
+	stressed := false
+	for i, phone := range phones {
+		if !isArpabetVowel(phone) {
+			continue
+		}
+		if !stressed {
+			phones[i] = phone + "1"
+			stressed = true
+		} else {
+			phones[i] = phone + "0"
+		}
+	}
+	return phones
+}
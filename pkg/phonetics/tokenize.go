@@ -0,0 +1,187 @@
+package phonetics
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+var ErrInvalidIPA = errors.New("invalid transcription")
+
+// TokenKind - вид элемента разобранной транскрипции.
+type TokenKind int
+
+const (
+	PhonemeToken TokenKind = iota
+	PrimaryStress
+	SecondaryStress
+	SyllableBreak
+	WordBreak
+)
+
+type Token struct {
+	Kind   TokenKind
+	Symbol string
+}
+
+// IPA - разобранная транскрипция. Display - нормализованная запись для показа: без скобок
+// и косых черт, с единым написанием эквивалентных знаков. Phonemes - фонемы в написании
+// инвентаря, по ним считаются оценки и подбираются советы.
+type IPA struct {
+	Display  string
+	Tokens   []Token
+	Phonemes []string
+}
+
+// equivalents сводит разные написания одного знака к принятому в записи для показа.
+var equivalents = map[rune]rune{
+	'g':  'ɡ',
+	':':  'ː',
+	'꞉':  'ː',
+	'\'': 'ˈ',
+	'’':  'ˈ',
+	'ˊ':  'ˈ',
+	',':  ' ',
+	'|':  ' ',
+	'‖':  ' ',
+	'‿':  ' ',
+	'\t': ' ',
+	'\n': ' ',
+}
+
+// dropped - скобки и знаки узкой транскрипции, которые не меняют фонему: стяжка аффрикат,
+// слоговость, придыхание, назализация, r-окраска, полудолгота.
+var dropped = map[rune]bool{
+	'/':      true,
+	'[':      true,
+	']':      true,
+	'(':      true,
+	')':      true,
+	'\u0361': true, // стяжка t͡ʃ
+	'\u035C': true,
+	'\u0329': true, // слоговость n̩
+	'\u030D': true,
+	'\u0303': true, // назализация
+	'\u0325': true, // оглушение
+	'\u031A': true,
+	'˞':      true,
+	'ʰ':      true,
+	'ˑ':      true,
+}
+
+// symbols - все известные написания фонем, от самых длинных к коротким.
+var symbols []string
+
+func buildSymbols() {
+	for symbol := range inventory {
+		symbols = append(symbols, symbol)
+	}
+	for symbol := range aliases {
+		symbols = append(symbols, symbol)
+	}
+	sort.Slice(symbols, func(i, j int) bool {
+		li, lj := utf8.RuneCountInString(symbols[i]), utf8.RuneCountInString(symbols[j])
+		if li != lj {
+			return li > lj
+		}
+		return symbols[i] < symbols[j]
+	})
+}
+
+// normalize приводит эквивалентные написания к одному виду и схлопывает пробелы.
+func normalize(transcription string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(transcription) {
+		if dropped[r] {
+			continue
+		}
+		if eq, ok := equivalents[r]; ok {
+			r = eq
+		}
+		b.WriteRune(r)
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// parse разбирает нормализованную транскрипцию жадным поиском самого длинного символа
+// инвентаря. Неизвестные символы попадают в фонемы как есть и возвращаются отдельно.
+func parse(transcription string) (*IPA, []string) {
+	display := normalize(transcription)
+	result := &IPA{
+		Display:  display,
+		Tokens:   make([]Token, 0, len(display)),
+		Phonemes: make([]string, 0, len(display)),
+	}
+	var unknown []string
+
+	rest := display
+	for rest != "" {
+		r, size := utf8.DecodeRuneInString(rest)
+
+		kind := PhonemeToken
+		switch r {
+		case 'ˈ':
+			kind = PrimaryStress
+		case 'ˌ':
+			kind = SecondaryStress
+		case '.', '-':
+			kind = SyllableBreak
+		case ' ':
+			kind = WordBreak
+		}
+		if kind != PhonemeToken {
+			result.Tokens = append(result.Tokens, Token{Kind: kind, Symbol: string(r)})
+			rest = rest[size:]
+			continue
+		}
+
+		symbol := ""
+		for _, candidate := range symbols {
+			if strings.HasPrefix(rest, candidate) {
+				symbol = candidate
+				break
+			}
+		}
+
+		switch {
+		case symbol != "":
+			rest = rest[len(symbol):]
+			symbol = Canonical(symbol)
+		case r == 'ː' && len(result.Phonemes) > 0:
+			// знак долготы после фонемы без долгой пары (ɛː, ɒː) не меняет её
+			rest = rest[size:]
+			continue
+		default:
+			rest = rest[size:]
+			symbol = string(r)
+			unknown = append(unknown, symbol)
+		}
+
+		result.Tokens = append(result.Tokens, Token{Kind: PhonemeToken, Symbol: symbol})
+		result.Phonemes = append(result.Phonemes, symbol)
+	}
+
+	return result, unknown
+}
+
+// ParseIPA разбирает и нормализует транскрипцию. Неизвестные символы - ошибка ErrInvalidIPA.
+// Пустая транскрипция ошибкой не считается.
+func ParseIPA(transcription string) (*IPA, error) {
+	result, unknown := parse(transcription)
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("%w: unknown symbols %q", ErrInvalidIPA, strings.Join(unknown, " "))
+	}
+	if result.Display != "" && len(result.Phonemes) == 0 {
+		return nil, fmt.Errorf("%w: no phonemes", ErrInvalidIPA)
+	}
+	return result, nil
+}
+
+// Tokenize разбивает транскрипцию на фонемы. Неизвестные символы возвращаются как отдельные
+// фонемы, чтобы выравнивание их штрафовало.
+func Tokenize(transcription string) []string {
+	result, _ := parse(transcription)
+	return result.Phonemes
+}
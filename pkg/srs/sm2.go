@@ -0,0 +1,77 @@
+package srs
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	DefaultEase = 2.5
+	MinEase     = 1.3
+	// оценка ниже PassGrade считается забыванием и сбрасывает интервал
+	PassGrade = 3
+)
+
+// Card - состояние карточки интервального повторения по алгоритму SM-2.
+type Card struct {
+	Ease         float64
+	IntervalDays int
+	Repetitions  int
+	Lapses       int
+}
+
+// Schedule применяет оценку 0-5 к карточке и возвращает её новое состояние и время следующего показа.
+func Schedule(card Card, grade int, now time.Time) (Card, time.Time) {
+	if grade < 0 {
+		grade = 0
+	}
+	if grade > 5 {
+		grade = 5
+	}
+	if card.Ease == 0 {
+		card.Ease = DefaultEase
+	}
+
+	if grade < PassGrade {
+		card.Repetitions = 0
+		card.IntervalDays = 1
+		card.Lapses++
+	} else {
+		switch card.Repetitions {
+		case 0:
+			card.IntervalDays = 1
+		case 1:
+			card.IntervalDays = 6
+		default:
+			card.IntervalDays = int(math.Round(float64(card.IntervalDays) * card.Ease))
+		}
+		card.Repetitions++
+	}
+
+	q := float64(5 - grade)
+	card.Ease = math.Max(MinEase, card.Ease+0.1-q*(0.08+q*0.02))
+
+	return card, now.Add(time.Duration(card.IntervalDays) * 24 * time.Hour)
+}
+
+// GradeFromAttempt переводит результат попытки в оценку SM-2. Без балла успешная попытка
+// считается уверенным ответом, с баллом - оценка зависит от того, насколько он выше порога.
+func GradeFromAttempt(result string, score *int, passThreshold int) int {
+	if result != "completed" {
+		if score != nil && *score >= passThreshold/2 {
+			return 2
+		}
+		return 1
+	}
+
+	switch {
+	case score == nil:
+		return 4
+	case *score >= 95:
+		return 5
+	case *score >= passThreshold:
+		return 4
+	default:
+		return 3
+	}
+}
@@ -3,10 +3,12 @@ package utils
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
+	"sync"
 )
 
 type FileStorageClient struct {
@@ -102,3 +104,50 @@ func (c *FileStorageClient) GetFileLink(fileUUID string) (string, error) {
 
 	return data.Payload, nil
 }
+
+// fileLinkWorkers ограничивает число одновременных запросов к файловому хранилищу.
+const fileLinkWorkers = 8
+
+// GetFileLinks получает ссылки на несколько файлов параллельно. Повторяющиеся и пустые
+// идентификаторы запрашиваются не больше одного раза. Ссылки, которые удалось получить,
+// возвращаются вместе с ошибками остальных.
+func (c *FileStorageClient) GetFileLinks(fileUUIDs []string) (map[string]string, error) {
+	unique := make([]string, 0, len(fileUUIDs))
+	seen := make(map[string]bool, len(fileUUIDs))
+	for _, id := range fileUUIDs {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	links := make(map[string]string, len(unique))
+	var errs []error
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, fileLinkWorkers)
+
+	for _, id := range unique {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(id string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			link, err := c.GetFileLink(id)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("file %s: %w", id, err))
+				return
+			}
+			links[id] = link
+		}(id)
+	}
+	wg.Wait()
+
+	return links, errors.Join(errs...)
+}
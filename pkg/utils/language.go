@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParseAcceptLanguage возвращает языки из заголовка Accept-Language по убыванию веса q.
+// Регион отбрасывается (kk-KZ -> kk), языки с q=0 и "*" пропускаются, повторы убираются.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		lang string
+		q    float64
+	}
+
+	var items []weighted
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		lang := strings.ToLower(strings.TrimSpace(fields[0]))
+		if i := strings.IndexAny(lang, "-_"); i >= 0 {
+			lang = lang[:i]
+		}
+		if lang == "" || lang == "*" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || strings.TrimSpace(name) != "q" {
+				continue
+			}
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				parsed = 0
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		items = append(items, weighted{lang: lang, q: q})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool { return items[i].q > items[j].q })
+
+	result := make([]string, 0, len(items))
+	seen := make(map[string]bool)
+	for _, item := range items {
+		if !seen[item.lang] {
+			seen[item.lang] = true
+			result = append(result, item.lang)
+		}
+	}
+	return result
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -38,3 +39,24 @@ func TranscribeMLService(url string, file multipart.File, filename string, timeo
 
 	return client.Do(req)
 }
+
+// SynthesizeMLService запрашивает у ML-сервиса озвучку текста; в ответе приходит аудиофайл.
+func SynthesizeMLService(url string, text string, timeout time.Duration) (*http.Response, error) {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{
+		Timeout: timeout,
+	}
+
+	return client.Do(req)
+}